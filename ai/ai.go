@@ -1,93 +1,78 @@
+// Package ai is a compatibility layer for code written before the engine was
+// split into the blackjack and strategy packages. New code should import those
+// packages directly.
 package ai
 
 import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
 	"github.com/Scrimzay/blackjacksimulator/deck"
-	"fmt"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
 )
 
-// AI interface defines the behavior for different types of players (human or dealer).
+// AI is the interface AIs implemented before bet sizing moved out to
+// blackjack.Bettor. The engine still plays AIs written against it.
 type AI interface {
-	// Bet determines the amount a player wants to bet, considering if the deck was shuffled.
 	Bet(shuffled bool) int
-	
-	// Play takes the player's current hand and the dealer's visible card, returning the player's move.
 	Play(hand []deck.Card, dealer deck.Card) Move
-	
-	// Results provides feedback at the end of the round, showing the final hands.
 	Results(hand [][]deck.Card, dealer []deck.Card)
 }
 
-// dealerAI is the built-in AI for the dealer's moves.
-type dealerAI struct {}
+// Game is an alias for blackjack.Game.
+type Game = blackjack.Game
 
-// Bet is a no-op for the dealer since the dealer doesn't bet.
-func (ai dealerAI) Bet(shuffled bool) int {
-	return 1 // Returns a dummy value since the dealer doesn't bet.
+// Options is an alias for blackjack.Options.
+type Options = blackjack.Options
+
+// Insurer is an alias for blackjack.Insurer: an AI implementing it is offered
+// insurance against the dealer's ace.
+type Insurer = blackjack.Insurer
+
+// Move is an alias for blackjack.Move.
+type Move = blackjack.Move
+
+// Moves re-exported from the blackjack package.
+var (
+	MoveHit       = blackjack.MoveHit
+	MoveStand     = blackjack.MoveStand
+	MoveDouble    = blackjack.MoveDouble
+	MoveSplit     = blackjack.MoveSplit
+	MoveSurrender = blackjack.MoveSurrender // Where the table allows it, see Options.Surrender
+)
+
+// New initializes a Game, see blackjack.New.
+func New(opts Options) Game {
+	return blackjack.New(opts)
 }
 
-// Play determines the dealer's move based on blackjack rules:
-// - Hit on 16 or lower
-// - Hit on soft 17 (an Ace counted as 11)
-// - Otherwise, stand
-func (ai dealerAI) Play(hand []deck.Card, dealer deck.Card) Move {
-	dScore := Score(hand...)
-	if dScore <= 16 || (dScore == 17 && Soft(hand...)) {
-		return MoveHit
-	} 
-	return MoveStand
+// HumanAI returns a human-controlled AI, see strategy.HumanAI.
+func HumanAI() AI {
+	return legacy{strategy.HumanAI()}
 }
 
-// Results is a no-op for the dealer AI since it doesn’t need to process results.
-func (ai dealerAI) Results(hand [][]deck.Card, dealer []deck.Card) {}
+// legacy gives an AI the old Bet method.
+type legacy struct {
+	blackjack.AI
+}
 
-// humanAI represents a human player, requiring user input for actions.
-type humanAI struct {}
+func (l legacy) Bet(shuffled bool) int {
+	return blackjack.PlaceBet(l.AI, blackjack.BetContext{Shuffled: shuffled, MinBet: 100})
+}
 
-// HumanAI initializes and returns a human-controlled AI.
-func HumanAI() AI {
-	return humanAI{}
+func (l legacy) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return blackjack.TakeInsurance(l.AI, hand, dealer)
 }
 
-// Bet prompts the player to enter their bet amount. If the deck was shuffled, it notifies the player.
-func (ai humanAI) Bet(shuffled bool) int {
-	if shuffled {
-		fmt.Println("The deck was just shuffled")
-	}
-	fmt.Println("What would you like to bet?")
-	var bet int
-	fmt.Scanf("%d\n", &bet)
-	return bet
+// Score calculates the best possible score for a hand, see blackjack.Score.
+func Score(hand ...deck.Card) int {
+	return blackjack.Score(hand...)
 }
 
-// Play prompts the player to choose an action: hit, stand, double, or split.
-func (ai humanAI) Play(hand []deck.Card, dealer deck.Card) Move {
-	for {
-		fmt.Println("Player:", hand)
-		fmt.Println("Dealer:", dealer)
-		fmt.Println("What will you do? (h)it, (s)tand, (d)ouble or s(p)lit")
-		var input string
-		fmt.Scanf("%s\n", &input)
-		switch input {
-		case "h":
-			return MoveHit
-		case "s":
-			return MoveStand
-		case "d":
-			return MoveDouble
-		case "p":
-			return MoveSplit
-		default:
-			fmt.Println("Not a valid option.")
-		}
-	}
+// Soft reports whether the hand's score counts an Ace as 11, see blackjack.Soft.
+func Soft(hand ...deck.Card) bool {
+	return blackjack.Soft(hand...)
 }
 
-// Results displays the final hands of both the player and dealer at the end of the round.
-func (ai humanAI) Results(hands [][]deck.Card, dealer []deck.Card) {
-	fmt.Println("=== FINAL HANDS ===")
-	fmt.Println("Player:")
-	for _, h := range hands {
-		fmt.Println(" ", h)
-	}
-	fmt.Println("Dealer:", dealer)
-}
\ No newline at end of file
+// Blackjack identifies a natural, see blackjack.Blackjack.
+func Blackjack(hand ...deck.Card) bool {
+	return blackjack.Blackjack(hand...)
+}
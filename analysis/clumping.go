@@ -0,0 +1,51 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/count"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Clumping measures how much the high and low cards of a shoe clump together:
+// the correlation between the Hi-Lo tags of neighbouring cards. A perfectly
+// shuffled shoe of n cards averages -1/(n-1), a little below zero; the more
+// the cards clump, the higher it gets, up to 1.
+func Clumping(cards []deck.Card) float64 {
+	var c correlation
+	for i := 1; i < len(cards); i++ {
+		c.add(float64(count.HiLo(cards[i-1])), float64(count.HiLo(cards[i])))
+	}
+	sxx := c.xx - c.x*c.x/c.n
+	syy := c.yy - c.y*c.y/c.n
+	if c.n < 2 || sxx <= 0 || syy <= 0 {
+		return 0
+	}
+	return (c.xy - c.x*c.y/c.n) / math.Sqrt(sxx*syy)
+}
+
+// ClumpMeter shuffles like Permer, or perfectly if it's nil, and keeps the
+// Clumping of every shoe it shuffles, to set as Options.Shuffler.
+type ClumpMeter struct {
+	deck.Permer
+
+	Shoes    int     // Shoes shuffled
+	Clumping float64 // Average Clumping of the shoes
+}
+
+// PermCards shuffles the cards with Permer and measures the shoe it makes.
+func (m *ClumpMeter) PermCards(cards []deck.Card) []int {
+	var perm []int
+	switch p := m.Permer.(type) {
+	case nil:
+		perm = deck.Perfect.Perm(len(cards))
+	case blackjack.CardShuffler:
+		perm = p.PermCards(cards)
+	default:
+		perm = p.Perm(len(cards))
+	}
+	m.Shoes++
+	m.Clumping += (Clumping(deck.Permute(cards, perm)) - m.Clumping) / float64(m.Shoes)
+	return perm
+}
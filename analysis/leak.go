@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/count"
+)
+
+// BetLeak tests recorded rounds for bets that know the cards they were placed
+// before, which points at an AI that sees cards it shouldn't, through a shared
+// slice or a peek at the shoe. Rounds are grouped by the Hi-Lo true count the
+// bet was placed at, kept from the rounds themselves as a counter would keep
+// it, so a bet that follows the count legitimately doesn't count against it.
+// Within each group an honest bet is uncorrelated with what came next:
+//   - the round's cards: how far they moved the running count, beyond what
+//     the count already said they would
+//   - the round's result, per unit bet
+//
+// Each check is the pooled correlation within the groups as a z-score. Rounds
+// the seat sat out are left out, and flat bets pass trivially.
+func BetLeak(rounds []blackjack.RoundResult, decks int) []Check {
+	cards, result := map[int]*correlation{}, map[int]*correlation{}
+	counter := count.Counter{System: count.HiLo, Decks: decks}
+	for _, r := range rounds {
+		if r.Shuffled {
+			counter.Reset()
+		}
+		tc := int(math.Round(counter.True()))
+		before, seen := counter.Running(), counter.Seen()
+		left := max(decks*52-seen, 1)
+		for _, h := range r.Hands {
+			counter.Observe(h.Cards...)
+		}
+		counter.Observe(r.Dealer...)
+		counter.EndRound()
+		if !r.Seated || r.Bet == 0 {
+			continue
+		}
+		if cards[tc] == nil {
+			cards[tc], result[tc] = &correlation{}, &correlation{}
+		}
+		// The cards left in a balanced count tag up to minus the running
+		// count, so the round's cards are expected to move it by their
+		// share of that; only what they do beyond it is news.
+		drawn := counter.Seen() - seen
+		expected := -float64(before) * float64(drawn) / float64(left)
+		cards[tc].add(float64(r.Bet), float64(counter.Running()-before)-expected)
+		result[tc].add(float64(r.Bet), float64(r.Net)/float64(r.Bet))
+	}
+	cz, rz := pooledZ(cards), pooledZ(result)
+	return []Check{
+		{Name: "bets and the round's cards (z)", Statistic: cz, PValue: normalP(cz)},
+		{Name: "bets and the round's result (z)", Statistic: rz, PValue: normalP(rz)},
+	}
+}
+
+// correlation accumulates the sums for the correlation of x and y.
+type correlation struct {
+	n, x, y, xx, yy, xy float64
+}
+
+func (c *correlation) add(x, y float64) {
+	c.n++
+	c.x += x
+	c.y += y
+	c.xx += x * x
+	c.yy += y * y
+	c.xy += x * y
+}
+
+// pooledZ returns the correlation within groups, pooled over them, as a
+// z-score by Fisher's transformation: 0 if there's nothing to correlate.
+func pooledZ(groups map[int]*correlation) float64 {
+	var sxx, syy, sxy, df float64
+	for _, c := range groups {
+		sxx += c.xx - c.x*c.x/c.n
+		syy += c.yy - c.y*c.y/c.n
+		sxy += c.xy - c.x*c.y/c.n
+		df += c.n - 1
+	}
+	if sxx <= 0 || syy <= 0 || df < 4 {
+		return 0
+	}
+	r := max(min(sxy/math.Sqrt(sxx*syy), 0.999999), -0.999999)
+	return math.Atanh(r) * math.Sqrt(df-2)
+}
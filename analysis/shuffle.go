@@ -0,0 +1,96 @@
+// Package analysis contains statistical checks and reports computed from
+// shufflers, games and recorded play.
+package analysis
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Significance is the p-value below which a check is considered failed. It is
+// deliberately strict since a report runs several checks at once.
+const Significance = 0.001
+
+// Check is the outcome of one statistical test.
+type Check struct {
+	Name      string  // What was tested
+	Statistic float64 // Test statistic (chi-square or z-score)
+	PValue    float64 // Chance of a statistic at least this extreme from a perfect shuffle
+}
+
+// Passed reports whether the shuffler is consistent with a uniform shuffle.
+func (c Check) Passed() bool {
+	return c.PValue >= Significance
+}
+
+// CheckShuffler shuffles n cards trials times with p and tests the results
+// against what a perfectly uniform shuffle would produce:
+//   - positions: every card is equally likely to end up in every position
+//     (chi-square over the n×n position counts)
+//   - rising sequences: the number of rising sequences averages (n+1)/2, riffles
+//     with too few passes leave far fewer
+//   - neighbors: a card is followed by its original neighbor 1 time in n
+func CheckShuffler(p deck.Permer, n, trials int) []Check {
+	positions := make([][]int, n) // positions[card][position]
+	for i := range positions {
+		positions[i] = make([]int, n)
+	}
+	pos := make([]int, n)
+	rising, neighbors := 0.0, 0.0
+	for t := 0; t < trials; t++ {
+		perm := p.Perm(n)
+		for i, card := range perm {
+			positions[card][i]++
+			pos[card] = i
+		}
+		for card := 0; card < n-1; card++ {
+			if pos[card+1] < pos[card] {
+				rising++
+			}
+			if pos[card+1] == pos[card]+1 {
+				neighbors++
+			}
+		}
+		rising++ // A deck always has at least one rising sequence
+	}
+
+	expected := float64(trials) / float64(n)
+	chi := 0.0
+	for _, row := range positions {
+		for _, observed := range row {
+			d := float64(observed) - expected
+			chi += d * d / expected
+		}
+	}
+	df := (n - 1) * (n - 1)
+
+	// Rising sequences of a uniform permutation: mean (n+1)/2, variance (n+1)/12
+	rMean := float64(n+1) / 2
+	rZ := (rising/float64(trials) - rMean) / math.Sqrt(float64(n+1)/12/float64(trials))
+
+	// Original neighbors kept: mean (n-1)/n, roughly Poisson
+	nMean := float64(n-1) / float64(n)
+	nZ := (neighbors/float64(trials) - nMean) / math.Sqrt(nMean/float64(trials))
+
+	return []Check{
+		{Name: fmt.Sprintf("positions (chi-square, %d df)", df), Statistic: chi, PValue: chiSquareP(chi, df)},
+		{Name: "rising sequences (z)", Statistic: rZ, PValue: normalP(rZ)},
+		{Name: "original neighbors (z)", Statistic: nZ, PValue: normalP(nZ)},
+	}
+}
+
+// chiSquareP returns the upper tail probability of a chi-square statistic,
+// using the Wilson-Hilferty normal approximation, which is accurate for the
+// large degrees of freedom used here.
+func chiSquareP(chi float64, df int) float64 {
+	k := float64(df)
+	z := (math.Cbrt(chi/k) - (1 - 2/(9*k))) / math.Sqrt(2/(9*k))
+	return 0.5 * math.Erfc(z/math.Sqrt2)
+}
+
+// normalP returns the two-sided p-value of a z-score.
+func normalP(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// Bench workload: the basic AI at a six-deck table with the engine's default
+// rules, dealt from a fixed seed so every run plays the same hands.
+const (
+	benchDecks = 6
+	benchSeed  = 1
+)
+
+// bench runs the standard workload and reports how fast the engine is, so
+// results can be compared across releases and machines.
+func bench(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	hands := fs.Int("hands", 1000000, "hands to play in each run")
+	shoes := fs.Int("shoes", 100000, "shoes to build and shuffle in each run")
+	runs := fs.Int("runs", 3, "times to repeat the workload; the fastest run is reported")
+	reuse := fs.Bool("reuse", false, "recycle each round's slices (Options.Reuse)")
+	batch := fs.Int("batch", 0, "shuffle this many shoes before timing and deal from them, to time the game loop alone")
+	printer := outputFlags(fs)
+	profile := profileFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+	defer profile()()
+
+	var best benchResult
+	for i := 0; i < *runs; i++ {
+		r := benchRun(*hands, *shoes, *reuse, *batch)
+		if i == 0 || r.handsPerSec > best.handsPerSec {
+			best.handsPerSec = r.handsPerSec
+			best.allocsPerHand, best.bytesPerHand = r.allocsPerHand, r.bytesPerHand
+		}
+		best.shufflesPerSec = max(best.shufflesPerSec, r.shufflesPerSec)
+	}
+
+	out.Printf("%s %s/%s, %d CPUs\n", runtime.Version(), runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+	out.Printf("%d hands and %d shoes of %d decks, best of %d\n\n", *hands, *shoes, benchDecks, *runs)
+	out.Table([][]string{
+		{"Hands/sec:", fmt.Sprintf("%.0f", best.handsPerSec)},
+		{"Allocations/hand:", fmt.Sprintf("%.1f", best.allocsPerHand)},
+		{"Bytes/hand:", fmt.Sprintf("%.0f", best.bytesPerHand)},
+		{"Shuffles/sec:", fmt.Sprintf("%.0f", best.shufflesPerSec)},
+	})
+}
+
+// benchResult is the outcome of one run of the workload.
+type benchResult struct {
+	handsPerSec    float64
+	allocsPerHand  float64
+	bytesPerHand   float64
+	shufflesPerSec float64
+}
+
+// benchRun plays the workload once.
+func benchRun(hands, shoes int, reuse bool, batch int) benchResult {
+	var r benchResult
+	opts := blackjack.Options{Decks: benchDecks, Hands: hands, Seed: benchSeed, Reuse: reuse}
+	if batch > 0 {
+		b := deck.NewShoeBatch(rand.New(rand.NewSource(benchSeed)), len(blackjack.Classic.Shoe(benchDecks)), batch)
+		opts.Shuffler = b.Stream(0)
+	}
+	g := blackjack.New(opts)
+	ai := strategy.BasicAI(benchDecks)
+
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	g.Play(ai)
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	played := float64(g.Played())
+	r.handsPerSec = played / elapsed.Seconds()
+	r.allocsPerHand = float64(after.Mallocs-before.Mallocs) / played
+	r.bytesPerHand = float64(after.TotalAlloc-before.TotalAlloc) / played
+
+	shoe := blackjack.Classic.Shoe(benchDecks)
+	rnd := rand.New(rand.NewSource(benchSeed))
+	start = time.Now()
+	for i := 0; i < shoes; i++ {
+		shoe = deck.Permute(shoe, rnd.Perm(len(shoe)))
+	}
+	r.shufflesPerSec = float64(shoes) / time.Since(start).Seconds()
+	return r
+}
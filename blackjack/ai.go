@@ -0,0 +1,32 @@
+package blackjack
+
+import "github.com/Scrimzay/blackjacksimulator/deck"
+
+// AI interface defines the behavior for different types of players (human or dealer).
+type AI interface {
+	// Play takes the player's current hand and the dealer's visible card, returning the player's move.
+	Play(hand []deck.Card, dealer deck.Card) Move
+
+	// Results provides feedback at the end of the round, showing the final hands.
+	Results(hand [][]deck.Card, dealer []deck.Card)
+}
+
+// dealerAI is the built-in AI for the dealer's moves.
+type dealerAI struct {
+	standSoft17 bool // Stand on soft 17 instead of hitting it
+}
+
+// Play determines the dealer's move based on blackjack rules:
+// - Hit on 16 or lower
+// - Hit on soft 17 (an Ace counted as 11), unless the table stands on it
+// - Otherwise, stand
+func (ai dealerAI) Play(hand []deck.Card, dealer deck.Card) Move {
+	dScore := Score(hand...)
+	if dScore <= 16 || (dScore == 17 && Soft(hand...) && !ai.standSoft17) {
+		return MoveHit
+	}
+	return MoveStand
+}
+
+// Results is a no-op for the dealer AI since it doesn’t need to process results.
+func (ai dealerAI) Results(hand [][]deck.Card, dealer []deck.Card) {}
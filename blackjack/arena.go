@@ -0,0 +1,68 @@
+package blackjack
+
+import "github.com/Scrimzay/blackjacksimulator/deck"
+
+// arena hands out the slices a round needs from buffers that are reused every
+// round, when Options.Reuse is set, so that long simulations make next to no
+// garbage. Slices are cut with a fixed capacity: appending past it moves the
+// slice elsewhere rather than writing over its neighbour.
+type arena struct {
+	cards   []deck.Card
+	actions []Action
+	hands   []hand
+	results []HandResult
+	all     [][]deck.Card
+	count   Count
+}
+
+// reset makes the buffers available to the next round.
+func (a *arena) reset() {
+	a.cards = a.cards[:0]
+	a.actions = a.actions[:0]
+}
+
+// newCards returns an empty slice with room for n cards, from the arena if the
+// game reuses its slices.
+func (g *Game) newCards(n int) []deck.Card {
+	a := g.arena
+	if a == nil {
+		return make([]deck.Card, 0, n)
+	}
+	if len(a.cards)+n > cap(a.cards) {
+		a.cards = make([]deck.Card, 0, max(2*cap(a.cards), 64, n))
+	}
+	i := len(a.cards)
+	a.cards = a.cards[:i+n]
+	return a.cards[i : i : i+n]
+}
+
+// copyCards returns a copy of cards, from the arena if the game reuses its
+// slices.
+func (g *Game) copyCards(cards []deck.Card) []deck.Card {
+	return append(g.newCards(len(cards)), cards...)
+}
+
+// newActions returns an empty slice for a hand's moves.
+func (g *Game) newActions() []Action {
+	const n = 4
+	a := g.arena
+	if a == nil {
+		return nil
+	}
+	if len(a.actions)+n > cap(a.actions) {
+		a.actions = make([]Action, 0, max(2*cap(a.actions), 64))
+	}
+	i := len(a.actions)
+	a.actions = a.actions[:i+n]
+	return a.actions[i : i : i+n]
+}
+
+// keepCount returns a pointer to c for a RoundResult, from the arena if the
+// game reuses its slices.
+func (g *Game) keepCount(c Count) *Count {
+	if g.arena == nil {
+		return &c
+	}
+	g.arena.count = c
+	return &g.arena.count
+}
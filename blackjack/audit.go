@@ -0,0 +1,148 @@
+package blackjack
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// With Options.Audit the engine checks that AIs only ever get copies of what
+// they're allowed to see. Around every call that hands an AI slices, it takes
+// a fingerprint of the game's cards, chips and bets and panics if the call
+// changed it. It then scribbles random canary cards over the slices it passed,
+// out to their capacity, and panics if that changes the fingerprint too, which
+// catches a slice sharing memory with the game before any AI writes to it. The
+// canaries are undone before play goes on, so AIs that keep what they were
+// passed still see what they were given.
+
+// auditor draws the canaries, apart from the game's own randomness so an
+// audited game deals the same shoes.
+type auditor struct {
+	rand *rand.Rand
+}
+
+// newAuditor returns the auditor for a game seeded with seed, random if 0.
+func newAuditor(seed int64) *auditor {
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+	return &auditor{rand: rand.New(rand.NewSource(deriveSeed(seed, streamAudit)))}
+}
+
+// fingerprint is an FNV-1a hash of the game's state.
+type fingerprint uint64
+
+func (f *fingerprint) add(n int) {
+	*f = (*f ^ fingerprint(n)) * 1099511628211
+}
+
+func (f *fingerprint) cards(cards []deck.Card) {
+	f.add(len(cards))
+	for _, c := range cards {
+		f.add(int(c.Suit)<<8 | int(c.Rank))
+	}
+}
+
+// fingerprint returns the fingerprint of everything an AI must not be able to
+// change: the shoe, the discards, the hands in play, the bets and the chips.
+// It's 0 if the game isn't audited.
+func (g *Game) fingerprint() fingerprint {
+	if g.audit == nil {
+		return 0
+	}
+	f := fingerprint(14695981039346656037)
+	f.cards(g.deck)
+	f.cards(g.discards)
+	f.cards(g.dealer)
+	f.cards(g.forcedDealer)
+	for _, h := range g.player {
+		f.cards(h.start)
+		f.cards(h.cards)
+		for _, a := range h.actions {
+			f.add(len(a.Move))
+			f.add(int(a.Card.Suit)<<8 | int(a.Card.Rank))
+		}
+		f.add(h.bet)
+	}
+	f.add(len(g.chips))
+	for _, c := range g.chips {
+		f.add(c)
+	}
+	f.add(g.playerBet)
+	f.add(g.balance)
+	return f
+}
+
+// audited checks a call to the AI that was passed passed, given the game's
+// fingerprint from before the call.
+func (g *Game) audited(call string, before fingerprint, passed ...any) {
+	if g.fingerprint() != before {
+		panic(fmt.Sprintf("Audit: the game changed during %s, through something an AI was passed", call))
+	}
+	var undo []func()
+	for _, p := range passed {
+		undo = g.audit.scribble(undo, p)
+	}
+	leaked := g.fingerprint() != before
+	for _, u := range undo {
+		u()
+	}
+	if leaked {
+		panic(fmt.Sprintf("Audit: what was passed to %s shares memory with the game", call))
+	}
+}
+
+// scribble writes canaries over p, appending the functions that undo them.
+func (a *auditor) scribble(undo []func(), p any) []func() {
+	switch p := p.(type) {
+	case []deck.Card:
+		return append(undo, overwrite(p, a.card))
+	case Hand:
+		return append(undo, overwrite(p, a.card))
+	case [][]deck.Card:
+		for _, cards := range p {
+			undo = a.scribble(undo, cards)
+		}
+		return undo
+	case []int:
+		return append(undo, overwrite(p, a.rand.Int))
+	case []Action:
+		return append(undo, overwrite(p, func() Action { return Action{Card: a.card()} }))
+	case []Slug:
+		for _, s := range p {
+			undo = append(undo, overwrite(s.Cards, a.card), overwrite(s.Positions, a.rand.Int))
+		}
+		return undo
+	case GameView:
+		return a.scribble(undo, p.Hand)
+	case BetContext:
+		return a.scribble(undo, p.Chips)
+	case RoundResult:
+		undo = a.scribble(undo, p.Dealer)
+		for _, h := range p.Hands {
+			undo = a.scribble(undo, h.Start)
+			undo = a.scribble(undo, h.Cards)
+			undo = a.scribble(undo, h.Actions)
+		}
+		return undo
+	}
+	panic(fmt.Sprintf("Audit: can't check a %T", p))
+}
+
+// card returns a random canary card.
+func (a *auditor) card() deck.Card {
+	return deck.Card{Suit: deck.Suit(a.rand.Intn(4)), Rank: deck.Rank(1 + a.rand.Intn(int(deck.King)))}
+}
+
+// overwrite fills s out to its capacity with canaries and returns the function
+// that puts it back.
+func overwrite[T any](s []T, canary func() T) func() {
+	full := s[:cap(s)]
+	saved := slices.Clone(full)
+	for i := range full {
+		full[i] = canary()
+	}
+	return func() { copy(full, saved) }
+}
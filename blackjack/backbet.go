@@ -0,0 +1,53 @@
+package blackjack
+
+// BackBettor is a passive player who bets behind the seat: they wager on the
+// seat's hands from their own bankroll but never act on them. A back bet follows
+// the seat's splits and doubles, so it always wins or loses in proportion to the
+// seat's own result.
+type BackBettor interface {
+	// BetBehind returns the amount wagered behind the seat this round, 0 to sit it out.
+	BetBehind(shuffled bool) int
+}
+
+// backer tracks a back bettor's wager and balance.
+type backer struct {
+	bettor  BackBettor
+	bet     int // Amount wagered this round
+	seatBet int // The seat's original bet this round
+	balance int // Back bettor's balance
+}
+
+// AddBackBettor lets b bet behind the seat in every following round and returns
+// the index used to look up their balance with BackBalance.
+func (g *Game) AddBackBettor(b BackBettor) int {
+	g.backers = append(g.backers, backer{bettor: b})
+	return len(g.backers) - 1
+}
+
+// BackBalance returns the balance of the i-th back bettor.
+func (g *Game) BackBalance(i int) int {
+	return g.backers[i].balance
+}
+
+// betBehind collects the back bets for the round.
+func betBehind(g *Game, shuffled bool) {
+	for i := range g.backers {
+		bet := g.backers[i].bettor.BetBehind(shuffled)
+		if bet < 0 {
+			panic("Back bets can't be negative")
+		}
+		g.backers[i].bet = bet
+		g.backers[i].seatBet = g.playerBet
+	}
+}
+
+// settleBehind pays the back bets in proportion to the seat's winnings on its
+// original bet.
+func settleBehind(g *Game, winnings int) {
+	for i := range g.backers {
+		if g.backers[i].bet == 0 {
+			continue
+		}
+		g.backers[i].balance += g.backers[i].bet * winnings / g.backers[i].seatBet
+	}
+}
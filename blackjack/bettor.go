@@ -0,0 +1,148 @@
+package blackjack
+
+import "slices"
+
+// BetContext is what the engine tells a Bettor before each bet.
+type BetContext struct {
+	Shuffled  bool    // Whether the shoe was shuffled since the last bet
+	Bankroll  int     // Options.Bankroll plus rebuys and everything won or lost so far
+	MinBet    int     // Table minimum
+	MaxBet    int     // Table maximum, or the bankroll if that's smaller; 0 for no limit
+	Increment int     // Bets must be a multiple of this, any amount if 0
+	Chips     []int   // Chip denominations bets are made up of, any amount if empty
+	Decks     int     // Number of decks in the shoe
+	ShoeSize  int     // Number of cards in a full shoe
+	CardsLeft int     // Number of cards left to deal before the shoe is rebuilt
+	TrueCount float64 // The engine's true count, if Counted
+	Running   int     // The engine's running count, if Counted
+	Counted   bool    // Whether the engine keeps a count (Options.Count is set)
+
+	Composition Composition // The unseen cards by rank, if Options.Composition is set
+}
+
+// DecksLeft returns the number of decks left in the shoe, as a true count
+// divides by.
+func (c BetContext) DecksLeft() float64 {
+	if c.ShoeSize == 0 {
+		return 0
+	}
+	return float64(c.CardsLeft) * float64(c.Decks) / float64(c.ShoeSize)
+}
+
+// Bettor decides how much to bet each round. Playing and betting strategies
+// can be mixed by setting Options.Bettor; otherwise an AI that is also a
+// Bettor places its own bets.
+type Bettor interface {
+	Bet(ctx BetContext) int
+}
+
+// Cloner is implemented by bettors that can be copied, so that branches from
+// a Snapshot bet with a copy instead of changing the game's own bettor. A
+// bettor with no state of its own can return itself. Only games whose
+// Options.Bettor is a Cloner can be branched.
+type Cloner interface {
+	Clone() Bettor
+}
+
+// legacyBettor is an AI written before Bettor, which only learns whether the
+// shoe was shuffled.
+type legacyBettor interface {
+	Bet(shuffled bool) int
+}
+
+// PlaceBet returns the bet ai makes in ctx: from its Bet method if it is a
+// Bettor or has the older Bet(shuffled bool), or else the table minimum.
+// Wrapping AIs use it to ask the AI they wrap.
+func PlaceBet(ai AI, ctx BetContext) int {
+	switch b := ai.(type) {
+	case Bettor:
+		return b.Bet(ctx)
+	case legacyBettor:
+		return b.Bet(ctx.Shuffled)
+	default:
+		return ctx.MinBet
+	}
+}
+
+// betContext returns the context for the coming bet.
+func (g *Game) betContext(shuffled bool) BetContext {
+	ctx := BetContext{
+		Shuffled:  shuffled,
+		Bankroll:  g.bankroll + g.rebought + g.balance,
+		MinBet:    g.minBet,
+		MaxBet:    g.maxBet,
+		Increment: g.betIncrement,
+		Chips:     g.chips,
+
+		Decks:     g.nDecks,
+		ShoeSize:  g.shoeSize,
+		CardsLeft: len(g.deck),
+	}
+	if g.bankroll > 0 && (ctx.MaxBet == 0 || ctx.Bankroll < ctx.MaxBet) {
+		ctx.MaxBet = ctx.Bankroll // Nobody bets money they didn't bring
+	}
+	if c, ok := g.Count(); ok {
+		ctx.TrueCount, ctx.Running, ctx.Counted = c.True, c.Running, true
+	}
+	if g.composed {
+		ctx.Composition = g.composition
+	}
+	return ctx
+}
+
+// bet takes the seat's bet for the round from Options.Bettor or the AI.
+func bet(g *Game, ai AI, shuffled bool) {
+	ctx := g.betContext(shuffled)
+	placed := ctx
+	placed.Chips = slices.Clone(ctx.Chips) // The table's chips stay the table's
+	before := g.fingerprint()
+	var bet int
+	if g.bettor != nil {
+		bet = g.bettor.Bet(placed)
+		g.sizer, _ = g.bettor.(DoubleSizer)
+	} else {
+		bet = PlaceBet(ai, placed)
+		g.sizer, _ = ai.(DoubleSizer)
+	}
+	if g.audit != nil {
+		g.audited("Bet", before, placed)
+	}
+	if err := ctx.Check(bet); err != nil {
+		panic(err.Error())
+	}
+	g.playerBet = bet
+	g.round.Bet = bet
+}
+
+// DoubleSizer is implemented by bettors that double for less than the whole
+// bet, such as ones that can't afford a full double near the end of their
+// bankroll. Play asks it whenever the seat plays MoveDouble.
+type DoubleSizer interface {
+	// DoubleFor returns how much to double for, from 1 to ctx.MaxBet. In ctx,
+	// Bankroll is what's left once the bets on the table are covered, and
+	// MaxBet is the hand's bet, or what's left if Options.Bankroll is set and
+	// that's less, but never under 1.
+	DoubleFor(ctx BetContext) int
+}
+
+// doubleSize returns how much to double a hand bet bet for.
+func (g *Game) doubleSize(bet int) int {
+	if g.sizer == nil {
+		return bet
+	}
+	ctx := g.betContext(false)
+	ctx.Chips = slices.Clone(ctx.Chips)
+	for _, h := range g.player {
+		ctx.Bankroll -= h.bet
+	}
+	ctx.MaxBet = bet
+	if g.bankroll > 0 {
+		ctx.MaxBet = max(min(bet, ctx.Bankroll), 1)
+	}
+	before := g.fingerprint()
+	amount := g.sizer.DoubleFor(ctx)
+	if g.audit != nil {
+		g.audited("DoubleFor", before, ctx)
+	}
+	return amount
+}
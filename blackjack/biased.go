@@ -0,0 +1,110 @@
+package blackjack
+
+import (
+	"math/rand"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Deliberately biased shoes, for checking that counts, bettors and strategies
+// respond the way they should to extreme ones. TenRich and AcePoor take cards
+// out of a composition, to deal with Options.Remaining; Clumped is a shuffle
+// that deals like cards in runs.
+
+// TenRich returns c with n cards taken out of the twos to nines, a rank at a
+// time, as if they'd been dealt. What's left is rich in tens, and in aces.
+func TenRich(c Composition, n int) Composition {
+	return without(c, n, deck.Two, deck.Nine)
+}
+
+// AcePoor returns c with n aces taken out, as if they'd been dealt.
+func AcePoor(c Composition, n int) Composition {
+	return without(c, n, deck.Ace, deck.Ace)
+}
+
+// without takes n cards out of c, one of each rank from lo to hi in turn,
+// skipping ranks that have run out.
+func without(c Composition, n int, lo, hi deck.Rank) Composition {
+	for r := lo; n > 0; r++ {
+		if r > hi {
+			r = lo
+			if c.Left() == 0 || !hasAny(c, lo, hi) {
+				break
+			}
+		}
+		if c[r] > 0 {
+			c[r]--
+			n--
+		}
+	}
+	return c
+}
+
+// hasAny reports whether c holds any cards ranked lo to hi.
+func hasAny(c Composition, lo, hi deck.Rank) bool {
+	for r := lo; r <= hi; r++ {
+		if c[r] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Clumped is a shuffle that deals the shoe in runs of like cards: high cards
+// (tens and aces), low cards (twos to sixes) and the sevens to nines in
+// between. Each run is Run cards of one kind, drawn at random from the kinds
+// left in proportion to how many are left, so the shoe holds the same cards
+// and the count still ends at zero, but it swings far wider along the way.
+type Clumped struct {
+	Run  int        // Cards in a run, 8 if 0
+	Rand *rand.Rand // Source of randomness, deck.Shuffle's if nil
+}
+
+// Perm shuffles n cards perfectly; Clumped needs to see the cards to clump
+// them, which the engine does through PermCards.
+func (c Clumped) Perm(n int) []int {
+	if c.Rand == nil {
+		return deck.Perfect.Perm(n)
+	}
+	return c.Rand.Perm(n)
+}
+
+// PermCards returns the permutation dealing cards in clumps.
+func (c Clumped) PermCards(cards []deck.Card) []int {
+	run := c.Run
+	if run == 0 {
+		run = 8
+	}
+	var kinds [3][]int // Positions of the high, middle and low cards, shuffled
+	for _, j := range c.Perm(len(cards)) {
+		switch v := cards[j].BlackjackValue(); {
+		case v == 1 || v >= 10:
+			kinds[0] = append(kinds[0], j)
+		case v >= 7:
+			kinds[1] = append(kinds[1], j)
+		default:
+			kinds[2] = append(kinds[2], j)
+		}
+	}
+	perm := make([]int, 0, len(cards))
+	for left := len(cards); left > 0; {
+		pick := c.intn(left)
+		k := 0
+		for pick >= len(kinds[k]) {
+			pick -= len(kinds[k])
+			k++
+		}
+		n := min(run, len(kinds[k]))
+		perm = append(perm, kinds[k][:n]...)
+		kinds[k] = kinds[k][n:]
+		left -= n
+	}
+	return perm
+}
+
+func (c Clumped) intn(n int) int {
+	if c.Rand == nil {
+		return rand.Intn(n)
+	}
+	return c.Rand.Intn(n)
+}
@@ -0,0 +1,39 @@
+package blackjack
+
+import "github.com/Scrimzay/blackjacksimulator/deck"
+
+// BurnWatcher is implemented by AIs that count the burned cards when the
+// dealer shows them. Play calls Burned once the first bets of a shoe are in,
+// before the first deal, when Options.ShowBurn is set.
+type BurnWatcher interface {
+	Burned(cards []deck.Card)
+}
+
+// burn discards the cards burned at the start of a shoe, showing them to the
+// AI and the engine's count if the table shows burn cards.
+func (g *Game) burn(ai AI) {
+	if g.nBurn == 0 {
+		return
+	}
+	burned := make([]deck.Card, 0, g.nBurn)
+	for i := 0; i < g.nBurn && len(g.deck) > 0; i++ {
+		var card deck.Card
+		card, g.deck = deck.Draw(g.deck)
+		if g.events != nil {
+			g.emit(Event{Kind: EventBurn, Card: &card})
+		}
+		burned = append(burned, card)
+	}
+	g.discards = append(g.discards, burned...)
+	if !g.showBurn {
+		return
+	}
+	g.observe(burned...)
+	if bw, ok := ai.(BurnWatcher); ok {
+		before := g.fingerprint()
+		bw.Burned(burned)
+		if g.audit != nil {
+			g.audited("Burned", before, burned)
+		}
+	}
+}
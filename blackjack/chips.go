@@ -0,0 +1,95 @@
+package blackjack
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Check returns an error if bet isn't one the table takes: below the minimum,
+// above the maximum, not a multiple of the increment or not something the
+// table's chips add up to.
+func (c BetContext) Check(bet int) error {
+	if bet < c.MinBet {
+		return fmt.Errorf("Bet must be at least %d", c.MinBet)
+	}
+	if c.MaxBet > 0 && bet > c.MaxBet {
+		return fmt.Errorf("Bet must be at most %d", c.MaxBet)
+	}
+	if c.Increment > 1 && bet%c.Increment != 0 {
+		return fmt.Errorf("Bet must be a multiple of %d", c.Increment)
+	}
+	if len(c.Chips) > 0 && !makeable(bet, c.Chips) {
+		return fmt.Errorf("Bet of %d can't be made with chips of %v", bet, c.Chips)
+	}
+	return nil
+}
+
+// Round returns the largest bet the table takes that is no more than bet, or
+// the smallest it takes if bet is below that. Bettors use it to turn the
+// amount they'd like to bet into one they can place.
+func (c BetContext) Round(bet int) int {
+	if c.MaxBet > 0 {
+		bet = min(bet, c.MaxBet)
+	}
+	for b := bet; b >= c.MinBet; b-- {
+		if c.Check(b) == nil {
+			return b
+		}
+	}
+	for b := max(bet, c.MinBet); c.MaxBet == 0 || b <= c.MaxBet; b++ {
+		if c.Check(b) == nil {
+			return b
+		}
+	}
+	return c.MinBet
+}
+
+// checkChips returns an error if the chip denominations can't be used.
+func checkChips(chips []int) error {
+	for _, c := range chips {
+		if c <= 0 {
+			return errors.New("Chip denominations must be positive")
+		}
+	}
+	return nil
+}
+
+// makeable reports whether amount is a sum of chips, using as many of each
+// denomination as needed.
+func makeable(amount int, chips []int) bool {
+	if amount < 0 {
+		return false
+	}
+	g := 0
+	for _, c := range chips {
+		g = gcd(g, c)
+	}
+	if g == 0 || amount%g != 0 {
+		return false
+	}
+	// Past the product of the smallest and largest chips every multiple of
+	// their common divisor can be made, so only small amounts need working out.
+	lo, hi := slices.Min(chips), slices.Max(chips)
+	if amount > lo*hi {
+		return true
+	}
+	can := make([]bool, amount+1)
+	can[0] = true
+	for a := 1; a <= amount; a++ {
+		for _, c := range chips {
+			if c <= a && can[a-c] {
+				can[a] = true
+				break
+			}
+		}
+	}
+	return can[amount]
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,141 @@
+package blackjack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Composition is the number of cards of each rank the player hasn't seen since
+// the shuffle: the cards left in the shoe, plus the hole card and any burn
+// cards dealt face down. It's indexed by deck.Rank; index 0 counts jokers.
+type Composition [deck.King + 1]int
+
+// Compose returns the composition of cards.
+func Compose(cards []deck.Card) Composition {
+	return Composition(deck.CountRanks(cards))
+}
+
+// Add puts cards back in the composition.
+func (c *Composition) Add(cards ...deck.Card) {
+	for _, card := range cards {
+		c[c.index(card)]++
+	}
+}
+
+// Remove takes seen cards out of the composition.
+func (c *Composition) Remove(cards ...deck.Card) {
+	for _, card := range cards {
+		c[c.index(card)]--
+	}
+}
+
+func (c *Composition) index(card deck.Card) deck.Rank {
+	if card.Suit == deck.Joker {
+		return 0
+	}
+	return card.Rank
+}
+
+// Left returns the number of unseen cards.
+func (c Composition) Left() int {
+	n := 0
+	for _, k := range c {
+		n += k
+	}
+	return n
+}
+
+// Value returns the number of unseen cards worth v, 1 for an ace and 10 for
+// the tens and faces together.
+func (c Composition) Value(v int) int {
+	if v == 10 {
+		return c[deck.Ten] + c[deck.Jack] + c[deck.Queen] + c[deck.King]
+	}
+	if v < 1 || v > 9 {
+		return 0
+	}
+	return c[v]
+}
+
+// rankNames are how ranks are written in a composition, by index.
+var rankNames = [...]string{"joker", "A", "2", "3", "4", "5", "6", "7", "8", "9", "T", "J", "Q", "K"}
+
+// String writes the composition as counts by rank, e.g. "A:20 2:24 ... K:30",
+// leaving out the ranks it has none of. ParseComposition reads it back.
+func (c Composition) String() string {
+	var b strings.Builder
+	for i := range c {
+		r := (i + 1) % len(c) // Jokers last
+		if c[r] == 0 {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%s:%d", rankNames[r], c[r])
+	}
+	return b.String()
+}
+
+// ParseComposition reads a composition written by Composition.String. The
+// ranks can come in any order, separated by spaces or commas, and ranks left
+// out have none.
+func ParseComposition(s string) (Composition, error) {
+	var c Composition
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' || r == '\n' || r == '\t' }) {
+		name, n, ok := strings.Cut(f, ":")
+		i := -1
+		for r, rn := range rankNames {
+			if strings.EqualFold(name, rn) {
+				i = r
+			}
+		}
+		count, err := strconv.Atoi(n)
+		if !ok || i < 0 || err != nil || count < 0 {
+			return c, fmt.Errorf("Invalid rank count %q, expected e.g. T:24", f)
+		}
+		c[i] += count
+	}
+	if c.Left() == 0 {
+		return c, fmt.Errorf("Composition %q has no cards", s)
+	}
+	return c, nil
+}
+
+// Take returns the cards of the composition taken out of shoe, in shoe order,
+// and the rest of the shoe, or an error if the shoe doesn't hold them all.
+func (c Composition) Take(shoe []deck.Card) (taken, rest []deck.Card, err error) {
+	want := c
+	for _, card := range shoe {
+		if i := want.index(card); want[i] > 0 {
+			want[i]--
+			taken = append(taken, card)
+		} else {
+			rest = append(rest, card)
+		}
+	}
+	for r, n := range want {
+		if n > 0 {
+			return nil, nil, fmt.Errorf("The composition has %d more %s than the shoe holds", n, rankNames[r])
+		}
+	}
+	return taken, rest, nil
+}
+
+// CompositionWatcher is implemented by AIs that want the exact composition of
+// the unseen cards, e.g. to play by the expected value of every decision
+// without tracking the cards themselves. Play calls Composition before every
+// decision when Options.Composition is set.
+type CompositionWatcher interface {
+	Composition(Composition)
+}
+
+// Composition returns the composition of the cards unseen since the shuffle.
+// The engine always keeps it, so analyzers can use it whether or not it's
+// shown to the AI.
+func (g *Game) Composition() Composition {
+	return g.composition
+}
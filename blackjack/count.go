@@ -0,0 +1,41 @@
+package blackjack
+
+import "github.com/Scrimzay/blackjacksimulator/deck"
+
+// Count is the engine's count of the cards dealt face up since the shuffle,
+// kept with the counting system in Options.Count.
+type Count struct {
+	Running int     // Running count
+	True    float64 // Running count per deck left to deal
+	Seen    int     // Number of cards counted
+}
+
+// CountWatcher is implemented by AIs that want to see the engine's count, e.g.
+// to check their own against it or to show it to a player in training. Play
+// calls Count before every decision when Options.Count is set.
+type CountWatcher interface {
+	Count(Count)
+}
+
+// Count returns the engine's count as it stands, and false if the game isn't
+// keeping one.
+func (g *Game) Count() (Count, bool) {
+	if g.counter == nil {
+		return Count{}, false
+	}
+	c := Count{Running: g.counter.Running(), Seen: g.counter.Seen()}
+	if len(g.deck) > 0 {
+		decksLeft := float64(len(g.deck)) * float64(g.nDecks) / float64(g.shoeSize)
+		c.True = float64(c.Running) / decksLeft
+	}
+	return c, true
+}
+
+// observe counts cards as they are exposed and takes them out of the
+// composition.
+func (g *Game) observe(cards ...deck.Card) {
+	g.composition.Remove(cards...)
+	if g.counter != nil {
+		g.counter.Observe(cards...)
+	}
+}
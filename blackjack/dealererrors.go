@@ -0,0 +1,48 @@
+package blackjack
+
+import "github.com/Scrimzay/blackjacksimulator/deck"
+
+// DealerErrors sets how often the dealer makes each kind of mistake, as a
+// chance per round. Real dealers slip up rarely, a few times in a thousand
+// rounds at most, and a player paying attention can take advantage.
+type DealerErrors struct {
+	ExposedHole float64 // The hole card is flashed to the whole table during the deal
+	Overdraw    float64 // The dealer draws a card after the hand is done; the rules correct it by burning the card
+}
+
+// DealerError is a kind of dealer mistake.
+type DealerError string
+
+const (
+	DealerExposedHole DealerError = "exposed-hole" // The hole card was seen by everyone
+	DealerOverdraw    DealerError = "overdraw"     // A card drawn by mistake was taken out of play
+)
+
+// exposeHoleCard may flash the hole card, showing it to a HoleCarder AI as it
+// really is. It reports whether it did.
+func (g *Game) exposeHoleCard(ai AI) bool {
+	if g.noHoleCard || g.dealerErrors.ExposedHole <= 0 || g.rand.Float64() >= g.dealerErrors.ExposedHole {
+		return false
+	}
+	g.round.DealerError = DealerExposedHole
+	hole := g.dealer[1]
+	g.emit(Event{Kind: EventDealerError, Error: DealerExposedHole, Card: &hole})
+	if hc, ok := ai.(HoleCarder); ok {
+		hc.HoleCard(hole)
+	}
+	return true
+}
+
+// overdraw may have the dealer draw a card once the hand is done. The card is
+// turned up, so it's counted, and discarded.
+func (g *Game) overdraw() {
+	if g.dealerErrors.Overdraw <= 0 || len(g.deck) == 0 || g.rand.Float64() >= g.dealerErrors.Overdraw {
+		return
+	}
+	g.round.DealerError = DealerOverdraw
+	var card deck.Card
+	card, g.deck = deck.Draw(g.deck)
+	g.emit(Event{Kind: EventDealerError, Error: DealerOverdraw, Card: &card})
+	g.discards = append(g.discards, card)
+	g.observe(card)
+}
@@ -0,0 +1,263 @@
+package blackjack
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// EventKind identifies a state transition in the event log.
+type EventKind string
+
+const (
+	EventShuffle EventKind = "shuffle" // A new shoe, with every card in dealing order
+	EventBet     EventKind = "bet"     // The seat's bet, 0 if it sat out
+	EventCard    EventKind = "card"    // A card dealt to a hand or the dealer
+	EventMove    EventKind = "move"    // A move applied to a hand
+	EventPayout  EventKind = "payout"  // A hand settled
+	EventEnd     EventKind = "end"     // The round is over
+	EventRebuy   EventKind = "rebuy"   // The seat bought back in between rounds
+	EventBurn    EventKind = "burn"    // A card burned at the start of a shoe
+	EventJoker   EventKind = "joker"   // A joker set aside instead of going to a hand, and the bonus it paid
+
+	EventDealerError EventKind = "dealer-error" // A dealer mistake, and the card it involved
+	EventInsurance   EventKind = "insurance"    // The seat insured against the dealer's ace, and for how much
+)
+
+// DealerHand is the Hand of events that concern the dealer.
+const DealerHand = -1
+
+// Event is a single state transition. Every field but Seq, Round, Kind and
+// Phase is only set for the kinds that need it.
+type Event struct {
+	Seq     int         `json:"seq"`               // Position in the log, from 1
+	Round   int         `json:"round"`             // Round the event belongs to, from 1 over the game's life
+	Kind    EventKind   `json:"kind"`              //
+	Phase   Phase       `json:"phase"`             // Stage of the round the game was at
+	Hand    int         `json:"hand"`              // Player hand index, or DealerHand
+	Card    *deck.Card  `json:"card,omitempty"`    // EventCard, EventBurn, EventDealerError, EventJoker: the card
+	Cards   []deck.Card `json:"cards,omitempty"`   // EventShuffle: the new shoe
+	Move    string      `json:"move,omitempty"`    // EventMove: name of the move
+	Forced  bool        `json:"forced,omitempty"`  // EventCard: the card was forced by a Forcer, out of the shoe's order
+	Outcome Outcome     `json:"outcome,omitempty"` // EventPayout: how the hand was settled
+	Error   DealerError `json:"error,omitempty"`   // EventDealerError: the mistake
+	Amount  int         `json:"amount"`            // EventBet, EventPayout, EventEnd, EventRebuy, EventJoker, EventInsurance: amount bet, won, lost, bought in for, paid or insured; EventMove: what a double added to the bet
+}
+
+// EventSink receives the events of a game as they happen, set through
+// Options.Events.
+type EventSink interface {
+	Record(Event)
+}
+
+// EventLog keeps events in memory.
+type EventLog struct {
+	Events []Event
+}
+
+// Record appends e to the log.
+func (l *EventLog) Record(e Event) {
+	l.Events = append(l.Events, e)
+}
+
+// tee records events to several sinks.
+type tee []EventSink
+
+// Tee returns a sink recording every event to each of sinks in turn, e.g. to a
+// file and to spectators.
+func Tee(sinks ...EventSink) EventSink {
+	return tee(sinks)
+}
+
+func (t tee) Record(e Event) {
+	for _, s := range t {
+		s.Record(e)
+	}
+}
+
+// jsonEvents writes events as JSON lines.
+type jsonEvents struct {
+	enc *json.Encoder
+}
+
+// JSONEvents returns a sink writing every event to w as a line of JSON.
+func JSONEvents(w io.Writer) EventSink {
+	return jsonEvents{enc: json.NewEncoder(w)}
+}
+
+func (j jsonEvents) Record(e Event) {
+	j.enc.Encode(e)
+}
+
+// ReadEvents reads a log written by JSONEvents.
+func ReadEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return events, fmt.Errorf("Event %d: %v", len(events)+1, err)
+		}
+		events = append(events, e)
+	}
+	return events, sc.Err()
+}
+
+// emit records an event if the game has a sink.
+func (g *Game) emit(e Event) {
+	if g.events == nil {
+		return
+	}
+	g.seq++
+	e.Seq = g.seq
+	if e.Round == 0 {
+		e.Round = g.rounds
+	}
+	e.Phase = g.phase
+	g.events.Record(e)
+}
+
+// emitCard records a card dealt to a hand.
+func (g *Game) emitCard(hand int, card deck.Card) {
+	if g.events == nil {
+		return // Don't let card escape for nothing
+	}
+	g.emit(Event{Kind: EventCard, Hand: hand, Card: &card})
+}
+
+// emitForced records a forced card dealt to a hand.
+func (g *Game) emitForced(hand int, card deck.Card) {
+	if g.events == nil {
+		return // Don't let card escape for nothing
+	}
+	g.emit(Event{Kind: EventCard, Hand: hand, Card: &card, Forced: true})
+}
+
+// Rebuild returns a game in the state the events leave it: the same shoe,
+// discards, balance, rebuys and count, as of the last round the log saw settled. It
+// checks every card against the shoe as it goes, so a log that has been
+// tampered with or doesn't belong together fails to rebuild. opts supplies
+// the configuration, which isn't part of the log; Play on the result carries
+// on from where the log stops.
+func Rebuild(opts Options, events []Event) (Game, error) {
+	g := New(opts)
+	var (
+		shoe     []deck.Card
+		discards []deck.Card
+		hands    [][]deck.Card
+		dealer   []deck.Card
+		burned   int // Cards burned from the shoe, the first of its discards
+		played   int // Rounds settled since the shuffle
+		settled  int // played as of the last round settled
+	)
+	for i, e := range events {
+		if e.Seq != i+1 {
+			return g, fmt.Errorf("Event %d is out of sequence (seq %d)", i+1, e.Seq)
+		}
+		switch e.Kind {
+		case EventShuffle:
+			if opts.Jokers == 0 && slices.ContainsFunc(e.Cards, isJoker) {
+				return g, fmt.Errorf("Event %d: %s", e.Seq, errJoker)
+			}
+			shoe, discards, burned, played = e.Cards, nil, 0, 0
+		case EventBet:
+			hands, dealer = [][]deck.Card{nil}, nil
+		case EventCard:
+			if e.Card != nil && e.Forced {
+				j := slices.Index(shoe, *e.Card)
+				if j < 0 {
+					return g, fmt.Errorf("Event %d: forced card isn't in the shoe", e.Seq)
+				}
+				shoe = slices.Delete(slices.Clone(shoe), j, j+1)
+			} else {
+				if e.Card == nil || len(shoe) == 0 || shoe[0] != *e.Card {
+					return g, fmt.Errorf("Event %d: card doesn't match the shoe", e.Seq)
+				}
+				shoe = shoe[1:]
+			}
+			switch {
+			case e.Hand == DealerHand:
+				dealer = append(dealer, *e.Card)
+			case e.Hand >= 0 && e.Hand < len(hands):
+				hands[e.Hand] = append(hands[e.Hand], *e.Card)
+			default:
+				return g, fmt.Errorf("Event %d: no hand %d", e.Seq, e.Hand)
+			}
+		case EventMove:
+			if e.Move != "split" {
+				continue
+			}
+			if e.Hand < 0 || e.Hand >= len(hands) || len(hands[e.Hand]) != 2 {
+				return g, fmt.Errorf("Event %d: can't split hand %d", e.Seq, e.Hand)
+			}
+			hands = slices.Insert(hands, e.Hand+1, []deck.Card{hands[e.Hand][1]})
+			hands[e.Hand] = hands[e.Hand][:1:1]
+		case EventPayout, EventInsurance:
+		case EventEnd:
+			for _, h := range hands {
+				discards = append(discards, h...)
+			}
+			discards = append(discards, dealer...)
+			g.balance += e.Amount
+			played++
+			settled = played
+			g.rounds = e.Round
+			g.seq = e.Seq
+			g.deck = append([]deck.Card(nil), shoe...)
+			g.discards = append([]deck.Card(nil), discards...)
+			hands, dealer = nil, nil
+		case EventJoker:
+			if e.Card == nil || len(shoe) == 0 || shoe[0] != *e.Card {
+				return g, fmt.Errorf("Event %d: joker doesn't match the shoe", e.Seq)
+			}
+			shoe = shoe[1:]
+			discards = append(discards, *e.Card)
+		case EventBurn:
+			if e.Card == nil || len(shoe) == 0 || shoe[0] != *e.Card {
+				return g, fmt.Errorf("Event %d: burned card doesn't match the shoe", e.Seq)
+			}
+			shoe = shoe[1:]
+			discards = append(discards, *e.Card)
+			burned++
+		case EventDealerError:
+			if e.Error != DealerOverdraw {
+				continue
+			}
+			if e.Card == nil || len(shoe) == 0 || shoe[0] != *e.Card {
+				return g, fmt.Errorf("Event %d: overdrawn card doesn't match the shoe", e.Seq)
+			}
+			shoe = shoe[1:]
+			discards = append(discards, *e.Card)
+		case EventRebuy:
+			g.rebuys++
+			g.rebought += e.Amount
+			g.seq = e.Seq
+		default:
+			return g, fmt.Errorf("Event %d: unknown kind %q", e.Seq, e.Kind)
+		}
+	}
+	if g.counter != nil {
+		seen := g.discards
+		if !g.showBurn {
+			seen = seen[min(burned, len(seen)):]
+		}
+		g.counter.Observe(seen...)
+		for range settled {
+			g.counter.EndRound()
+		}
+	}
+	g.composition = Compose(g.deck)
+	if !g.showBurn {
+		g.composition.Add(g.discards[:min(burned, len(g.discards))]...)
+	}
+	g.resumed = g.deck != nil
+	return g, nil
+}
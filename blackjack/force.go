@@ -0,0 +1,67 @@
+package blackjack
+
+import (
+	"fmt"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Forcer is implemented by AIs that pick their own starting cards, such as
+// trainers drilling 16 against a ten. The forced cards are taken out of the
+// shoe, so the shoe's composition and the count stay honest, and the shoe is
+// reshuffled early once it runs out of them; anything not forced is drawn
+// normally.
+type Forcer interface {
+	// Force returns the player's starting cards and the dealer's upcard and
+	// hole card for the next round. Either may be shorter than two cards, or
+	// nil, to leave the rest to the shoe.
+	Force() (player, dealer []deck.Card)
+}
+
+// take pulls the forced card out of the shoe, or any card of the same rank if
+// that one is gone. It panics if the shoe has no card of the rank left.
+func (g *Game) take(c deck.Card) deck.Card {
+	if isJoker(c) {
+		panic("Can't force a joker, it's never part of a hand")
+	}
+	j := -1
+	for i, card := range g.deck {
+		if card == c {
+			j = i
+			break
+		}
+		if j < 0 && card.Rank == c.Rank && card.Suit != deck.Joker {
+			j = i
+		}
+	}
+	if j < 0 {
+		panic(fmt.Sprintf("No %s left in the shoe to force", c))
+	}
+	card := g.deck[j]
+	g.deck = append(g.deck[:j], g.deck[j+1:]...)
+	return card
+}
+
+// stocked reports whether the shoe still has a card of every forced rank.
+func (g *Game) stocked(forced ...[]deck.Card) bool {
+	need := map[deck.Rank]int{}
+	for _, cards := range forced {
+		for _, c := range cards {
+			need[c.Rank]++
+		}
+	}
+	if len(need) == 0 {
+		return true
+	}
+	for _, card := range g.deck {
+		if card.Suit != deck.Joker {
+			need[card.Rank]--
+		}
+	}
+	for _, n := range need {
+		if n > 0 {
+			return false
+		}
+	}
+	return true
+}
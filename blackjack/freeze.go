@@ -0,0 +1,146 @@
+package blackjack
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// Freezer is implemented by AIs that want the game frozen at each of their
+// decisions, such as coaching tools asking what would have happened had the
+// player hit. Freeze is called before every decision, with a snapshot the AI
+// may keep and branch from as often as it likes.
+type Freezer interface {
+	Freeze(s Snapshot)
+}
+
+// Snapshot is the exact state of a game, shoe included, either at a decision
+// or between rounds. Branching from it never touches the game it came from.
+type Snapshot struct {
+	g    *Game
+	mid  bool  // Whether it was taken at a decision, in the middle of a round
+	seed int64 // Seeds every branch's randomness
+}
+
+// Freeze captures the game as it stands, which must be between rounds or at a
+// decision: between calls to Play, or from a Freezer.
+func (g *Game) Freeze() Snapshot {
+	if g.phase != Betting && g.phase != PlayerTurn {
+		panic(fmt.Sprintf("Can't freeze the game during %v", g.phase))
+	}
+	// Every branch gets the same randomness, so they differ only by the
+	// moves made, without drawing on the game's own.
+	seed := deriveSeed(int64(g.rounds)<<32|int64(g.seq), streamBranch)
+	return Snapshot{g: g.clone(), mid: g.phase == PlayerTurn, seed: seed}
+}
+
+// game returns a copy of the frozen game to branch with. A branch bets with
+// a copy of Options.Bettor, so it panics if the bettor can't be copied.
+func (s Snapshot) game() *Game {
+	if _, ok := s.g.bettor.(Cloner); s.g.bettor != nil && !ok {
+		panic(fmt.Sprintf("Can't branch with bettor %T, which isn't a Cloner", s.g.bettor))
+	}
+	g := s.g.clone()
+	g.rand = rand.New(rand.NewSource(deriveSeed(s.seed, streamEngine)))
+	if g.shuffleRand != nil {
+		g.shuffleRand = rand.New(rand.NewSource(deriveSeed(s.seed, streamShoe)))
+	}
+	return g
+}
+
+// Mid reports whether the snapshot was taken at a decision, so branches
+// finish its round rather than playing the next.
+func (s Snapshot) Mid() bool {
+	return s.mid
+}
+
+// View returns the decision the snapshot was taken at.
+func (s Snapshot) View() GameView {
+	if !s.mid {
+		panic("Snapshot was taken between rounds")
+	}
+	return s.g.view()
+}
+
+// Rules returns the rules of the frozen game.
+func (s Snapshot) Rules() Rules {
+	return s.g.Rules()
+}
+
+// Composition returns the cards the player hadn't seen when the snapshot was
+// taken, the hole card and the rest of the shoe.
+func (s Snapshot) Composition() Composition {
+	return s.g.composition
+}
+
+// Branch plays on from the snapshot with ai deciding, after first making the
+// moves given, and returns how the round ends: the round in progress, or the
+// next one if the snapshot was taken between rounds. The result is zero if the
+// game stops before the next round is dealt. Branches can be compared: they all
+// see the same shoe, so the same cards come out until a move changes who draws
+// them.
+func (s Snapshot) Branch(ai AI, moves ...Move) RoundResult {
+	g := s.game()
+	g.script = moves
+	var result RoundResult
+	g.onRound = func(r RoundResult) { result = r }
+	if !s.mid {
+		g.nHands, g.nShoes, g.resumed = 1, 0, true
+		g.Play(ai)
+		return result
+	}
+	if rt, ok := ai.(RulesTaker); ok {
+		rt.SetRules(g.Rules())
+	}
+	if g.bettor == nil {
+		g.sizer, _ = ai.(DoubleSizer) // ai stands in for the AI that bet
+	}
+	g.finishRound(ai, ai)
+	return result
+}
+
+// Game returns a game carrying on from a snapshot taken between rounds, for
+// branches longer than a round. Play continues with the frozen shoe.
+func (s Snapshot) Game() *Game {
+	if s.mid {
+		panic("Snapshot was taken in the middle of a round")
+	}
+	g := s.game()
+	g.resumed = true
+	return g
+}
+
+// clone copies the game deeply enough for the copy to be played on without
+// changing the original. The copy records no events, reports no rounds, isn't
+// ended by Options.Leave and has no one betting behind it. It bets with a
+// copy of Options.Bettor if that's a Cloner.
+func (g *Game) clone() *Game {
+	c := *g
+	if b, ok := g.bettor.(Cloner); ok {
+		c.bettor = b.Clone()
+		c.sizer, _ = c.bettor.(DoubleSizer)
+	}
+	c.deck = slices.Clone(g.deck)
+	c.discards = slices.Clone(g.discards)
+	c.dealer = slices.Clone(g.dealer)
+	c.forcedDealer = slices.Clone(g.forcedDealer)
+	c.script = nil
+	if g.counter != nil {
+		counter := *g.counter
+		c.counter = &counter
+	}
+	if g.round.Count != nil {
+		count := *g.round.Count
+		c.round.Count = &count
+	}
+	c.round.Hands = nil
+	if g.player != nil {
+		c.player = make([]hand, len(g.player))
+		for i, h := range g.player {
+			h.start, h.actions, h.cards = slices.Clone(h.start), slices.Clone(h.actions), slices.Clone(h.cards)
+			c.player[i] = h
+		}
+	}
+	c.events, c.onRound, c.leave, c.arena, c.audit, c.backers = nil, nil, nil, nil, nil, nil
+	return &c
+}
@@ -0,0 +1,53 @@
+package blackjack
+
+import (
+	"testing"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// counting bets the minimum and counts its bets.
+type counting struct {
+	bets int
+}
+
+func (c *counting) Bet(ctx BetContext) int {
+	c.bets++
+	return ctx.MinBet
+}
+
+func (c *counting) Clone() Bettor {
+	copied := *c
+	return &copied
+}
+
+// standAI stands on everything.
+type standAI struct{}
+
+func (standAI) Play([]deck.Card, deck.Card) Move   { return MoveStand }
+func (standAI) Results([][]deck.Card, []deck.Card) {}
+
+func TestBranchCopiesBettor(t *testing.T) {
+	b := &counting{}
+	g := New(Options{Hands: 3, Seed: 1, Bettor: b})
+	g.Play(standAI{})
+	s := g.Freeze()
+	for range 2 {
+		s.Branch(standAI{})
+	}
+	if b.bets != 3 {
+		t.Errorf("Bettor placed %d bets after branching, want the game's 3", b.bets)
+	}
+}
+
+func TestBranchNeedsCloner(t *testing.T) {
+	g := New(Options{Hands: 1, Seed: 1, Bettor: struct{ Bettor }{&counting{}}})
+	g.Play(standAI{})
+	s := g.Freeze()
+	defer func() {
+		if recover() == nil {
+			t.Error("Branched with a bettor that isn't a Cloner")
+		}
+	}()
+	s.Branch(standAI{})
+}
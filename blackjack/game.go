@@ -0,0 +1,874 @@
+// Package blackjack implements the rules engine: dealing, player moves, the
+// dealer's play and settlement.
+package blackjack
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/count"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Options struct defines configuration parameters for the game.
+type Options struct {
+	Decks              int          // Number of decks used in the game
+	Hands              int          // Number of hands to be played
+	Shoes              int          // Stop at the end of this many shoes, 0 for no limit
+	Penetration        float64      // Fraction of the shoe dealt before it's reshuffled, DefaultPenetration for the decks if 0
+	BlackjackPayout    float64      // Payout ratio for blackjack
+	Variant            Variant      // Rule variant, Classic by default
+	DoubleOn           DoubleRule   // Which two-card totals may be doubled, any by default
+	StandSoft17        bool         // Dealer stands on soft 17 instead of hitting it
+	NoDoubleAfterSplit bool         // Split hands may not be doubled
+	SplitHands         int          // Most hands a seat can split into, 4 by default
+	ResplitAces        bool         // Split aces may be split again
+	HitSplitAces       bool         // Split aces may be played on rather than getting one card each
+	Surrender          bool         // Late surrender: a hand's first two cards may be given up for half the bet, which Super Fun 21 always allows
+	DealerWinsTies     bool         // Ties (including blackjack vs blackjack) lose instead of pushing
+	Push22             bool         // A dealer total of exactly 22 pushes every hand that didn't bust or make blackjack
+	Peek               PeekRule     // Upcards the dealer checks for blackjack under before play, aces and tens by default
+	NoHoleCard         bool         // The dealer's second card is dealt after the players act (European no hole card), so there's no peek
+	OriginalBetsOnly   bool         // A blackjack the dealer didn't peek for only takes the original bet, not doubles and splits
+	Shuffler           deck.Permer  // Shuffle model, e.g. deck.Riffle; a perfectly random shuffle if nil
+	NewDecks           int          // Put in new decks, in factory order, every this many shoes; only the first shoe is new if 0
+	SlugSize           int          // Cards per slug reported to ShuffleTracker AIs, 52 by default
+	Burn               int          // Cards burned at the start of each shoe
+	ShowBurn           bool         // Burned cards are shown, so the count and BurnWatcher AIs see them
+	DealerErrors       DealerErrors // How often the dealer makes mistakes, never by default
+
+	HoleCardReliability float64 // Chance a HoleCarder AI reads the hole card correctly, 0 disables hole-card play
+	Tells               float64 // Chance a TellWatcher AI reads the dealer's tell right, e.g. 0.7; 0.5 tells nothing, 0 disables tells
+	NoMidShoeEntry      bool    // A Wonger AI that sits out a round can't rejoin until the next shuffle
+	Jokers              int     // Jokers shuffled into the shoe as bonus cards, a home rule; none by default
+	JokerBonus          float64 // What a joker dealt to the seat pays, as a multiple of its original bet, 1 if 0
+
+	Bettor       Bettor       // Places the seat's bets instead of the AI, e.g. to pair a betting strategy with a playing one
+	Bankroll     int          // Starting bankroll reported to bettors; play stops when it can't cover MinBet
+	Rebuys       int          // Times the seat buys back in for Bankroll instead of stopping when it runs out
+	StopLoss     int          // Stop once the session is down this much, 0 for no limit
+	StopWin      int          // Stop once the session is up this much, 0 for no limit
+	Leave        func() bool  // Asked before each round, stops play when it returns true, e.g. once a session's time is up
+	MinBet       int          // Table minimum, 100 by default
+	MaxBet       int          // Table maximum, 0 for no limit
+	BetIncrement int          // Bets must be a multiple of this, e.g. 5; any amount if 0
+	Chips        []int        // Chip denominations bets must add up from, e.g. 5, 25, 100; any amount if empty
+	Count        count.System // Counting system the engine keeps a count with for bettors and watchers, none if nil
+	Composition  bool         // Show the composition of the unseen cards to bettors and CompositionWatcher AIs
+	Remaining    *Composition // Cards every shoe starts from, as if the rest of a full shoe had already been dealt, e.g. a composition saved from another game; a full shoe if nil
+
+	Seed   int64 // Seeds the perfect shuffle and the engine's own randomness for repeatable shoes, random if 0
+	AISeed int64 // Seeds AIs and bettors that implement Seeder, independently of Seed; left alone if 0
+
+	Settler Settler           // Works out what each hand pays instead of the variant's rules, e.g. for a bonus paytable
+	Events  EventSink         // Receives every state transition, e.g. an EventLog
+	OnRound func(RoundResult) // Called with every settled round, e.g. to record a history
+
+	// Reuse recycles the slices of each round instead of allocating new ones,
+	// for long simulations. The hands passed to the AI and the RoundResult
+	// passed to OnRound and Settled are then only valid until the call returns.
+	Reuse bool
+
+	// Audit checks around every call to the AI that it only got copies of the
+	// game's cards, panicking if one could change the game through what it
+	// was passed. It's slow, for testing AIs and the engine.
+	Audit bool
+}
+
+// New initializes a Game instance with default values if options are not provided.
+func New(opts Options) Game {
+	g := Game{
+		balance: 0,
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	// Set default values if none are provided
+	if opts.Decks == 0 {
+		opts.Decks = 3
+	}
+	if opts.Hands == 0 && opts.Shoes == 0 {
+		opts.Hands = 100
+	}
+	if opts.MinBet == 0 {
+		opts.MinBet = 100
+	}
+	if opts.SplitHands == 0 {
+		opts.SplitHands = 4
+	}
+	if opts.SlugSize == 0 {
+		opts.SlugSize = 52
+	}
+	if opts.BlackjackPayout == 0.0 {
+		opts.BlackjackPayout = 1.5
+		if opts.Variant == SuperFun21 {
+			opts.BlackjackPayout = 1 // Super Fun 21 pays even money
+		}
+	}
+	g.nDecks = opts.Decks
+	g.nHands = opts.Hands
+	g.nShoes = opts.Shoes
+	g.blackjackPayout = opts.BlackjackPayout
+	g.variant = opts.Variant
+	g.doubleOn = opts.DoubleOn
+	g.standSoft17 = opts.StandSoft17
+	g.noDoubleAfterSplit = opts.NoDoubleAfterSplit
+	g.splitHands = opts.SplitHands
+	g.resplitAces = opts.ResplitAces
+	g.hitSplitAces = opts.HitSplitAces
+	g.surrender = opts.Surrender
+	g.dealerAI = dealerAI{standSoft17: opts.StandSoft17}
+	g.dealerWinsTies = opts.DealerWinsTies
+	g.push22 = opts.Push22
+	g.peek = opts.Peek
+	g.noHoleCard = opts.NoHoleCard
+	if g.noHoleCard {
+		g.peek = NoPeek
+	}
+	g.originalBetsOnly = opts.OriginalBetsOnly
+	g.shuffler = opts.Shuffler
+	g.newDecks = opts.NewDecks
+	g.slugSize = opts.SlugSize
+	g.nBurn = opts.Burn
+	g.showBurn = opts.ShowBurn
+	g.dealerErrors = opts.DealerErrors
+	checkJokerOptions(opts)
+	g.jokers = opts.Jokers
+	g.jokerBonus = opts.JokerBonus
+	if g.jokerBonus == 0 {
+		g.jokerBonus = 1
+	}
+	g.shoeSize = len(g.fullShoe())
+	g.penetration = opts.Penetration
+	if g.penetration < 0 || g.penetration >= 1 {
+		panic(fmt.Sprintf("Penetration of %g isn't a fraction of the shoe", g.penetration))
+	}
+	if g.penetration == 0 {
+		g.penetration = DefaultPenetration(g.nDecks)
+	}
+	if opts.Remaining != nil {
+		left, gone, err := opts.Remaining.Take(g.fullShoe())
+		if err != nil {
+			panic(err.Error())
+		}
+		if len(left) < g.reshuffleAt() {
+			panic(fmt.Sprintf("The %d cards remaining are already past the cut card, which comes out with %d left", len(left), g.reshuffleAt()))
+		}
+		g.remaining, g.gone = left, gone
+	}
+	g.holeCardReliability = opts.HoleCardReliability
+	g.tells = opts.Tells
+	if g.tells < 0 || g.tells > 1 {
+		panic(fmt.Sprintf("Tells of %g isn't a chance", g.tells))
+	}
+	g.noMidShoeEntry = opts.NoMidShoeEntry
+	g.onRound = opts.OnRound
+	if opts.Reuse {
+		g.arena = &arena{}
+	}
+	if opts.Audit {
+		g.audit = newAuditor(opts.Seed)
+	}
+	g.aiSeed = opts.AISeed
+	g.settler = opts.Settler
+	if g.settler == nil {
+		g.settler = g.variant.Settler()
+	}
+	g.events = opts.Events
+	g.seed(opts)
+	g.bettor = opts.Bettor
+	g.minBet = opts.MinBet
+	g.maxBet = opts.MaxBet
+	g.betIncrement = opts.BetIncrement
+	g.chips = slices.Clone(opts.Chips)
+	if err := checkChips(g.chips); err != nil {
+		panic(err.Error())
+	}
+	if err := g.betContext(false).Check(g.minBet); err != nil {
+		panic(fmt.Sprintf("Table minimum of %d isn't a valid bet: %v", g.minBet, err))
+	}
+	g.bankroll = opts.Bankroll
+	g.maxRebuys = opts.Rebuys
+	g.stopLoss = opts.StopLoss
+	g.stopWin = opts.StopWin
+	g.leave = opts.Leave
+	if opts.Count != nil {
+		g.counter = &count.Counter{System: opts.Count, Decks: opts.Decks}
+	}
+	g.composed = opts.Composition
+	return g
+}
+
+// Game represents the state of the game.
+type Game struct {
+	nDecks             int          // Number of decks
+	nHands             int          // Number of hands, 0 for no limit
+	nShoes             int          // Number of shoes, 0 for no limit
+	blackjackPayout    float64      // Payout ratio for blackjack
+	variant            Variant      // Rule variant in play
+	doubleOn           DoubleRule   // Which two-card totals may be doubled
+	standSoft17        bool         // Whether the dealer stands on soft 17
+	noDoubleAfterSplit bool         // Whether split hands may not be doubled
+	splitHands         int          // Most hands a seat can split into
+	resplitAces        bool         // Whether split aces may be split again
+	hitSplitAces       bool         // Whether split aces may be played on
+	surrender          bool         // Whether late surrender is allowed
+	dealerWinsTies     bool         // Whether ties go to the dealer
+	push22             bool         // Whether a dealer 22 pushes
+	peek               PeekRule     // Upcards the dealer peeks under
+	noHoleCard         bool         // Whether the dealer's second card is dealt after the players act
+	originalBetsOnly   bool         // Whether an unpeeked blackjack only takes the original bet
+	shuffler           deck.Permer  // Shuffle model, nil for a perfect shuffle
+	newDecks           int          // Shoes between new decks, 0 for only the first
+	slugSize           int          // Cards per slug for shuffle trackers
+	nBurn              int          // Cards burned at the start of each shoe
+	showBurn           bool         // Whether burned cards are shown
+	dealerErrors       DealerErrors // How often the dealer makes mistakes
+
+	holeCardReliability float64    // Chance the hole card is read correctly
+	tells               float64    // Chance the dealer's tell is read right
+	noMidShoeEntry      bool       // Whether players can only join at the start of a shoe
+	jokers              int        // Jokers in the shoe, as bonus cards
+	jokerBonus          float64    // What a joker dealt to the seat pays, per unit bet
+	waiting             bool       // Whether the AI sat out and is waiting for the next shuffle
+	missedShuffle       bool       // Whether the shoe was shuffled while the AI sat out
+	rand                *rand.Rand // Randomness used by the engine itself, not the shuffle
+	shuffleRand         *rand.Rand // Randomness of the perfect shuffle when seeded, deck.Shuffle's if nil
+	aiSeed              int64      // Seed for the AI and bettor, 0 to leave them alone
+	events              EventSink  // Where events are recorded, nil for nowhere
+	settler             Settler    // Works out what each hand pays
+	onRound             func(RoundResult)
+	arena               *arena   // Buffers reused every round, nil to allocate
+	audit               *auditor // Checks the AI only gets copies, nil if not auditing
+
+	bettor       Bettor         // Places bets instead of the AI, if set
+	sizer        DoubleSizer    // Decides how much the round's doubles are for, nil for the whole bet
+	bankroll     int            // Starting bankroll
+	maxRebuys    int            // Rebuys allowed
+	rebuys       int            // Rebuys made
+	rebought     int            // Total bought back in for
+	stopLoss     int            // Loss that ends the session, 0 for no limit
+	stopWin      int            // Win that ends the session, 0 for no limit
+	leave        func() bool    // Ends the session when it says so, never if nil
+	stopped      StopReason     // Why the last Play stopped early
+	minBet       int            // Table minimum
+	maxBet       int            // Table maximum, 0 for no limit
+	betIncrement int            // Bets must be a multiple of this, any amount if 0
+	chips        []int          // Chip denominations, any amount if empty
+	counter      *count.Counter // Count of the cards seen this shoe, nil if not counting
+	composition  Composition    // Cards unseen since the shuffle
+	composed     bool           // Whether the composition is shown to bettors and AIs
+	remaining    []deck.Card    // Cards every shoe starts from, nil for a full shoe
+	gone         []deck.Card    // The rest of the full shoe, counted as dealt at every shuffle
+
+	played      int         // Rounds dealt by the last call to Play
+	rounds      int         // Rounds dealt over the game's life
+	seq         int         // Number of events recorded
+	resumed     bool        // Whether the shoe was rebuilt from events, for Play to carry on with
+	round       RoundResult // Details of the round in progress
+	dealtFrom   int         // Cards in the shoe before the round in progress took any
+	shoes       int         // Shoes started by the last call to Play
+	shoeSize    int         // Number of cards in a full shoe
+	penetration float64     // Fraction of the shoe dealt before it's reshuffled
+	deck        []deck.Card // The deck of cards
+	discards    []deck.Card // Cards played since the last shuffle, in pickup order
+	phase       Phase       // Stage of the round
+
+	player    []hand // Player's hands
+	handIdx   int    // Index of the active hand
+	playerBet int    // Current bet amount
+	balance   int    // Player's balance
+
+	dealer       []deck.Card // Dealer's hand
+	dealerAI     AI          // AI logic for dealer's moves
+	forcedDealer []deck.Card // Dealer's forced cards this round
+	script       []Move      // Moves made before asking the AI, for branches
+	stepping     bool        // Whether a round started with StartRound hasn't been finished
+	decisions    int         // Decisions made in stepped rounds, so a stale apply is refused
+
+	backers []backer // Players betting behind the seat
+}
+
+// currentHand returns a pointer to the current active hand's cards.
+func (g *Game) currentHand() *[]deck.Card {
+	switch g.phase {
+	case PlayerTurn:
+		return &g.player[g.handIdx].cards
+	case DealerTurn:
+		return &g.dealer
+	default:
+		panic("It isn't currently any players' turn")
+	}
+}
+
+// hand represents a single hand played by the player.
+type hand struct {
+	start       []deck.Card // Cards the hand started with
+	actions     []Action    // Moves made on the hand
+	cards       []deck.Card // Cards in the hand
+	bet         int         // Bet placed on the hand
+	surrendered bool        // Whether the hand was surrendered
+}
+
+// deal distributes two cards to the player and dealer at the beginning of a round,
+// starting with any forced cards.
+func deal(g *Game, forcedPlayer, forcedDealer []deck.Card) {
+	g.enter(PlayerTurn)
+	if g.arena != nil {
+		g.arena.reset()
+	}
+	playerHand := g.newCards(5) // Player's hand initialized with capacity of 5
+	g.handIdx = 0
+	g.dealer = g.newCards(5) // Dealer's hand initialized
+
+	var card deck.Card
+	for i := 0; i < 2; i++ {
+		if i < len(forcedPlayer) {
+			card = g.take(forcedPlayer[i])
+			g.emitForced(0, card)
+		} else {
+			card = g.drawFor(0)
+			g.emitCard(0, card)
+		}
+		playerHand = append(playerHand, card)
+		if i == 1 && g.noHoleCard {
+			break // The dealer's second card waits for dealHoleCard
+		}
+		if i < len(forcedDealer) {
+			card = g.take(forcedDealer[i])
+			g.emitForced(DealerHand, card)
+		} else {
+			card = g.drawFor(DealerHand)
+			g.emitCard(DealerHand, card)
+		}
+		g.dealer = append(g.dealer, card)
+	}
+	var hands []hand
+	if g.arena != nil {
+		hands = g.arena.hands[:0]
+	}
+	g.player = append(hands, hand{
+		start:   g.copyCards(playerHand),
+		actions: g.newActions(),
+		cards:   playerHand,
+		bet:     g.playerBet,
+	})
+}
+
+// Play runs the game loop for the specified number of hands.
+func (g *Game) Play(ai AI) int {
+	if g.stepping {
+		panic("Can't Play during a stepped round")
+	}
+	if !g.resumed {
+		g.deck = nil
+		g.discards = nil
+	}
+	g.resumed = false
+	g.shoes = 0
+	g.stopped = ""
+	if rt, ok := ai.(RulesTaker); ok {
+		rt.SetRules(g.Rules())
+	}
+	g.seedPlayers(ai)
+	g.checkJokers()
+	min := g.reshuffleAt() // Minimum deck size before reshuffling
+
+	for g.played = 0; g.nHands == 0 || g.played < g.nHands; g.played++ {
+		if g.stop(ai) {
+			break
+		}
+		shuffled := false
+		var forcedPlayer []deck.Card
+		g.forcedDealer = nil
+		if f, ok := ai.(Forcer); ok {
+			before := g.fingerprint()
+			forcedPlayer, g.forcedDealer = f.Force()
+			if g.audit != nil {
+				g.audited("Force", before)
+			}
+			// The AI's own slices could change under the round
+			forcedPlayer, g.forcedDealer = slices.Clone(forcedPlayer), slices.Clone(g.forcedDealer)
+		}
+		if len(g.deck) < min || !g.stocked(forcedPlayer, g.forcedDealer) {
+			if g.nShoes > 0 && g.shoes == g.nShoes {
+				break
+			}
+			g.newShoe(ai)
+			shuffled = true
+		}
+		player, over := g.startRound(ai, shuffled, forcedPlayer)
+		if !over {
+			g.finishRound(ai, player)
+		}
+	}
+	return g.balance
+}
+
+// newShoe shuffles the cards for a new shoe.
+func (g *Game) newShoe(ai AI) {
+	g.shuffle(ai)
+	g.shoes++
+	if g.counter != nil {
+		g.counter.Reset()
+		g.counter.Observe(g.gone...)
+	}
+	g.composition = Compose(g.deck)
+}
+
+// startRound takes the bet and deals, and returns the AI making the seat's
+// decisions: ai, or the dealer's if ai sat the round out. over reports whether
+// the round is already settled, because the dealer peeked a blackjack.
+func (g *Game) startRound(ai AI, shuffled bool, forcedPlayer []deck.Card) (player AI, over bool) {
+	g.rounds++
+	player, seated := ai, g.seated(ai, shuffled)
+	g.round = RoundResult{Round: g.played + 1, Shuffled: shuffled, Seated: seated}
+	g.dealtFrom = len(g.deck)
+	if c, ok := g.Count(); ok {
+		g.round.Count = g.keepCount(c)
+	}
+	if seated {
+		shuffled = shuffled || g.missedShuffle
+		g.missedShuffle = false
+		bet(g, ai, shuffled)
+		betBehind(g, shuffled)
+	} else {
+		g.sitOut(shuffled)
+		player = g.dealerAI // Someone else plays the seat while the AI watches
+	}
+	g.emit(Event{Kind: EventBet, Amount: g.playerBet})
+	if g.round.Shuffled {
+		g.burn(ai)
+	}
+	deal(g, forcedPlayer, g.forcedDealer)
+	g.observe(g.player[0].cards...)
+	g.observe(g.dealer[0])
+	if exposed := g.exposeHoleCard(ai); seated && !exposed {
+		g.peekHoleCard(ai)
+		g.tell(ai)
+	}
+	if seated {
+		g.offerInsurance(ai)
+	}
+
+	// Check for dealer blackjack immediately, if the dealer peeks under the upcard
+	if Blackjack(g.dealer...) && g.peek.Peeks(g.dealer[0]) {
+		endRound(g, ai)
+		return player, true
+	}
+	return player, false
+}
+
+// finishRound plays a dealt round to the end: player's decisions, then the
+// dealer's hand, then the settlement with ai.
+func (g *Game) finishRound(ai, player AI) {
+	// Player's turn
+	for g.phase == PlayerTurn {
+		hand := g.copyCards(*g.currentHand())
+		before := g.fingerprint()
+		if cw, ok := player.(CountWatcher); ok {
+			if c, ok := g.Count(); ok {
+				cw.Count(c)
+			}
+		}
+		if cw, ok := player.(CompositionWatcher); ok && g.composed {
+			cw.Composition(g.composition)
+		}
+		if f, ok := player.(Freezer); ok {
+			f.Freeze(g.Freeze())
+		}
+		var move Move
+		if len(g.script) > 0 {
+			move, g.script = g.script[0], g.script[1:]
+		} else if v, ok := player.(Viewer); ok {
+			view := g.view()
+			move = v.PlayView(view)
+			if g.audit != nil {
+				g.audited("PlayView", before, view)
+			}
+		} else {
+			move = player.Play(hand, g.dealer[0])
+			if g.audit != nil {
+				g.audited("Play", before, hand)
+			}
+		}
+		if err := g.apply(move, len(hand)); err != nil {
+			panic(err)
+		}
+	}
+	g.settleRound(ai)
+}
+
+// apply makes a move on the current hand, which held cards cards, standing
+// for the player if it busts. It returns the error of a move that couldn't be
+// made.
+func (g *Game) apply(move Move, cards int) error {
+	idx := g.handIdx
+	if name := move.String(); name != "double" {
+		g.emit(Event{Kind: EventMove, Hand: idx, Move: name})
+	}
+	err := move(g)
+	if err == nil || err == errBust {
+		g.recordAction(idx, move, cards)
+	}
+	if err == errBust {
+		return MoveStand(g) // If player busts, automatically stand
+	}
+	return err
+}
+
+// settleRound plays the dealer's hand once the seat is done, then settles
+// the round with ai.
+func (g *Game) settleRound(ai AI) {
+	if g.noHoleCard {
+		g.dealHoleCard(g.forcedDealer)
+	}
+	for g.phase == DealerTurn {
+		hand := g.copyCards(g.dealer)
+		move := g.dealerAI.Play(hand, g.dealer[0])
+		g.emit(Event{Kind: EventMove, Hand: DealerHand, Move: move.String()})
+		move(g)
+	}
+	g.overdraw()
+
+	endRound(g, ai)
+}
+
+// Played returns the number of rounds dealt by the last call to Play, plus
+// any stepped with StartRound since.
+func (g *Game) Played() int {
+	return g.played
+}
+
+// Error representing a busted hand.
+var (
+	errBust = errors.New("Hand score exceeded 21")
+)
+
+// Reasons a hand can't be split, made once since views ask before every move.
+var (
+	errSplitCards  = errors.New("You can only split with two cards in your hand")
+	errSplitRanks  = errors.New("Both cards must have the same rank to split")
+	errResplitAces = errors.New("Aces can't be split again")
+)
+
+// Move represents a function that executes a player's move.
+type Move func(*Game) error
+
+// String returns the name of a built-in move, such as "hit".
+func (m Move) String() string {
+	switch reflect.ValueOf(m).Pointer() {
+	case reflect.ValueOf(MoveHit).Pointer():
+		return "hit"
+	case reflect.ValueOf(MoveStand).Pointer():
+		return "stand"
+	case reflect.ValueOf(MoveDouble).Pointer(), doubleFor:
+		return "double"
+	case reflect.ValueOf(MoveSplit).Pointer():
+		return "split"
+	case reflect.ValueOf(MoveSurrender).Pointer():
+		return "surrender"
+	default:
+		return "custom move"
+	}
+}
+
+// MoveHit allows the player to draw a card.
+func MoveHit(g *Game) error {
+	if g.phase == PlayerTurn && g.splitAces() {
+		return errors.New("Split aces get one card each")
+	}
+	hand, idx := g.currentHand(), g.handIdx
+	if g.phase == DealerTurn {
+		idx = DealerHand
+	}
+	card := g.drawFor(idx)
+	*hand = append(*hand, card)
+	g.emitCard(idx, card)
+	if Score(*hand...) > 21 {
+		return errBust
+	}
+	return nil
+}
+
+// MoveSplit allows the player to split their hand if they have two cards of the
+// same rank. The new hand is played right after the current one, and each hand
+// is dealt its second card as it comes up to be played: the current one right
+// away, the new one once the hands before it are done. Split aces get one card
+// each unless the table allows hitting them.
+func MoveSplit(g *Game) error {
+	if err := g.splitError(); err != nil {
+		return err
+	}
+	h := &g.player[g.handIdx]
+	second := h.cards[1]
+	h.cards = h.cards[:1]
+	g.player = slices.Insert(g.player, g.handIdx+1, hand{
+		start:   append(g.newCards(2), second),
+		actions: g.newActions(),
+		cards:   append(g.newCards(5), second),
+		bet:     h.bet,
+	})
+	g.dealSplit()
+	return nil
+}
+
+// splitError returns why the current hand can't be split, nil if it can.
+func (g *Game) splitError() error {
+	cards := g.player[g.handIdx].cards
+	switch {
+	case len(cards) != 2:
+		return errSplitCards
+	case cards[0].Rank != cards[1].Rank:
+		return errSplitRanks
+	case len(g.player) >= g.splitHands:
+		return fmt.Errorf("Can't split into more than %d hands", g.splitHands)
+	case cards[0].Rank == deck.Ace && len(g.player) > 1 && !g.resplitAces:
+		return errResplitAces
+	}
+	return nil
+}
+
+// splitAces reports whether the current hand is a split ace that can only
+// stand or, where allowed, be split again.
+func (g *Game) splitAces() bool {
+	h := g.player[g.handIdx]
+	return !g.hitSplitAces && len(g.player) > 1 && h.start[0].Rank == deck.Ace
+}
+
+// dealSplit deals the second card of the current hand if it was split off with
+// only one, and moves on from split aces that can't be played further.
+func (g *Game) dealSplit() {
+	h := &g.player[g.handIdx]
+	if len(h.cards) != 1 {
+		return
+	}
+	card := g.drawFor(g.handIdx)
+	h.cards = append(h.cards, card)
+	if len(h.start) == 1 {
+		h.start = append(h.start, card) // A new hand starts with both its cards
+		g.observe(card)
+	}
+	g.emitCard(g.handIdx, card)
+	if g.splitAces() && g.splitError() != nil {
+		MoveStand(g)
+	}
+}
+
+// MoveDouble allows the player to double their bet and draw one final card.
+// The bettor decides how much to double for if it's a DoubleSizer; otherwise
+// it's the whole bet.
+func MoveDouble(g *Game) error {
+	return g.double(0)
+}
+
+// MoveDoubleFor doubles for less: it adds amount, at most the hand's bet, to
+// the bet and draws one final card.
+func MoveDoubleFor(amount int) Move {
+	return func(g *Game) error {
+		if amount < 1 {
+			return errors.New("Can't double for nothing")
+		}
+		return g.double(amount)
+	}
+}
+
+// doubleFor identifies the moves made by MoveDoubleFor, which all share its
+// code.
+var doubleFor = reflect.ValueOf(MoveDoubleFor(1)).Pointer()
+
+// double doubles the current hand for amount, or for what the bettor decides
+// if 0. It records the move itself once it's allowed, so the event carries
+// the amount.
+func (g *Game) double(amount int) error {
+	if len(*g.currentHand()) != 2 {
+		return errors.New("Can only double on a hand with 2 cards")
+	}
+	if !g.doubleOn.allows(*g.currentHand()) {
+		return errors.New("Doubling is not allowed on this total")
+	}
+	if g.noDoubleAfterSplit && len(g.player) > 1 {
+		return errors.New("Doubling is not allowed after splitting")
+	}
+	if g.splitAces() {
+		return errors.New("Split aces get one card each")
+	}
+	h := &g.player[g.handIdx]
+	if amount == 0 {
+		amount = g.doubleSize(h.bet)
+	}
+	if amount < 1 || amount > h.bet {
+		return fmt.Errorf("Can only double for 1 to %d", h.bet)
+	}
+	h.bet += amount
+	g.emit(Event{Kind: EventMove, Hand: g.handIdx, Move: "double", Amount: amount})
+	MoveHit(g)
+	return MoveStand(g)
+}
+
+// MoveSurrender gives up the current hand for half of its bet. Where
+// Options.Surrender allows it, it's late surrender: only as the first move on
+// the two cards dealt, not after a split, and a dealer blackjack that wasn't
+// peeked for still takes the whole bet. Super Fun 21 allows it on any number
+// of cards as long as the hand hasn't been doubled.
+func MoveSurrender(g *Game) error {
+	if err := g.surrenderError(); err != nil {
+		return err
+	}
+	g.player[g.handIdx].surrendered = true
+	return MoveStand(g)
+}
+
+// surrenderError returns why the current hand can't be surrendered, nil if it
+// can.
+func (g *Game) surrenderError() error {
+	switch {
+	case g.phase != PlayerTurn:
+		return errors.New("Can only surrender during the player's turn")
+	case g.variant == SuperFun21:
+		return nil
+	case !g.surrender:
+		return errors.New("Surrender is not allowed at this table")
+	case len(g.player) > 1:
+		return errors.New("Can't surrender after splitting")
+	case len(g.player[g.handIdx].cards) != 2:
+		return errors.New("Can only surrender the first two cards")
+	}
+	return nil
+}
+
+// MoveStand ends the player's turn.
+func MoveStand(g *Game) error {
+	if g.phase == DealerTurn {
+		g.enter(Settlement)
+		return nil
+	}
+	if g.phase == PlayerTurn {
+		g.handIdx++
+		if g.handIdx >= len(g.player) {
+			g.enter(DealerTurn)
+		} else {
+			g.dealSplit()
+		}
+		return nil
+	}
+	return fmt.Errorf("Can't stand during %v", g.phase)
+}
+
+// endRound evaluates the results of the round and updates the balance.
+func endRound(g *Game, ai AI) {
+	g.enter(Settlement)
+	net := 0
+	var allHands [][]deck.Card
+	if g.arena != nil {
+		allHands, g.round.Hands = g.arena.all[:0], g.arena.results[:0]
+	}
+	for _, hand := range g.player {
+		allHands = append(allHands, g.copyCards(hand.cards))
+	}
+	for hi, hand := range g.player {
+		cards := hand.cards
+		g.discards = append(g.discards, cards...)
+
+		winnings, outcome, why := g.settle(hi)
+		net += winnings
+		g.emit(Event{Kind: EventPayout, Hand: hi, Amount: winnings, Outcome: outcome})
+		g.round.Hands = append(g.round.Hands, HandResult{
+			Start:       hand.start,
+			Actions:     hand.actions,
+			Cards:       cards,
+			Bet:         hand.bet,
+			Net:         winnings,
+			Outcome:     outcome,
+			Explanation: why,
+		})
+	}
+	hands := net // Back bets ride on the hands, not the seat's side bets
+	net += g.round.Bonus
+	if g.round.Insurance > 0 {
+		g.round.InsuranceNet = InsurancePays(g.round.Insurance, g.dealer)
+		net += g.round.InsuranceNet
+	}
+	g.balance += net
+	g.emit(Event{Kind: EventEnd, Amount: net})
+	settleBehind(g, hands)
+	g.round.Dealer = g.copyCards(g.dealer)
+	g.round.Net = net
+	g.round.Drawn, g.round.Left = g.dealtFrom-len(g.deck), len(g.deck)
+	// The hands belong to the round's result from here on
+	if g.arena != nil {
+		g.arena.hands, g.arena.all, g.arena.results = g.player[:0], allHands[:0], g.round.Hands[:0]
+	}
+	g.player = nil
+	before := g.fingerprint()
+	if g.onRound != nil {
+		g.onRound(g.round)
+		if g.audit != nil {
+			g.audited("OnRound", before, g.round)
+		}
+	}
+	g.discards = append(g.discards, g.dealer...)
+	g.observe(g.dealer[1:]...) // The hole card and the dealer's draws
+	if g.counter != nil {
+		g.counter.EndRound()
+	}
+	dealer := g.copyCards(g.dealer)
+	before = g.fingerprint()
+	ai.Results(allHands, dealer)
+	if g.audit != nil {
+		g.audited("Results", before, allHands, dealer)
+	}
+	if rw, ok := ai.(RoundWatcher); ok {
+		rw.Settled(g.round)
+		if g.audit != nil {
+			g.audited("Settled", before, g.round)
+		}
+	}
+	g.dealer = nil
+	g.enter(Betting)
+}
+
+// Score calculates the best possible score for a hand.
+func Score(hand ...deck.Card) int {
+	minScore := minScore(hand...)
+	if minScore > 11 {
+		return minScore
+	}
+	for _, c := range hand {
+		if c.Rank == deck.Ace {
+			return minScore + 10
+		}
+	}
+	return minScore
+}
+
+// soft 17 score for dealer
+func Soft(hand ...deck.Card) bool {
+	minScore := minScore(hand...)
+	score := Score(hand...)
+	return minScore != score
+}
+
+// identifies a blackjack
+func Blackjack(hand ...deck.Card) bool {
+	return len(hand) == 2 && Score(hand...) == 21
+}
+
+func minScore(hand ...deck.Card) int {
+	score := 0
+	for _, c := range hand {
+		score += c.BlackjackValue()
+	}
+
+	return score
+}
+
+// helper func
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,47 @@
+package blackjack
+
+import "github.com/Scrimzay/blackjacksimulator/deck"
+
+// HoleCarder is implemented by AIs that get a glimpse of the dealer's hole card,
+// as a hole-card player does when a dealer flashes it. The read is only as good
+// as Options.HoleCardReliability.
+type HoleCarder interface {
+	// HoleCard is called after the deal with the card the player believes the dealer holds.
+	HoleCard(card deck.Card)
+}
+
+// peekHoleCard shows the hole card to a HoleCarder AI, misreading it as some
+// other rank at the configured rate.
+func (g *Game) peekHoleCard(ai AI) {
+	hc, ok := ai.(HoleCarder)
+	if !ok || g.holeCardReliability <= 0 || g.noHoleCard {
+		return
+	}
+	card := g.dealer[1]
+	if g.rand.Float64() >= g.holeCardReliability {
+		cards := g.variant.Shoe(1)
+		for {
+			misread := cards[g.rand.Intn(len(cards))]
+			if misread.Rank != card.Rank {
+				card = misread
+				break
+			}
+		}
+	}
+	hc.HoleCard(card)
+}
+
+// dealHoleCard gives the dealer a second card once the players have acted, at
+// a table without a hole card (Options.NoHoleCard). forced holds the dealer's
+// forced cards, the second of which is dealt here if there is one.
+func (g *Game) dealHoleCard(forced []deck.Card) {
+	var card deck.Card
+	if len(forced) > 1 {
+		card = g.take(forced[1])
+		g.emitForced(DealerHand, card)
+	} else {
+		card = g.drawFor(DealerHand)
+		g.emitCard(DealerHand, card)
+	}
+	g.dealer = append(g.dealer, card)
+}
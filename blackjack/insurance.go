@@ -0,0 +1,55 @@
+package blackjack
+
+import "github.com/Scrimzay/blackjacksimulator/deck"
+
+// Insurer is implemented by AIs that decide whether to take insurance. When
+// the dealer shows an ace, Insure is called after the deal, before the dealer
+// checks for blackjack (and after Count, for a CountWatcher). Taking it puts
+// up half the bet again, which pays 2 to 1 if the dealer has a natural and is
+// lost otherwise. AIs that don't implement it never insure, and neither do
+// stepped rounds.
+type Insurer interface {
+	Insure(hand []deck.Card, up deck.Card) bool
+}
+
+// TakeInsurance reports whether ai insures hand against the dealer's ace up:
+// what its Insure method says if it is an Insurer, or no. Wrapping AIs use it
+// to ask the AI they wrap.
+func TakeInsurance(ai AI, hand []deck.Card, up deck.Card) bool {
+	ins, ok := ai.(Insurer)
+	return ok && ins.Insure(hand, up)
+}
+
+// offerInsurance asks an Insurer seat whether it insures its hand against the
+// dealer's ace.
+func (g *Game) offerInsurance(ai AI) {
+	ins, ok := ai.(Insurer)
+	if !ok || g.dealer[0].Rank != deck.Ace || g.playerBet < 2 {
+		return
+	}
+	if cw, ok := ai.(CountWatcher); ok {
+		if c, ok := g.Count(); ok {
+			cw.Count(c)
+		}
+	}
+	hand := g.copyCards(g.player[0].cards)
+	before := g.fingerprint()
+	insure := ins.Insure(hand, g.dealer[0])
+	if g.audit != nil {
+		g.audited("Insure", before, hand)
+	}
+	if insure {
+		g.round.Insurance = g.playerBet / 2
+		g.emit(Event{Kind: EventInsurance, Amount: g.round.Insurance})
+	}
+}
+
+// InsurancePays returns what an insurance bet of amount comes to against the
+// dealer's cards: twice the bet if the first two are a natural, the bet lost
+// if not.
+func InsurancePays(amount int, dealer []deck.Card) int {
+	if len(dealer) >= 2 && Blackjack(dealer[:2]...) {
+		return 2 * amount
+	}
+	return -amount
+}
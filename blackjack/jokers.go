@@ -0,0 +1,66 @@
+package blackjack
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Blackjack has no value for a joker, so the engine won't deal one unless the
+// table plays the home rule of jokers as bonus cards (Options.Jokers): a joker
+// is never part of a hand; one dealt to the seat pays it a bonus, one dealt to
+// the dealer is simply set aside, and either way the next card takes its place.
+
+// errJoker is the panic of a game whose shoe has a joker it can't play.
+const errJoker = "The shoe has a joker, which blackjack can't score: strip it, or set Options.Jokers to play jokers as bonus cards"
+
+// fullShoe returns the cards of a new shoe, jokers included.
+func (g *Game) fullShoe() []deck.Card {
+	return deck.Jokers(g.jokers)(g.variant.Shoe(g.nDecks))
+}
+
+// checkJokers panics if the shoe has a joker the table doesn't play, which
+// can only get there from a rebuilt game or a custom shoe.
+func (g *Game) checkJokers() {
+	if g.jokers == 0 && slices.ContainsFunc(g.deck, isJoker) {
+		panic(errJoker)
+	}
+}
+
+// isJoker reports whether c is a joker.
+func isJoker(c deck.Card) bool {
+	return c.Suit == deck.Joker
+}
+
+// drawFor draws the next card for a hand, or DealerHand, setting aside any
+// jokers on the way and paying the seat's bonus for those that were its.
+func (g *Game) drawFor(hand int) deck.Card {
+	for {
+		var card deck.Card
+		card, g.deck = deck.Draw(g.deck)
+		if !isJoker(card) {
+			return card
+		}
+		bonus := 0
+		if hand != DealerHand {
+			bonus = int(g.jokerBonus * float64(g.round.Bet))
+			g.round.Bonus += bonus
+		}
+		if g.events != nil {
+			g.emit(Event{Kind: EventJoker, Hand: hand, Card: &card, Amount: bonus})
+		}
+		g.discards = append(g.discards, card)
+		g.composition.Remove(card)
+	}
+}
+
+// checkJokerOptions panics if the joker options make no sense.
+func checkJokerOptions(opts Options) {
+	if opts.Jokers < 0 {
+		panic(fmt.Sprintf("Can't shuffle %d jokers into the shoe", opts.Jokers))
+	}
+	if opts.JokerBonus < 0 {
+		panic(fmt.Sprintf("A joker bonus of %g isn't a payout", opts.JokerBonus))
+	}
+}
@@ -0,0 +1,45 @@
+package blackjack
+
+// Outcome is how a hand was settled.
+type Outcome string
+
+const (
+	OutcomeWin        Outcome = "win"         // The hand beat the dealer's total
+	OutcomeLoss       Outcome = "loss"        // The dealer's total or blackjack beat the hand
+	OutcomePush       Outcome = "push"        // The bet was returned
+	OutcomeBlackjack  Outcome = "blackjack"   // A natural, paid at the blackjack payout
+	OutcomeSurrender  Outcome = "surrender"   // Half the bet was given up
+	OutcomeBust       Outcome = "bust"        // The hand went over 21
+	OutcomeDealerBust Outcome = "dealer-bust" // The dealer went over 21
+)
+
+// Outcomes lists every outcome.
+var Outcomes = []Outcome{OutcomeWin, OutcomeLoss, OutcomePush, OutcomeBlackjack, OutcomeSurrender, OutcomeBust, OutcomeDealerBust}
+
+// RoundWatcher is implemented by AIs that want to know how each hand was
+// settled. Play calls Settled after Results.
+type RoundWatcher interface {
+	Settled(r RoundResult)
+}
+
+// settle works out what the i-th hand wins or loses against the dealer's
+// hand, how, and why, in words, from the game's Settler.
+func (g *Game) settle(i int) (int, Outcome, string) {
+	h := g.player[i]
+	ctx := SettleContext{
+		Cards:       g.copyCards(h.cards),
+		Dealer:      g.copyCards(g.dealer),
+		Bet:         h.bet,
+		OriginalBet: g.playerBet,
+		Hand:        i,
+		Hands:       len(g.player),
+		Surrendered: h.surrendered,
+		Rules:       g.Rules(),
+	}
+	before := g.fingerprint()
+	p := g.settler.Settle(ctx)
+	if g.audit != nil {
+		g.audited("Settle", before, ctx.Cards, ctx.Dealer)
+	}
+	return p.Net, p.Outcome, p.Explanation
+}
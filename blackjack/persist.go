@@ -0,0 +1,63 @@
+package blackjack
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Saver is implemented by AIs that learn as they play, such as reinforcement
+// learners or strategies that adapt to the table, and can write down what
+// they've learned (weights, tables) for the next run to pick up.
+type Saver interface {
+	Save(w io.Writer) error
+}
+
+// Loader is implemented by AIs that can pick up the state a Saver wrote, so
+// they can be trained a run at a time.
+type Loader interface {
+	Load(r io.Reader) error
+}
+
+// LoadState loads an AI's learned state from a file, if the AI is a Loader and
+// the file exists. A missing file isn't an error: the AI starts from scratch.
+func LoadState(ai AI, name string) error {
+	l, ok := ai.(Loader)
+	if !ok {
+		return nil
+	}
+	f, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return l.Load(f)
+}
+
+// SaveState saves an AI's learned state to a file, if the AI is a Saver. The
+// file is replaced only once the state is written in full, so a failed save
+// leaves the last one in place.
+func SaveState(ai AI, name string) error {
+	s, ok := ai.(Saver)
+	if !ok {
+		return nil
+	}
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := s.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
@@ -0,0 +1,74 @@
+package blackjack
+
+import "fmt"
+
+// Phase is the stage of a round the game is at. Every round goes Betting,
+// PlayerTurn, then DealerTurn unless the dealer peeked a blackjack, then
+// Settlement, and back to Betting for the next.
+type Phase int8
+
+const (
+	Betting    Phase = iota // Between rounds: the bet, the shuffle and burn; a new game starts here
+	PlayerTurn              // From the deal until the seat has played all its hands
+	DealerTurn              // The dealer plays out their hand
+	Settlement              // Hands are paid and collected
+)
+
+// next holds the phases each phase may move on to.
+var next = map[Phase][]Phase{
+	Betting:    {PlayerTurn},
+	PlayerTurn: {DealerTurn, Settlement},
+	DealerTurn: {Settlement},
+	Settlement: {Betting},
+}
+
+var phaseNames = map[Phase]string{
+	Betting:    "betting",
+	PlayerTurn: "player-turn",
+	DealerTurn: "dealer-turn",
+	Settlement: "settlement",
+}
+
+func (p Phase) String() string {
+	if name, ok := phaseNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Phase(%d)", p)
+}
+
+// MarshalText writes the phase by name, so event logs stay readable.
+func (p Phase) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText reads a phase written by MarshalText.
+func (p *Phase) UnmarshalText(b []byte) error {
+	for phase, name := range phaseNames {
+		if name == string(b) {
+			*p = phase
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown phase %q", b)
+}
+
+// Phase returns the stage of the round the game is at, e.g. for an AI or a
+// watcher called mid-round to tell the deal from the settlement.
+func (g *Game) Phase() Phase {
+	return g.phase
+}
+
+// enter moves the game on to phase p, which must follow the current one.
+// Entering the current phase again does nothing.
+func (g *Game) enter(p Phase) {
+	if p == g.phase {
+		return
+	}
+	for _, ok := range next[g.phase] {
+		if ok == p {
+			g.phase = p
+			return
+		}
+	}
+	panic(fmt.Sprintf("Can't go from %v to %v", g.phase, p))
+}
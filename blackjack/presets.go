@@ -0,0 +1,52 @@
+package blackjack
+
+// Presets are the usual rules for tables of each size, to start Options from.
+// Hand pitched single- and double-deck games are dealt shallower and, to make
+// up for the fewer decks, usually take something back in the rules. Hands,
+// bets and everything else are left to the caller.
+var Presets = map[string]Options{
+	// Single deck: double on 10 and 11 only, no doubling or resplitting after a
+	// split, half the deck dealt.
+	"single": {
+		Decks:              1,
+		BlackjackPayout:    1.5,
+		DoubleOn:           DoubleTenToEleven,
+		NoDoubleAfterSplit: true,
+		SplitHands:         2,
+		Penetration:        0.5,
+	},
+	// Double deck: the dealer hits soft 17, but doubling is allowed on any two
+	// cards and after splits.
+	"double": {
+		Decks:           2,
+		BlackjackPayout: 1.5,
+		Penetration:     0.6,
+	},
+	// Six-deck shoe: the dealer stands on soft 17, the shoe is dealt three
+	// quarters of the way through.
+	"shoe": {
+		Decks:           6,
+		BlackjackPayout: 1.5,
+		StandSoft17:     true,
+		Penetration:     0.75,
+	},
+}
+
+// DefaultPenetration returns the fraction of the shoe dealt before it's
+// reshuffled when Options.Penetration isn't set: half of a single deck, 60% of
+// a double deck, and two thirds of a shoe.
+func DefaultPenetration(decks int) float64 {
+	switch decks {
+	case 1:
+		return 0.5
+	case 2:
+		return 0.6
+	}
+	return 2.0 / 3
+}
+
+// reshuffleAt returns the number of cards left in the shoe that calls for a
+// reshuffle.
+func (g *Game) reshuffleAt() int {
+	return int(float64(g.shoeSize) * (1 - g.penetration))
+}
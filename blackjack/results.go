@@ -0,0 +1,83 @@
+package blackjack
+
+import (
+	"slices"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// RoundResult describes a settled round, as passed to Options.OnRound.
+type RoundResult struct {
+	Round    int          // Number of the round within Play, from 1
+	Shuffled bool         // Whether the round was the first of a new shoe
+	Seated   bool         // Whether the AI played the round rather than watching it
+	Bet      int          // The seat's original bet, 0 if it sat out
+	Hands    []HandResult // The seat's hands, in the order they were played
+	Dealer   []deck.Card  // The dealer's final cards, upcard first
+	Net      int          // Total won or lost on the round, Bonus and insurance included
+	Bonus    int          // Paid for jokers dealt to the seat, under Options.Jokers
+	Count    *Count       // The engine's count before the deal, nil unless Options.Count is set
+	Drawn    int          // Cards the round took from the shoe, burned, forced and set-aside ones included
+	Left     int          // Cards left in the shoe once the round was settled
+
+	DealerError DealerError // Mistake the dealer made during the round, if any
+
+	Insurance    int // Insurance bet against the dealer's ace, 0 if the seat didn't take it
+	InsuranceNet int // Won or lost on the insurance bet
+}
+
+// Clone returns a deep copy of r, which stays valid after the round's slices
+// are reused under Options.Reuse.
+func (r RoundResult) Clone() RoundResult {
+	r.Hands = slices.Clone(r.Hands)
+	for i := range r.Hands {
+		h := &r.Hands[i]
+		h.Start, h.Actions, h.Cards = slices.Clone(h.Start), slices.Clone(h.Actions), slices.Clone(h.Cards)
+	}
+	r.Dealer = slices.Clone(r.Dealer)
+	if r.Count != nil {
+		c := *r.Count
+		r.Count = &c
+	}
+	return r
+}
+
+// HandResult is one of the seat's hands at the end of a round.
+type HandResult struct {
+	Start   []deck.Card // Cards the hand started with: the deal, or the card it was split off with
+	Actions []Action    // Moves made on the hand, in order
+	Cards   []deck.Card // Final cards
+	Bet     int         // Final amount wagered on the hand
+	Net     int         // Amount won or lost on the hand
+
+	Outcome     Outcome // How the hand was settled, empty if not known
+	Explanation string  // Why, in words, e.g. "20 beats 19"
+}
+
+// Action is a move made on a hand.
+type Action struct {
+	Move string    // Name of the move, as returned by Move.String
+	Card deck.Card // Card the move drew, the zero Card if it drew none
+}
+
+// Drew reports whether the action drew a card.
+func (a Action) Drew() bool {
+	return a.Card.Rank != 0
+}
+
+// recordAction notes a move made on the i-th hand. before is the number of
+// cards the hand had before the move.
+func (g *Game) recordAction(i int, move Move, before int) {
+	h := &g.player[i]
+	a := Action{Move: move.String()}
+	switch {
+	case len(h.cards) > before:
+		a.Card = h.cards[before]
+	case a.Move == "split" && len(h.cards) == 2:
+		a.Card = h.cards[1] // The split hand's second card
+	}
+	h.actions = append(h.actions, a)
+	if a.Drew() {
+		g.observe(a.Card)
+	}
+}
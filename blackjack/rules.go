@@ -0,0 +1,123 @@
+package blackjack
+
+import "github.com/Scrimzay/blackjacksimulator/deck"
+
+// Variant selects a family of table rules that changes how the game is dealt and settled.
+type Variant int8
+
+const (
+	Classic    Variant = iota // Standard blackjack with 52-card decks
+	SuperFun21                // Super Fun 21, see below
+)
+
+// Super Fun 21 is played with Spanish decks (all Tens removed, Jacks/Queens/Kings
+// remain) and looks generous on paper:
+// - A player 21 always wins, and a player blackjack beats a dealer blackjack
+// - Late surrender is allowed on any number of cards
+// - Blackjack pays 1:1, except a blackjack in diamonds which pays 2:1
+// Losing the Tens and the 3:2 blackjack costs far more than the extras give back,
+// which is easy to confirm by simulating it against Classic.
+
+// DoubleRule restricts which two-card totals may be doubled.
+type DoubleRule int8
+
+const (
+	DoubleAny          DoubleRule = iota // Double on any two cards
+	DoubleNineToEleven                   // Double on hard 9, 10 and 11 only
+	DoubleTenToEleven                    // Double on hard 10 and 11 only (common in Europe and single-deck games)
+)
+
+// allows reports whether a two-card hand may be doubled under the rule.
+func (r DoubleRule) allows(hand []deck.Card) bool {
+	return r.Allows(Score(hand...))
+}
+
+// Allows reports whether a two-card hand with the given score may be doubled
+// under the rule.
+func (r DoubleRule) Allows(score int) bool {
+	switch r {
+	case DoubleNineToEleven:
+		return score >= 9 && score <= 11
+	case DoubleTenToEleven:
+		return score >= 10 && score <= 11
+	default:
+		return true
+	}
+}
+
+// PeekRule says under which upcards the dealer checks the hole card for
+// blackjack before the players act. A blackjack the dealer doesn't peek for only
+// comes out once the hands are played, and takes doubled and split bets with it.
+type PeekRule int8
+
+const (
+	PeekAceTen PeekRule = iota // Peek under aces and ten-value cards
+	PeekAce                    // Peek under aces only
+	NoPeek                     // Never peek
+)
+
+// Peeks reports whether the dealer checks for blackjack under the upcard.
+func (r PeekRule) Peeks(up deck.Card) bool {
+	switch r {
+	case PeekAce:
+		return up.Rank == deck.Ace
+	case NoPeek:
+		return false
+	default:
+		return up.Rank == deck.Ace || up.Rank.IsTenValue()
+	}
+}
+
+// Rules describes the rules a game is played under, as far as they matter to
+// a playing strategy. It is the Options the game was created with, with the
+// defaults filled in.
+type Rules struct {
+	Decks            int        // Number of decks in the shoe
+	Variant          Variant    // Rule variant
+	StandSoft17      bool       // Dealer stands on soft 17 rather than hitting it
+	DoubleOn         DoubleRule // Which two-card totals may be doubled
+	DoubleAfterSplit bool       // Split hands may be doubled
+	SplitHands       int        // Most hands a seat can split into
+	ResplitAces      bool       // Split aces may be split again
+	HitSplitAces     bool       // Split aces may be played on rather than getting one card each
+	Surrender        bool       // Hands may be surrendered for half the bet
+	DealerWinsTies   bool       // Ties lose instead of pushing
+	Push22           bool       // A dealer 22 pushes
+	Peek             PeekRule   // Upcards the dealer checks for blackjack under
+	OriginalBetsOnly bool       // An unpeeked dealer blackjack only takes the original bet
+	BlackjackPayout  float64    // Payout ratio for blackjack
+}
+
+// RulesTaker is implemented by AIs that adapt their play to the rules of the
+// game. Play calls SetRules before the first round.
+type RulesTaker interface {
+	SetRules(Rules)
+}
+
+// Rules returns the rules the game is played under.
+func (g *Game) Rules() Rules {
+	return Rules{
+		Decks:            g.nDecks,
+		Variant:          g.variant,
+		StandSoft17:      g.standSoft17,
+		DoubleOn:         g.doubleOn,
+		DoubleAfterSplit: !g.noDoubleAfterSplit,
+		SplitHands:       g.splitHands,
+		ResplitAces:      g.resplitAces,
+		HitSplitAces:     g.hitSplitAces,
+		Surrender:        g.variant == SuperFun21 || g.surrender,
+		DealerWinsTies:   g.dealerWinsTies,
+		Push22:           g.push22,
+		Peek:             g.peek,
+		OriginalBetsOnly: g.originalBetsOnly,
+		BlackjackPayout:  g.blackjackPayout,
+	}
+}
+
+// Shoe builds a new, unshuffled shoe of the given number of decks for the variant.
+func (v Variant) Shoe(decks int) []deck.Card {
+	if v == SuperFun21 {
+		return deck.New(deck.WithoutRanks(deck.Ten), deck.Deck(decks))
+	}
+	return deck.New(deck.Deck(decks))
+}
@@ -0,0 +1,53 @@
+package blackjack
+
+import "math/rand"
+
+// Seeder is implemented by AIs and bettors with randomness of their own. When
+// Options.AISeed is set, Play seeds the AI with it and Options.Bettor with a
+// seed derived from it, so their choices can vary between runs while the
+// shoe, seeded by Options.Seed, stays the same, or the other way round.
+type Seeder interface {
+	Seed(seed int64)
+}
+
+// Streams of randomness derived from a seed.
+const (
+	streamShoe = iota
+	streamEngine
+	streamAI
+	streamBettor
+	streamBranch
+	streamAudit
+)
+
+// deriveSeed returns an independent seed for one stream of randomness,
+// scrambling the seed with splitmix64 so that neighbouring seeds and streams
+// don't give correlated sequences.
+func deriveSeed(seed int64, stream int) int64 {
+	z := uint64(seed) + uint64(stream+1)*0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return int64(z ^ z>>31)
+}
+
+// seed sets up the engine's randomness from the options.
+func (g *Game) seed(opts Options) {
+	if opts.Seed == 0 {
+		return
+	}
+	g.shuffleRand = rand.New(rand.NewSource(deriveSeed(opts.Seed, streamShoe)))
+	g.rand = rand.New(rand.NewSource(deriveSeed(opts.Seed, streamEngine)))
+}
+
+// seedPlayers seeds the AI and the bettor at the start of Play.
+func (g *Game) seedPlayers(ai AI) {
+	if g.aiSeed == 0 {
+		return
+	}
+	if s, ok := ai.(Seeder); ok {
+		s.Seed(deriveSeed(g.aiSeed, streamAI))
+	}
+	if s, ok := g.bettor.(Seeder); ok {
+		s.Seed(deriveSeed(g.aiSeed, streamBettor))
+	}
+}
@@ -0,0 +1,61 @@
+package blackjack
+
+// StopReason says why Play stopped before dealing every hand it was asked to.
+type StopReason string
+
+const (
+	StoppedLoss  StopReason = "stop-loss" // The session lost Options.StopLoss
+	StoppedWin   StopReason = "stop-win"  // The session won Options.StopWin
+	StoppedBroke StopReason = "broke"     // The bankroll can't cover the table minimum and there are no rebuys left
+	StoppedLeft  StopReason = "left"      // The AI got up from the table
+	StoppedDone  StopReason = "done"      // Options.Leave ended the session
+)
+
+// Leaver is implemented by AIs that can get up from the table for good, like a
+// table of players who have all gone broke. Play asks before each round.
+type Leaver interface {
+	Leave() bool
+}
+
+// Session tracks the seat's money over the game: what it brought to the table
+// and why it left.
+type Session struct {
+	Rebuys   int        // Times the seat bought back in
+	BoughtIn int        // Starting bankroll plus every rebuy
+	Stopped  StopReason // Why the last Play stopped early, empty if it played out
+}
+
+// Session returns the seat's session so far.
+func (g *Game) Session() Session {
+	return Session{
+		Rebuys:   g.rebuys,
+		BoughtIn: g.bankroll + g.rebought,
+		Stopped:  g.stopped,
+	}
+}
+
+// stop reports whether the session rules or the AI end play before the next
+// round, buying back in first if the bankroll has run out and a rebuy is left.
+func (g *Game) stop(ai AI) bool {
+	switch {
+	case g.stopLoss > 0 && -g.balance >= g.stopLoss:
+		g.stopped = StoppedLoss
+	case g.stopWin > 0 && g.balance >= g.stopWin:
+		g.stopped = StoppedWin
+	case g.bankroll > 0 && g.bankroll+g.rebought+g.balance < g.minBet:
+		if g.rebuys == g.maxRebuys {
+			g.stopped = StoppedBroke
+			break
+		}
+		g.rebuys++
+		g.rebought += g.bankroll
+		g.emit(Event{Kind: EventRebuy, Amount: g.bankroll})
+	}
+	if l, ok := ai.(Leaver); ok && g.stopped == "" && l.Leave() {
+		g.stopped = StoppedLeft
+	}
+	if g.leave != nil && g.stopped == "" && g.leave() {
+		g.stopped = StoppedDone
+	}
+	return g.stopped != ""
+}
@@ -0,0 +1,109 @@
+package blackjack
+
+import (
+	"fmt"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// SettleContext is a finished hand, ready to be settled against the dealer's.
+type SettleContext struct {
+	Cards       []deck.Card // The hand's cards
+	Dealer      []deck.Card // The dealer's final hand
+	Bet         int         // Bet on the hand, doubles included
+	OriginalBet int         // The seat's bet before any doubles or splits
+	Hand        int         // Which of the seat's hands it is, from 0
+	Hands       int         // How many hands the seat ended up with, more than 1 after a split
+	Surrendered bool        // Whether the hand was surrendered
+	Rules       Rules       // Rules the table is played under
+}
+
+// Payout is what a settled hand comes to.
+type Payout struct {
+	Net         int     // Amount won, negative if lost
+	Outcome     Outcome // How the hand was settled
+	Explanation string  // Why, in words
+}
+
+// Settler works out what every hand pays once the dealer's hand is done.
+// Setting Options.Settler replaces the variant's own, so exotic paytables,
+// like a bonus for a suited 6-7-8 or hands that win automatically, can pay
+// their hands and leave the rest to the variant's Settler.
+type Settler interface {
+	Settle(ctx SettleContext) Payout
+}
+
+// Settler returns the Settler that pays hands by the variant's rules.
+func (v Variant) Settler() Settler {
+	if v == SuperFun21 {
+		return superFun21Settler{}
+	}
+	return StandardSettler{}
+}
+
+// StandardSettler pays hands by the classic rules, as adjusted by the table's
+// Rules.
+type StandardSettler struct{}
+
+// Settle works out what the hand wins or loses against the dealer's.
+func (StandardSettler) Settle(ctx SettleContext) Payout {
+	r, bet := ctx.Rules, ctx.Bet
+	dScore, dBlackjack := Score(ctx.Dealer...), Blackjack(ctx.Dealer...)
+	pScore := Score(ctx.Cards...)
+	pBlackjack := Blackjack(ctx.Cards...) && ctx.Hands == 1 // 21 on a split hand isn't a natural
+
+	switch {
+	case ctx.Surrendered && dBlackjack:
+		return Payout{-bet, OutcomeLoss, "Dealer blackjack takes a late surrender's whole bet"}
+	case ctx.Surrendered:
+		return Payout{-bet / 2, OutcomeSurrender, "Surrendered for half the bet"}
+	case pBlackjack && dBlackjack:
+		if r.DealerWinsTies {
+			return Payout{-bet, OutcomeLoss, "Blackjack against blackjack goes to the dealer"}
+		}
+		return Payout{0, OutcomePush, "Blackjack against blackjack pushes"}
+	case dBlackjack && r.OriginalBetsOnly && ctx.Hand > 0:
+		return Payout{0, OutcomePush, "Split bet returned: only the original bet is lost to a blackjack"}
+	case dBlackjack && r.OriginalBetsOnly && (bet != ctx.OriginalBet || ctx.Hands > 1):
+		return Payout{-ctx.OriginalBet, OutcomeLoss, fmt.Sprintf("Dealer blackjack beats %d, taking only the original bet", pScore)}
+	case dBlackjack:
+		return Payout{-bet, OutcomeLoss, fmt.Sprintf("Dealer blackjack beats %d", pScore)}
+	case pScore > 21:
+		return Payout{-bet, OutcomeBust, fmt.Sprintf("Bust with %d", pScore)}
+	case pBlackjack:
+		return Payout{int(float64(bet) * r.BlackjackPayout), OutcomeBlackjack, fmt.Sprintf("Blackjack pays %g to 1", r.BlackjackPayout)}
+	case dScore == 22 && r.Push22:
+		return Payout{0, OutcomePush, "Dealer 22 pushes"}
+	case dScore > 21:
+		return Payout{bet, OutcomeDealerBust, fmt.Sprintf("Dealer busts with %d", dScore)}
+	case pScore > dScore:
+		return Payout{bet, OutcomeWin, fmt.Sprintf("%d beats %d", pScore, dScore)}
+	case pScore == dScore && r.DealerWinsTies:
+		return Payout{-bet, OutcomeLoss, fmt.Sprintf("Tie at %d goes to the dealer", pScore)}
+	case pScore == dScore:
+		return Payout{0, OutcomePush, fmt.Sprintf("Push at %d", pScore)}
+	default:
+		return Payout{-bet, OutcomeLoss, fmt.Sprintf("%d loses to %d", pScore, dScore)}
+	}
+}
+
+// superFun21Settler pays Super Fun 21's extras and leaves the rest of the
+// hands to the classic rules.
+type superFun21Settler struct{}
+
+// Settle pays surrenders, naturals and player 21s the Super Fun 21 way.
+func (superFun21Settler) Settle(ctx SettleContext) Payout {
+	switch {
+	case ctx.Surrendered:
+		return Payout{-ctx.Bet / 2, OutcomeSurrender, "Surrendered for half the bet"}
+	case Blackjack(ctx.Cards...) && ctx.Hands == 1:
+		payout := ctx.Rules.BlackjackPayout
+		if ctx.Cards[0].Suit == deck.Diamond && ctx.Cards[1].Suit == deck.Diamond {
+			payout = 2
+		}
+		return Payout{int(float64(ctx.Bet) * payout), OutcomeBlackjack, "A player blackjack always wins in Super Fun 21"}
+	case Score(ctx.Cards...) == 21 && !Blackjack(ctx.Dealer...):
+		return Payout{ctx.Bet, OutcomeWin, "A player 21 always wins in Super Fun 21"}
+	}
+	return StandardSettler{}.Settle(ctx)
+}
@@ -0,0 +1,104 @@
+package blackjack
+
+import (
+	"slices"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Slug is a run of consecutive cards from the discard tray and where its cards
+// ended up after the shuffle.
+type Slug struct {
+	Cards     []deck.Card // Cards in discard-tray order
+	Positions []int       // Position in the new shoe of each card
+}
+
+// Zone returns the range of shoe positions [start, end] the slug was spread over.
+func (s Slug) Zone() (start, end int) {
+	start, end = s.Positions[0], s.Positions[0]
+	for _, p := range s.Positions[1:] {
+		start = min(start, p)
+		end = max(end, p)
+	}
+	return start, end
+}
+
+// ShuffleTracker is implemented by AIs that follow cards through the shuffle.
+// The engine reports exactly where every discarded card went, which is the upper
+// bound of what a real tracker can estimate by eye.
+type ShuffleTracker interface {
+	// Tracked is called after each reshuffle with the discard tray cut into slugs.
+	Tracked(slugs []Slug)
+}
+
+// CardShuffler is an optional interface for shuffle models that need to see the
+// cards they shuffle rather than just how many there are, such as importance
+// samplers that favor shoes of a certain composition.
+type CardShuffler interface {
+	deck.Permer
+
+	// PermCards returns the permutation for the cards, as Perm(len(cards)) would.
+	PermCards(cards []deck.Card) []int
+}
+
+// shuffle builds a new shoe. The discards are stacked on top of the undealt
+// cards and shuffled together, like a dealer would; the first shoe starts from
+// new decks, or the cards of Options.Remaining, and so does every shoe that
+// Options.NewDecks puts new decks in for.
+func (g *Game) shuffle(ai AI) {
+	defer func() {
+		if g.events != nil {
+			g.emit(Event{Kind: EventShuffle, Round: g.rounds + 1, Cards: append([]deck.Card(nil), g.deck...)})
+		}
+	}()
+	if g.deck == nil || g.newDecks > 0 && g.shoes > 0 && g.shoes%g.newDecks == 0 {
+		g.deck, g.discards = g.fullShoe(), nil
+		if g.remaining != nil {
+			g.deck = slices.Clone(g.remaining)
+		}
+	}
+	stack := append(g.discards, g.deck...)
+	discards := len(g.discards)
+	g.discards = nil
+	if g.arena != nil {
+		defer func() { g.discards = stack[:0] }() // The shoe is copied out of the stack
+	}
+
+	if g.shuffler == nil {
+		if g.shuffleRand != nil {
+			g.deck = deck.Permute(stack, g.shuffleRand.Perm(len(stack)))
+		} else {
+			g.deck = deck.Shuffle(stack)
+		}
+		return
+	}
+	var perm []int
+	if cs, ok := g.shuffler.(CardShuffler); ok {
+		perm = cs.PermCards(stack)
+	} else {
+		perm = g.shuffler.Perm(len(stack))
+	}
+	g.deck = deck.Permute(stack, perm)
+
+	tracker, ok := ai.(ShuffleTracker)
+	if !ok || discards == 0 {
+		return
+	}
+	positions := make([]int, len(stack))
+	for i, j := range perm {
+		positions[j] = i
+	}
+	var slugs []Slug
+	for start := 0; start < discards; start += g.slugSize {
+		end := min(start+g.slugSize, discards)
+		slugs = append(slugs, Slug{
+			Cards:     slices.Clone(stack[start:end]), // The stack becomes the discard tray
+			Positions: positions[start:end],
+		})
+	}
+	before := g.fingerprint()
+	tracker.Tracked(slugs)
+	if g.audit != nil {
+		g.audited("Tracked", before, slugs)
+	}
+}
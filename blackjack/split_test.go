@@ -0,0 +1,173 @@
+package blackjack
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// stacked shuffles the shoe with the given cards on top, in dealing order.
+type stacked struct {
+	top  []deck.Card
+	rand *rand.Rand
+}
+
+func (s stacked) Perm(n int) []int {
+	return s.rand.Perm(n)
+}
+
+func (s stacked) PermCards(cards []deck.Card) []int {
+	perm, err := deck.Find(cards, s.top...)
+	if err != nil {
+		panic(err)
+	}
+	used := make([]bool, len(cards))
+	for _, j := range perm {
+		used[j] = true
+	}
+	for j := range cards {
+		if !used[j] {
+			perm = append(perm, j)
+		}
+	}
+	return perm
+}
+
+// parseCards reads cards written like "8S,6D,TC".
+func parseCards(t *testing.T, s string) []deck.Card {
+	t.Helper()
+	var cards []deck.Card
+	for _, f := range strings.Split(s, ",") {
+		rank := strings.IndexByte("A23456789TJQK", f[0]) + 1
+		suit := strings.IndexByte("SDCH", f[1])
+		if rank == 0 || suit < 0 {
+			t.Fatalf("Bad card %q", f)
+		}
+		cards = append(cards, deck.Card{Rank: deck.Rank(rank), Suit: deck.Suit(suit)})
+	}
+	return cards
+}
+
+// stackedRound starts a round of 100 with cards on top of a two deck shoe.
+func stackedRound(t *testing.T, opts Options, cards string) *Game {
+	t.Helper()
+	opts.Decks = 2
+	opts.Shuffler = stacked{top: parseCards(t, cards), rand: rand.New(rand.NewSource(1))}
+	g := New(opts)
+	if err := g.StartRound(100); err != nil {
+		t.Fatal(err)
+	}
+	return &g
+}
+
+// step makes move on the next decision, checking which hand it's on.
+func step(t *testing.T, g *Game, hand int, move Move) {
+	t.Helper()
+	v, apply := g.NextDecision()
+	if apply == nil {
+		t.Fatalf("No decision left for %s on hand %d", move, hand)
+	}
+	if v.HandIndex != hand {
+		t.Fatalf("Deciding on hand %d, want hand %d", v.HandIndex, hand)
+	}
+	if err := apply(move); err != nil {
+		t.Fatalf("%s on hand %d: %v", move, hand, err)
+	}
+}
+
+// finish checks the turn is over and settles the round.
+func finish(t *testing.T, g *Game) RoundResult {
+	t.Helper()
+	if v, apply := g.NextDecision(); apply != nil {
+		t.Fatalf("Turn not over, still deciding on hand %d: %v", v.HandIndex, v.Hand)
+	}
+	r, err := g.FinishRound()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// checkHands compares the final cards of each hand.
+func checkHands(t *testing.T, r RoundResult, want ...string) {
+	t.Helper()
+	if len(r.Hands) != len(want) {
+		t.Fatalf("Got %d hands, want %d", len(r.Hands), len(want))
+	}
+	for i, w := range want {
+		if got := r.Hands[i].Cards; !slices.Equal(got, parseCards(t, w)) {
+			t.Errorf("Hand %d is %v, want %s", i, got, w)
+		}
+	}
+}
+
+func TestResplitStandFirstDoubleSecond(t *testing.T) {
+	g := stackedRound(t, Options{}, "8S,6D,8H,TC,8C,9S,3H,TH,7S,KD")
+	step(t, g, 0, MoveSplit)
+	step(t, g, 0, MoveSplit)
+	v, _ := g.NextDecision()
+	if v.Hands != 3 {
+		t.Fatalf("Got %d hands after resplitting, want 3", v.Hands)
+	}
+	step(t, g, 0, MoveStand)
+	step(t, g, 1, MoveDouble)
+	step(t, g, 2, MoveStand)
+	r := finish(t, g)
+	checkHands(t, r, "8S,9S", "8C,3H,TH", "8H,7S")
+	if r.Hands[1].Bet != 200 {
+		t.Errorf("Doubled hand bet %d, want 200", r.Hands[1].Bet)
+	}
+	if r.Net != 400 {
+		t.Errorf("Net %d, want 400", r.Net)
+	}
+}
+
+func TestSplitHandsLimit(t *testing.T) {
+	g := stackedRound(t, Options{SplitHands: 2}, "8S,6D,8H,TC,8C")
+	step(t, g, 0, MoveSplit)
+	v, apply := g.NextDecision()
+	if v.CanSplit {
+		t.Error("CanSplit on a pair past the SplitHands limit")
+	}
+	if err := apply(MoveSplit); err == nil {
+		t.Fatal("Split into 3 hands with SplitHands 2")
+	}
+	if v, _ := g.NextDecision(); v.Hands != 2 || len(v.Hand) != 2 {
+		t.Errorf("Refused split changed the hands: %d hands, %v", v.Hands, v.Hand)
+	}
+}
+
+func TestSplitAcesGetOneCard(t *testing.T) {
+	g := stackedRound(t, Options{}, "AS,6D,AH,TC,KS,2H,2D")
+	step(t, g, 0, MoveSplit)
+	r := finish(t, g)
+	checkHands(t, r, "AS,KS", "AH,2H")
+	if r.Hands[0].Outcome == OutcomeBlackjack {
+		t.Error("21 on split aces paid as a blackjack")
+	}
+}
+
+func TestSplitAcesNotResplit(t *testing.T) {
+	g := stackedRound(t, Options{}, "AS,6D,AH,TC,AD,AC,TD")
+	step(t, g, 0, MoveSplit)
+	r := finish(t, g)
+	checkHands(t, r, "AS,AD", "AH,AC")
+}
+
+func TestResplitAces(t *testing.T) {
+	g := stackedRound(t, Options{ResplitAces: true}, "AS,6D,AH,TC,AD,5S,4H,3C,TD")
+	step(t, g, 0, MoveSplit)
+	v, apply := g.NextDecision()
+	if !v.CanSplit || v.CanHit || v.CanDouble {
+		t.Fatalf("Split aces pair can split %v, hit %v, double %v; want only split", v.CanSplit, v.CanHit, v.CanDouble)
+	}
+	if err := apply(MoveHit); err == nil {
+		t.Fatal("Hit split aces")
+	}
+	step(t, g, 0, MoveSplit)
+	r := finish(t, g)
+	checkHands(t, r, "AS,5S", "AD,4H", "AH,3C")
+}
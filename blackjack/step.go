@@ -0,0 +1,137 @@
+package blackjack
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// The step API lets a frontend, a server or a learning environment drive the
+// game one decision at a time instead of handing Play an AI:
+//
+//	g.StartRound(bet)
+//	for v, apply := g.NextDecision(); apply != nil; v, apply = g.NextDecision() {
+//		apply(decide(v))
+//	}
+//	result, err := g.FinishRound()
+//
+// Stepped rounds share the shoe, balance, count and events with Play, but the
+// session rules (stop-loss, stop-win, rebuys) are left to the caller.
+
+var (
+	errRoundStarted  = errors.New("A round is already in progress")
+	errNoRound       = errors.New("No round was started")
+	errTurnNotOver   = errors.New("The player's turn isn't over")
+	errStaleDecision = errors.New("That decision was already made")
+	errNoMove        = errors.New("No move was given")
+)
+
+// stepper stands in for the AI in stepped rounds. Its bet is the one given to
+// StartRound, and its decisions come through NextDecision.
+type stepper struct {
+	bet int // The round's bet
+}
+
+// Bet places the bet given to StartRound.
+func (s stepper) Bet(ctx BetContext) int {
+	return s.bet
+}
+
+// Play is never called: the seat's decisions are applied by NextDecision.
+func (s stepper) Play(hand []deck.Card, dealer deck.Card) Move {
+	panic("Stepped rounds are played with NextDecision")
+}
+
+// Results is a no-op, FinishRound returns the round instead.
+func (s stepper) Results(hand [][]deck.Card, dealer []deck.Card) {}
+
+// StartRound bets bet and deals a round, reshuffling first if the shoe is
+// due. Options.Bettor is ignored: the bet given is the one placed. It returns
+// an error if the bet isn't valid at the table or a round is in progress.
+func (g *Game) StartRound(bet int) error {
+	if g.stepping || g.phase != Betting {
+		return errRoundStarted
+	}
+	g.checkJokers()
+	shuffled := len(g.deck) < g.reshuffleAt()
+	if err := g.betContext(shuffled).Check(bet); err != nil {
+		return err
+	}
+	ai := stepper{bet: bet}
+	if shuffled {
+		g.newShoe(ai)
+	}
+	defer func(b Bettor) { g.bettor = b }(g.bettor)
+	g.bettor = ai
+	g.forcedDealer = nil
+	g.stepping = true
+	g.startRound(ai, shuffled, nil)
+	return nil
+}
+
+// NextDecision returns the decision the seat faces and a function that makes
+// it. apply returns the error of a move that isn't allowed, leaving the hand
+// as it was, or of a decision that was already made. apply is nil once the
+// player's turn is over, when it's time for FinishRound.
+func (g *Game) NextDecision() (v GameView, apply func(Move) error) {
+	if !g.stepping || g.phase != PlayerTurn {
+		return GameView{}, nil
+	}
+	v = g.view()
+	decision := g.decisions
+	return v, func(move Move) error {
+		if g.decisions != decision || g.phase != PlayerTurn {
+			return errStaleDecision
+		}
+		if err := v.allows(move); err != nil {
+			return err
+		}
+		if err := g.apply(move, len(v.Hand)); err != nil {
+			return err
+		}
+		g.decisions++
+		return nil
+	}
+}
+
+// allows returns an error if the view rules out move, so a refused move is
+// never recorded in the events.
+func (v GameView) allows(move Move) error {
+	if move == nil {
+		return errNoMove
+	}
+	allowed := true
+	name := move.String()
+	switch name {
+	case "hit":
+		allowed = v.CanHit
+	case "double":
+		allowed = v.CanDouble
+	case "split":
+		allowed = v.CanSplit
+	case "surrender":
+		allowed = v.CanSurrender
+	}
+	if !allowed {
+		return fmt.Errorf("Can't %s on this hand", name)
+	}
+	return nil
+}
+
+// FinishRound plays the dealer's hand and settles the round once the player's
+// turn is over. With Options.Reuse the result is only valid until the next
+// round starts.
+func (g *Game) FinishRound() (RoundResult, error) {
+	switch {
+	case !g.stepping:
+		return RoundResult{}, errNoRound
+	case g.phase == PlayerTurn:
+		return RoundResult{}, errTurnNotOver
+	case g.phase == DealerTurn:
+		g.settleRound(stepper{})
+	}
+	g.stepping = false
+	g.played++
+	return g.round, nil
+}
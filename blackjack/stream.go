@@ -0,0 +1,33 @@
+package blackjack
+
+// streamBuffer is how many settled rounds PlayStream lets pile up before play
+// waits for the consumer to catch up.
+const streamBuffer = 64
+
+// PlayStream plays like Play, but in the background, sending every settled
+// round on the returned channel as it's produced. The channel is closed when
+// play stops. Rounds are copied under Options.Reuse so they stay valid, and
+// Options.OnRound is still called first. The game mustn't be used until the
+// channel is closed, and the channel must be drained or play stalls.
+func (g *Game) PlayStream(ai AI) (<-chan RoundResult, error) {
+	if g.stepping {
+		return nil, errRoundStarted
+	}
+	rounds := make(chan RoundResult, streamBuffer)
+	onRound := g.onRound
+	g.onRound = func(r RoundResult) {
+		if onRound != nil {
+			onRound(r)
+		}
+		if g.arena != nil {
+			r = r.Clone()
+		}
+		rounds <- r
+	}
+	go func() {
+		g.Play(ai)
+		g.onRound = onRound
+		close(rounds)
+	}()
+	return rounds, nil
+}
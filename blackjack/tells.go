@@ -0,0 +1,38 @@
+package blackjack
+
+import "github.com/Scrimzay/blackjacksimulator/deck"
+
+// Tell is a hint about the dealer's hole card, the kind a dealer gives away by
+// how they react to it: whether their two cards look like a stiff.
+type Tell struct {
+	Stiff    bool    // Whether the dealer seems to have a stiff
+	Accuracy float64 // Chance the tell is right, from Options.Tells
+}
+
+// TellWatcher is implemented by AIs that watch the dealer for tells. At a
+// table with Options.Tells set, Tell is called after the deal of every round
+// the AI plays.
+type TellWatcher interface {
+	Tell(t Tell)
+}
+
+// Stiff reports whether a hand is a stiff: a hard 12 to 16, which the dealer
+// must hit and may bust.
+func Stiff(hand ...deck.Card) bool {
+	score := Score(hand...)
+	return score >= 12 && score <= 16 && !Soft(hand...)
+}
+
+// tell shows a TellWatcher AI whether the dealer seems to have a stiff, wrong
+// as often as the table's tells are unreliable.
+func (g *Game) tell(ai AI) {
+	tw, ok := ai.(TellWatcher)
+	if !ok || g.tells <= 0 || g.noHoleCard {
+		return
+	}
+	stiff := Stiff(g.dealer...)
+	if g.rand.Float64() >= g.tells {
+		stiff = !stiff
+	}
+	tw.Tell(Tell{Stiff: stiff, Accuracy: g.tells})
+}
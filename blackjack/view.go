@@ -0,0 +1,57 @@
+package blackjack
+
+import "github.com/Scrimzay/blackjacksimulator/deck"
+
+// Hand is a player's or the dealer's cards.
+type Hand []deck.Card
+
+// IsPair reports whether the hand is two cards of the same rank, the only
+// hands MoveSplit accepts. A ten and a king are both worth ten but aren't a
+// pair.
+func (h Hand) IsPair() bool {
+	return len(h) == 2 && h[0].Rank == h[1].Rank
+}
+
+// Score returns the hand's best total, as Score does.
+func (h Hand) Score() int {
+	return Score(h...)
+}
+
+// Soft reports whether the hand counts an ace as 11, as Soft does.
+func (h Hand) Soft() bool {
+	return Soft(h...)
+}
+
+// GameView is what the player knows when making a decision, including which
+// moves the engine will accept.
+type GameView struct {
+	Hand         Hand      // The hand being played
+	Dealer       deck.Card // The dealer's upcard
+	HandIndex    int       // Index of the hand among the seat's hands
+	Hands        int       // Number of hands the seat has, more than one after a split
+	CanHit       bool      // Whether MoveHit is allowed, which split aces may not be
+	CanDouble    bool      // Whether MoveDouble is allowed
+	CanSplit     bool      // Whether MoveSplit is allowed: the hand is a pair and the table allows another split
+	CanSurrender bool      // Whether MoveSurrender is allowed
+}
+
+// Viewer is implemented by AIs that decide from a GameView rather than from
+// the bare cards. The engine calls PlayView instead of Play for them.
+type Viewer interface {
+	PlayView(v GameView) Move
+}
+
+// view describes the decision on the current hand.
+func (g *Game) view() GameView {
+	h := Hand(g.copyCards(g.player[g.handIdx].cards))
+	return GameView{
+		Hand:         h,
+		Dealer:       g.dealer[0],
+		HandIndex:    g.handIdx,
+		Hands:        len(g.player),
+		CanHit:       !g.splitAces(),
+		CanDouble:    len(h) == 2 && g.doubleOn.allows(h) && !(g.noDoubleAfterSplit && len(g.player) > 1) && !g.splitAces(),
+		CanSplit:     g.splitError() == nil,
+		CanSurrender: g.surrenderError() == nil,
+	}
+}
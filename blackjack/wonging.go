@@ -0,0 +1,41 @@
+package blackjack
+
+// Wonger is implemented by AIs that leave the table between rounds and come back
+// later, like a back-counter who only plays positive shoes. Rounds the AI sits out
+// are still dealt (someone else plays the seat) and shown to it through Results,
+// so it can keep counting, but it has nothing riding on them.
+type Wonger interface {
+	// Seated is asked before each round whether the AI wants to play it.
+	Seated(shuffled bool) bool
+}
+
+// seated reports whether the AI plays the coming round. With NoMidShoeEntry an
+// AI that sits out a round, including the first one of a shoe, has to wait for
+// the next shuffle to come back.
+func (g *Game) seated(ai AI, shuffled bool) bool {
+	w, ok := ai.(Wonger)
+	if !ok {
+		return true
+	}
+	if shuffled {
+		g.waiting = false
+	}
+	if g.noMidShoeEntry && g.waiting {
+		return false
+	}
+	if !w.Seated(shuffled) {
+		g.waiting = true
+		return false
+	}
+	return true
+}
+
+// sitOut clears the round's bets while the AI watches. A shuffle it missed is
+// announced with its next bet.
+func (g *Game) sitOut(shuffled bool) {
+	g.missedShuffle = g.missedShuffle || shuffled
+	g.playerBet = 0
+	for i := range g.backers {
+		g.backers[i].bet = 0
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// chart writes a basic strategy chart as JSON, to be edited and played with
+// play -chart.
+func chart(args []string) {
+	fs := flag.NewFlagSet("chart", flag.ExitOnError)
+	output := fs.String("o", "", "write the chart to this file instead of stdout")
+	decks := fs.Int("decks", 0, "generate the chart for this many decks instead of writing the built-in H17 chart")
+	s17 := fs.Bool("s17", false, "generate the chart for a dealer standing on soft 17")
+	noDAS := fs.Bool("no-das", false, "generate the chart for a table without doubling after splits")
+	check := fs.String("check", "", "validate a chart file instead of writing one")
+	rules := presetFlags(fs)
+	parseFlags(fs, args)
+
+	if *check != "" {
+		if _, err := loadChart(*check); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(*check, "is a valid chart")
+		return
+	}
+
+	w := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+	c := strategy.H17
+	opts := blackjack.Options{Decks: *decks}
+	if err := rules(&opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if opts.Decks > 0 || *s17 || *noDAS || opts.Surrender {
+		opts.StandSoft17 = opts.StandSoft17 || *s17
+		opts.NoDoubleAfterSplit = opts.NoDoubleAfterSplit || *noDAS
+		g := blackjack.New(opts)
+		c = strategy.ChartFor(g.Rules())
+	}
+	if err := c.WriteJSON(w); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// loadChart reads a chart file written by the chart command.
+func loadChart(name string) (strategy.Chart, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return strategy.Chart{}, err
+	}
+	defer f.Close()
+	c, err := strategy.ReadChart(f)
+	if err != nil {
+		return c, fmt.Errorf("%s: %v", name, err)
+	}
+	return c, nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/Scrimzay/blackjacksimulator/analysis"
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/stats"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// clumpModel is a way of shuffling compared by the clumping command.
+type clumpModel struct {
+	name     string
+	shuffler func(rnd *rand.Rand) deck.Permer
+	newDecks int
+}
+
+var clumpModels = []clumpModel{
+	{"perfect", func(rnd *rand.Rand) deck.Permer { return rnd }, 0},
+	{"riffle, 7 passes", func(rnd *rand.Rand) deck.Permer { return deck.Riffle{Passes: 7, Rand: rnd} }, 0},
+	{"riffle, 3 passes", func(rnd *rand.Rand) deck.Permer { return deck.Riffle{Passes: 3, Rand: rnd} }, 0},
+	{"lazy, 2 passes", func(rnd *rand.Rand) deck.Permer { return deck.Lazy{Passes: 2, Rand: rnd} }, 0},
+	{"lazy, 1 pass", func(rnd *rand.Rand) deck.Permer { return deck.Lazy{Passes: 1, Rand: rnd} }, 0},
+	{"lazy, 2 passes, new decks every shoe", func(rnd *rand.Rand) deck.Permer { return deck.Lazy{Passes: 2, Rand: rnd} }, 1},
+	{"clumped on purpose, runs of 8", func(rnd *rand.Rand) deck.Permer { return blackjack.Clumped{Run: 8, Rand: rnd} }, 0},
+}
+
+// clumping plays basic strategy through shuffles that leave the cards clumped
+// to varying degrees, and shows whether clumping moves its EV at all.
+func clumping(args []string) {
+	fs := flag.NewFlagSet("clumping", flag.ExitOnError)
+	decks := fs.Int("decks", 6, "number of decks used")
+	hands := fs.Int("hands", 2000000, "rounds to play with each shuffle")
+	seed := fs.Int64("seed", 1, "seed the shuffles (0 for random)")
+	printer := outputFlags(fs)
+	profile := profileFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+	defer profile()()
+
+	results := make([]stats.Stats, len(clumpModels))
+	meters := make([]analysis.ClumpMeter, len(clumpModels))
+	var wg sync.WaitGroup
+	for i, m := range clumpModels {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := *seed
+			if s == 0 {
+				s = rand.Int63()
+			}
+			meters[i].Permer = m.shuffler(rand.New(rand.NewSource(s + int64(i))))
+			opts := blackjack.Options{Decks: *decks, Hands: *hands, Seed: *seed, OnRound: results[i].Add, Shuffler: &meters[i], NewDecks: m.newDecks, Reuse: true}
+			g := blackjack.New(opts)
+			g.Play(strategy.BasicStrategyAI())
+		}()
+	}
+	wg.Wait()
+
+	perfect := results[0]
+	rows := [][]string{{"Shuffle", "Clumping", "EV per round", "Edge", "Against perfect", ""}}
+	for i, m := range clumpModels {
+		s := results[i]
+		diff, err := s.EV()-perfect.EV(), 1.96*math.Hypot(s.StdErr(), perfect.StdErr())
+		against, verdict := "-", ""
+		if i > 0 {
+			against = fmt.Sprintf("%+.2f ± %.2f", diff, err)
+			verdict = "no difference"
+			if math.Abs(diff) > err {
+				verdict = "different"
+			}
+		}
+		rows = append(rows, []string{
+			m.name,
+			fmt.Sprintf("%+.4f", meters[i].Clumping),
+			fmt.Sprintf("%+.2f ± %.2f", s.EV(), 1.96*s.StdErr()),
+			fmt.Sprintf("%+.2f%%", 100*float64(s.Net)/float64(max(s.Wagered, 1))),
+			against,
+			verdict,
+		})
+	}
+	out.Printf("Basic strategy, flat bets, %d decks, %d rounds per shuffle (± is 95%%)\n", *decks, *hands)
+	out.Printf("Clumping is the correlation of neighbouring cards' hi-lo tags, %+.4f for a perfect shuffle on average\n\n", -1/float64(len(blackjack.Classic.Shoe(*decks))-1))
+	out.Table(rows)
+}
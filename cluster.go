@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/cluster"
+)
+
+// clusterRun spreads a simulation over several machines: with -listen it
+// coordinates the job and plays shards locally too, with -join it plays shards
+// for a coordinator elsewhere.
+func clusterRun(args []string) {
+	fs := flag.NewFlagSet("cluster", flag.ExitOnError)
+	listen := fs.String("listen", "", "coordinate the job, serving shards to workers at this address, e.g. :8080")
+	join := fs.String("join", "", "play shards for the coordinator at this address, e.g. http://10.0.0.5:8080")
+	workers := fs.Int("workers", runtime.NumCPU(), "shards to play at once on this machine (0 to only coordinate)")
+	hands := fs.Int64("hands", 100000000, "rounds to play in all")
+	shard := fs.Int("shard", 1000000, "rounds per shard")
+	seed := fs.Int64("seed", 0, "seed the shards are dealt from, for a repeatable run (0 for random)")
+	preset := fs.String("preset", "", "start from the usual rules for a table (single, double, shoe)")
+	decks := fs.Int("decks", 0, "number of decks used (the preset's, or the engine's default, if 0)")
+	strategyName := fs.String("strategy", "basic", "playing strategy, one of those the strategies command lists")
+	ramp := fs.Int("ramp", 0, "bet one unit per point of Hi-Lo true count, up to this many units")
+	lease := fs.Duration("lease", 10*time.Minute, "hand a shard out again if it isn't reported within this long")
+	printer := outputFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+
+	url := *join
+	if (*listen == "") == (url == "") {
+		fmt.Fprintln(os.Stderr, "Either -listen to coordinate or -join to work")
+		os.Exit(2)
+	}
+	var c *cluster.Coordinator
+	if *listen != "" {
+		if *seed == 0 {
+			*seed = time.Now().UnixNano()
+		}
+		job := cluster.Job{Preset: *preset, Decks: *decks, Strategy: *strategyName, Ramp: *ramp, Hands: *hands, Shard: *shard, Seed: *seed}
+		if _, err := job.Options(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		if _, err := job.AI(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		l, err := net.Listen("tcp", *listen)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		c = cluster.NewCoordinator(job, *lease)
+		go http.Serve(l, c)
+		url = "http://" + l.Addr().String()
+		out.Printf("Coordinating %d rounds in %d shards at %s (seed %d).\n", *hands, c.Status().Shards, l.Addr(), *seed)
+	}
+
+	var wg sync.WaitGroup
+	var failed atomic.Bool
+	for i := 0; i < *workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := cluster.Worker{URL: url}
+			if c == nil {
+				w.Played = func(sh cluster.Shard) { out.Printf("Played shard %d.\n", sh.ID) }
+			}
+			if _, err := w.Run(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				failed.Store(true)
+			}
+		}()
+	}
+	if c == nil {
+		wg.Wait()
+		if failed.Load() {
+			os.Exit(1)
+		}
+		return
+	}
+
+	tick := time.NewTicker(10 * time.Second)
+	defer tick.Stop()
+wait:
+	for {
+		select {
+		case <-tick.C:
+			st := c.Status()
+			out.Printf("%d of %d shards done, %d being played.\n", st.Done, st.Shards, st.Out)
+		case <-c.Done():
+			break wait
+		}
+	}
+	s := c.Stats()
+	if err := s.Check(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	out.Println()
+	out.Table([][]string{
+		{"Rounds played:", fmt.Sprint(s.Rounds)},
+		{"Hands played:", fmt.Sprint(s.Hands)},
+		{"Net result:", out.Money(int(s.Net))},
+		{"EV per round:", fmt.Sprintf("%s (%.3f%% of the bet)", out.Average(s.EV()), 100*float64(s.Net)/float64(max(s.Wagered, 1)))},
+		{"Standard deviation:", fmt.Sprintf("%s per round (EV %s ± %s at 95%%)", out.Average(s.StdDev()), out.Average(s.EV()), out.Average(1.96*s.StdErr()))},
+	})
+}
@@ -0,0 +1,145 @@
+// Package cluster spreads a simulation over several machines. A Coordinator
+// splits the rounds of a Job into shards and hands them out over HTTP; a Worker
+// anywhere pulls a shard, plays it with the seed it was given and posts back
+// its stats.Stats, which the coordinator merges. A shard that isn't reported
+// in time is handed out again, so a worker that dies only costs its shard, and
+// the totals are merged in shard order, so the same job with the same seed
+// adds up the same however many workers played it.
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/count"
+	"github.com/Scrimzay/blackjacksimulator/stats"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// Job describes a simulation in a form that can be sent to workers.
+type Job struct {
+	Preset   string `json:"preset"`   // Rules from blackjack.Presets, the engine's defaults if empty
+	Decks    int    `json:"decks"`    // Number of decks, the preset's if 0
+	Strategy string `json:"strategy"` // Playing strategy registered in the strategy package, basic if empty
+	Ramp     int    `json:"ramp"`     // Bet one unit per point of Hi-Lo true count up to this many units, flat if 0
+	Hands    int64  `json:"hands"`    // Rounds to play in all
+	Shard    int    `json:"shard"`    // Rounds per shard, 1,000,000 if 0
+	Seed     int64  `json:"seed"`     // Seed the shards' seeds are derived from
+}
+
+// Shard is a slice of a job's rounds.
+type Shard struct {
+	ID    int   `json:"id"`    // Number of the shard, from 0
+	Hands int   `json:"hands"` // Rounds to play
+	Seed  int64 `json:"seed"`  // Seed of the shard's game
+}
+
+// Assignment is a shard handed to a worker, with the job it's part of.
+type Assignment struct {
+	Job   Job   `json:"job"`
+	Shard Shard `json:"shard"`
+}
+
+// Report is a worker's result for a shard.
+type Report struct {
+	Shard int         `json:"shard"` // ID of the shard played
+	Stats stats.Stats `json:"stats"`
+}
+
+// Options returns the game options for the job, without the hands or seed.
+func (j Job) Options() (blackjack.Options, error) {
+	var opts blackjack.Options
+	if j.Preset != "" {
+		p, ok := blackjack.Presets[j.Preset]
+		if !ok {
+			var names []string
+			for name := range blackjack.Presets {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return opts, fmt.Errorf("Unknown preset %q, try one of: %s", j.Preset, strings.Join(names, ", "))
+		}
+		opts = p
+	}
+	if j.Decks > 0 {
+		opts.Decks = j.Decks
+	}
+	if j.Ramp > 0 {
+		opts.Bettor = strategy.Ramp{Spread: j.Ramp}
+	}
+	opts.Count = count.HiLo
+	named, err := j.named()
+	if err != nil {
+		return opts, err
+	}
+	named.Options(&opts)
+	return opts, nil
+}
+
+// AI returns a fresh player for the job's strategy, set up for the decks the
+// job is played with.
+func (j Job) AI() (blackjack.AI, error) {
+	opts, err := j.Options()
+	if err != nil {
+		return nil, err
+	}
+	named, err := j.named()
+	if err != nil {
+		return nil, err
+	}
+	return named.New(strategy.Setup{Decks: opts.Decks}), nil
+}
+
+// named looks up the job's strategy.
+func (j Job) named() (strategy.Named, error) {
+	if j.Strategy == "" {
+		return strategy.Lookup("basic")
+	}
+	return strategy.Lookup(j.Strategy)
+}
+
+// Shards splits the job into shards, each with its own seed.
+func (j Job) Shards() []Shard {
+	size := int64(j.Shard)
+	if size <= 0 {
+		size = 1000000
+	}
+	var shards []Shard
+	for left := j.Hands; left > 0; left -= size {
+		id := len(shards)
+		shards = append(shards, Shard{ID: id, Hands: int(min(left, size)), Seed: shardSeed(j.Seed, id)})
+	}
+	return shards
+}
+
+// Run plays a shard of the job.
+func (j Job) Run(sh Shard) (stats.Stats, error) {
+	var s stats.Stats
+	opts, err := j.Options()
+	if err != nil {
+		return s, err
+	}
+	ai, err := j.AI()
+	if err != nil {
+		return s, err
+	}
+	opts.Hands, opts.Seed, opts.OnRound, opts.Reuse = sh.Hands, sh.Seed, s.Add, true
+	g := blackjack.New(opts)
+	g.Play(ai)
+	return s, nil
+}
+
+// shardSeed returns the seed of shard id of a job, mixed from the job's seed
+// so neighbouring shards don't deal related shoes. It's never 0, which would
+// leave the shard unseeded.
+func shardSeed(seed int64, id int) int64 {
+	z := uint64(seed) + uint64(id+1)*0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	if z ^= z >> 31; z == 0 {
+		z = 1
+	}
+	return int64(z)
+}
@@ -0,0 +1,160 @@
+package cluster
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/stats"
+)
+
+// Coordinator hands out a job's shards to workers and merges what they
+// report. It's an http.Handler:
+//
+//	POST /shard   an Assignment, 503 with Retry-After while every shard left is out, 204 once the job is done
+//	POST /report  a Report for a shard handed out, answered with the Status
+//	GET  /status  the shards done and in all
+type Coordinator struct {
+	job     Job
+	lease   time.Duration
+	mu      sync.Mutex
+	shards  []Shard
+	out     map[int]time.Time   // Shards handed out and not reported, by when their lease runs out
+	results map[int]stats.Stats // Reported shards
+	done    chan struct{}
+}
+
+// Status is how far a job has got.
+type Status struct {
+	Done   int `json:"done"`   // Shards reported
+	Out    int `json:"out"`    // Shards being played
+	Shards int `json:"shards"` // Shards in all
+}
+
+// NewCoordinator returns a coordinator for job. A shard not reported within
+// lease of being handed out is handed out again; 10 minutes if 0.
+func NewCoordinator(job Job, lease time.Duration) *Coordinator {
+	if lease == 0 {
+		lease = 10 * time.Minute
+	}
+	c := &Coordinator{
+		job:     job,
+		lease:   lease,
+		shards:  job.Shards(),
+		out:     map[int]time.Time{},
+		results: map[int]stats.Stats{},
+		done:    make(chan struct{}),
+	}
+	if len(c.shards) == 0 {
+		close(c.done)
+	}
+	return c
+}
+
+// Done returns a channel that's closed once every shard is reported.
+func (c *Coordinator) Done() <-chan struct{} {
+	return c.done
+}
+
+// Status returns how far the job has got.
+func (c *Coordinator) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status()
+}
+
+func (c *Coordinator) status() Status {
+	return Status{Done: len(c.results), Out: len(c.out), Shards: len(c.shards)}
+}
+
+// Stats returns the merged stats of the shards reported so far, in shard
+// order.
+func (c *Coordinator) Stats() stats.Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var s stats.Stats
+	for _, sh := range c.shards {
+		if r, ok := c.results[sh.ID]; ok {
+			s.Merge(r)
+		}
+	}
+	return s
+}
+
+// ServeHTTP serves the workers.
+func (c *Coordinator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/shard" && r.Method == http.MethodPost:
+		c.serveShard(w)
+	case r.URL.Path == "/report" && r.Method == http.MethodPost:
+		c.serveReport(w, r)
+	case r.URL.Path == "/status" && r.Method == http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(c.Status())
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveShard hands out the first shard that isn't done or out on a lease.
+func (c *Coordinator) serveShard(w http.ResponseWriter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.results) == len(c.shards) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	now := time.Now()
+	for _, sh := range c.shards {
+		if _, ok := c.results[sh.ID]; ok {
+			continue
+		}
+		if until, ok := c.out[sh.ID]; ok && now.Before(until) {
+			continue
+		}
+		c.out[sh.ID] = now.Add(c.lease)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Assignment{Job: c.job, Shard: sh})
+		return
+	}
+	w.Header().Set("Retry-After", "5")
+	http.Error(w, "Every shard left is being played", http.StatusServiceUnavailable)
+}
+
+// serveReport takes a shard's stats and replies with the job's status, so the
+// worker that reports the last shard knows to stop before the coordinator goes
+// away. A shard reported twice, because its lease ran out while it was still
+// being played, is only counted once; one that was never handed out isn't
+// counted at all.
+func (c *Coordinator) serveReport(w http.ResponseWriter, r *http.Request) {
+	var rep Report
+	if err := json.NewDecoder(r.Body).Decode(&rep); err != nil {
+		http.Error(w, "Report: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := rep.Stats.Check(); err != nil {
+		http.Error(w, "Report: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if rep.Shard < 0 || rep.Shard >= len(c.shards) {
+		http.Error(w, "Report for a shard that doesn't exist", http.StatusBadRequest)
+		return
+	}
+	_, done := c.results[rep.Shard]
+	if _, out := c.out[rep.Shard]; !done && !out {
+		http.Error(w, "Report for a shard that wasn't handed out", http.StatusConflict)
+		return
+	}
+	if !done {
+		delete(c.out, rep.Shard)
+		c.results[rep.Shard] = rep.Stats
+		if len(c.results) == len(c.shards) {
+			close(c.done)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c.status())
+}
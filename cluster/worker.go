@@ -0,0 +1,124 @@
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Worker plays shards for a coordinator.
+type Worker struct {
+	URL  string       // The coordinator's address, e.g. http://10.0.0.5:8080
+	HTTP *http.Client // Client making the calls, http.DefaultClient if nil
+
+	// Played is called with every shard once it's reported, if set.
+	Played func(Shard)
+}
+
+// Run pulls shards, plays them and reports them until the coordinator's job
+// is done, returning how many shards it played. A coordinator that goes away
+// while the worker waits for the last shards to come in is taken to be done.
+func (w Worker) Run() (int, error) {
+	played, waited := 0, false
+	for {
+		a, wait, err := w.next()
+		switch {
+		case err != nil && waited:
+			return played, nil
+		case err != nil:
+			return played, err
+		case wait > 0:
+			waited = true
+			time.Sleep(wait)
+			continue
+		case a == nil:
+			return played, nil
+		}
+		s, err := a.Job.Run(a.Shard)
+		if err != nil {
+			return played, err
+		}
+		var st Status
+		if err := w.post("/report", Report{Shard: a.Shard.ID, Stats: s}, &st); err != nil {
+			return played, err
+		}
+		played++
+		if w.Played != nil {
+			w.Played(a.Shard)
+		}
+		if st.Done == st.Shards {
+			return played, nil
+		}
+	}
+}
+
+// next asks for a shard. It returns nil once the job is done, or how long to
+// wait before asking again if every shard left is out.
+func (w Worker) next() (*Assignment, time.Duration, error) {
+	var a Assignment
+	err := w.post("/shard", nil, &a)
+	var busy *busyError
+	switch {
+	case err == errFinished:
+		return nil, 0, nil
+	case errors.As(err, &busy):
+		return nil, busy.wait, nil
+	case err != nil:
+		return nil, 0, err
+	}
+	return &a, 0, nil
+}
+
+// errFinished is what the coordinator says once every shard is done.
+var errFinished = errors.New("Job finished")
+
+// busyError is the coordinator asking to come back later.
+type busyError struct {
+	wait time.Duration
+}
+
+func (e *busyError) Error() string {
+	return fmt.Sprintf("Coordinator busy, retry in %v", e.wait)
+}
+
+// post sends body to the coordinator as JSON and decodes the reply into v.
+func (w Worker) post(path string, body, v interface{}) error {
+	hc := w.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	resp, err := hc.Post(strings.TrimSuffix(w.URL, "/")+path, "application/json", r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		return errFinished
+	case http.StatusServiceUnavailable:
+		secs, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return &busyError{wait: time.Duration(max(secs, 1)) * time.Second}
+	default:
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("Coordinator %s: %s %s", path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
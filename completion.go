@@ -0,0 +1,263 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/display"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+func init() {
+	// Registered here rather than in commands, which completion reads.
+	commands["completion"] = completion
+}
+
+// flagValues lists the values flags taking one of a few can be completed
+// with, by flag name. Other flags complete with file names.
+var flagValues = map[string]func() []string{
+	"preset": func() []string { return sortedKeys(blackjack.Presets) },
+	"money":  func() []string { return sortedKeys(display.Currencies) },
+	"lang": func() []string {
+		var langs []string
+		for _, l := range display.Langs {
+			langs = append(langs, string(l))
+		}
+		return langs
+	},
+	"model":    func() []string { return []string{"perfect", "riffle", "lazy"} },
+	"strategy": strategy.Names,
+	"show-ev":  func() []string { return []string{"before", "after"} },
+}
+
+// argValues lists the values a command's arguments can be completed with, on
+// top of file names.
+var argValues = map[string]func() []string{
+	"rate":       strategy.Names,
+	"completion": func() []string { return []string{"bash", "zsh", "fish"} },
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// completion writes a script completing the simulator's commands, flags and
+// the values of flags like -preset, for bash, zsh or fish.
+func completion(args []string) {
+	fs := flag.NewFlagSet("completion", flag.ExitOnError)
+	name := fs.String("name", "blackjacksimulator", "name the program is run by")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: completion [flags] bash|zsh|fish")
+		fs.PrintDefaults()
+	}
+	parseFlags(fs, args)
+	write := map[string]func(prog string, cmds []cmdFlags) string{"bash": bashCompletion, "zsh": zshCompletion, "fish": fishCompletion}[fs.Arg(0)]
+	if fs.NArg() != 1 || write == nil {
+		fs.Usage()
+		os.Exit(2)
+	}
+	fmt.Print(write(*name, probeCommands()))
+}
+
+// cmdFlags is a command and the flags it takes; the game itself, played
+// without a command, has an empty name.
+type cmdFlags struct {
+	name  string
+	flags []*flag.Flag
+}
+
+// probeCommands collects the flags of every command, the game's first, by
+// running each with probing set so it stops once they're defined.
+func probeCommands() []cmdFlags {
+	probing = true
+	defer func() { probing = false }()
+	probe := func(name string, cmd func([]string)) (c cmdFlags) {
+		defer func() {
+			switch p := recover().(type) {
+			case probed:
+				c = cmdFlags{name: name}
+				p.fs.VisitAll(func(f *flag.Flag) { c.flags = append(c.flags, f) })
+			case nil:
+			default:
+				panic(p)
+			}
+		}()
+		cmd(nil)
+		return c
+	}
+	cmds := []cmdFlags{probe("", play)}
+	for _, name := range sortedKeys(commands) {
+		cmds = append(cmds, probe(name, commands[name]))
+	}
+	return cmds
+}
+
+// isBool reports whether f is a flag that's given without a value.
+func isBool(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// takesText reports whether f takes a string, usually a file name, rather
+// than a number or a duration.
+func takesText(f *flag.Flag) bool {
+	g, ok := f.Value.(flag.Getter)
+	if !ok {
+		return true
+	}
+	_, ok = g.Get().(string)
+	return ok
+}
+
+// summary shortens a flag's usage to a completion description: its first
+// clause, without examples.
+func summary(usage string) string {
+	for _, sep := range []string{"; ", ", e.g.", " (", ": "} {
+		usage, _, _ = strings.Cut(usage, sep)
+	}
+	return usage
+}
+
+// commandNames returns the names of the commands after the game's flags.
+func commandNames(cmds []cmdFlags) []string {
+	var names []string
+	for _, c := range cmds[1:] {
+		names = append(names, c.name)
+	}
+	return names
+}
+
+func bashCompletion(prog string, cmds []cmdFlags) string {
+	fn := "_" + strings.ReplaceAll(prog, "-", "_")
+	var b strings.Builder
+	fmt.Fprintf(&b, "# bash completion for %s, load with: source <(%s completion bash)\n", prog, prog)
+	fmt.Fprintf(&b, "%s() {\n", fn)
+	b.WriteString("\tlocal cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]} cmd= words=\n")
+	b.WriteString("\t[[ $COMP_CWORD -gt 1 ]] && cmd=${COMP_WORDS[1]}\n")
+	b.WriteString("\tcase $prev in\n")
+	for _, name := range sortedKeys(flagValues) {
+		fmt.Fprintf(&b, "\t-%s|--%s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", name, name, strings.Join(flagValues[name](), " "))
+	}
+	// Any other flag taking a value takes a file name, or something to type.
+	fmt.Fprintf(&b, "\t%s) return ;;\n", strings.Join(valueFlags(cmds), "|"))
+	b.WriteString("\tesac\n\tcase $cmd in\n")
+	for _, c := range cmds[1:] {
+		fmt.Fprintf(&b, "\t%s) words=%q ;;\n", c.name, strings.Join(append(flagNames(c), argNames(c.name)...), " "))
+	}
+	fmt.Fprintf(&b, "\t*) words=%q ;;\n", strings.Join(append(flagNames(cmds[0]), commandNames(cmds)...), " "))
+	b.WriteString("\tesac\n")
+	b.WriteString("\tCOMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n}\n")
+	fmt.Fprintf(&b, "complete -o default -F %s %s\n", fn, prog)
+	return b.String()
+}
+
+// valueFlags returns the flags of any command that take a value other than
+// one of flagValues, as they're typed.
+func valueFlags(cmds []cmdFlags) []string {
+	seen := map[string]bool{}
+	for _, c := range cmds {
+		for _, f := range c.flags {
+			if _, ok := flagValues[f.Name]; !ok && !isBool(f) {
+				seen["-"+f.Name] = true
+			}
+		}
+	}
+	return sortedKeys(seen)
+}
+
+// flagNames returns c's flags as they're typed, with the dash.
+func flagNames(c cmdFlags) []string {
+	var names []string
+	for _, f := range c.flags {
+		names = append(names, "-"+f.Name)
+	}
+	return names
+}
+
+func argNames(command string) []string {
+	if values, ok := argValues[command]; ok {
+		return values()
+	}
+	return nil
+}
+
+func zshCompletion(prog string, cmds []cmdFlags) string {
+	fn := "_" + strings.ReplaceAll(prog, "-", "_")
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n# zsh completion for %s, save as %s somewhere on $fpath\n", prog, prog, fn)
+	fmt.Fprintf(&b, "%s() {\n\tcase $words[2] in\n", fn)
+	for _, c := range cmds[1:] {
+		fmt.Fprintf(&b, "\t%s)\n\t\tshift words; (( CURRENT-- ))\n\t\t_arguments -S", c.name)
+		for _, f := range c.flags {
+			b.WriteString(" \\\n\t\t\t" + zshSpec(f))
+		}
+		arg := "_files"
+		if values := argNames(c.name); values != nil {
+			arg = "(" + strings.Join(values, " ") + ")"
+		}
+		fmt.Fprintf(&b, " \\\n\t\t\t'*:argument:%s'\n\t\t;;\n", arg)
+	}
+	b.WriteString("\t*)\n\t\t_arguments -S")
+	for _, f := range cmds[0].flags {
+		b.WriteString(" \\\n\t\t\t" + zshSpec(f))
+	}
+	fmt.Fprintf(&b, " \\\n\t\t\t'1::command:(%s)'\n\t\t;;\n\tesac\n}\n%s \"$@\"\n", strings.Join(commandNames(cmds), " "), fn)
+	return b.String()
+}
+
+// zshSpec writes f as an _arguments spec, quoted for the shell.
+func zshSpec(f *flag.Flag) string {
+	desc := strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, ":", `\:`).Replace(summary(f.Usage))
+	spec := "-" + f.Name + "[" + desc + "]"
+	if !isBool(f) {
+		action := " "
+		if values, ok := flagValues[f.Name]; ok {
+			action = "(" + strings.Join(values(), " ") + ")"
+		} else if takesText(f) {
+			action = "_files"
+		}
+		spec += ":" + f.Name + ":" + action
+	}
+	return "'" + strings.ReplaceAll(spec, "'", `'\''`) + "'"
+}
+
+func fishCompletion(prog string, cmds []cmdFlags) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# fish completion for %s, save as ~/.config/fish/completions/%s.fish\n", prog, prog)
+	names := strings.Join(commandNames(cmds), " ")
+	fmt.Fprintf(&b, "complete -c %s -n 'not __fish_seen_subcommand_from %s' -f -a '%s'\n", prog, names, names)
+	for _, c := range cmds {
+		cond := "'not __fish_seen_subcommand_from " + names + "'"
+		if c.name != "" {
+			cond = "'__fish_seen_subcommand_from " + c.name + "'"
+			if values := argNames(c.name); values != nil {
+				fmt.Fprintf(&b, "complete -c %s -n %s -a '%s'\n", prog, cond, strings.Join(values, " "))
+			}
+		}
+		for _, f := range c.flags {
+			fmt.Fprintf(&b, "complete -c %s -n %s -o %s -d %s", prog, cond, f.Name, fishQuote(summary(f.Usage)))
+			if values, ok := flagValues[f.Name]; ok {
+				fmt.Fprintf(&b, " -x -a '%s'", strings.Join(values(), " "))
+			} else if takesText(f) {
+				b.WriteString(" -r")
+			} else if !isBool(f) {
+				b.WriteString(" -x")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,68 @@
+package count
+
+import (
+	"math"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// eor is the effect of removing one card of each rank from a single deck on the
+// player's expectation, in percent, for basic strategy under common rules
+// (after Griffin).
+var eor = map[deck.Rank]float64{
+	deck.Ace:   -0.61,
+	deck.Two:   0.38,
+	deck.Three: 0.44,
+	deck.Four:  0.55,
+	deck.Five:  0.69,
+	deck.Six:   0.46,
+	deck.Seven: 0.28,
+	deck.Eight: 0,
+	deck.Nine:  -0.18,
+	deck.Ten:   -0.51,
+	deck.Jack:  -0.51,
+	deck.Queen: -0.51,
+	deck.King:  -0.51,
+}
+
+// Deviation returns the standard deviation of a system's tags over a deck, how
+// far one card moves its count. Dividing one system's true counts by another's
+// deviation puts them on the same scale.
+func Deviation(s System) float64 {
+	mean, _ := moments(s)
+	var sq float64
+	for r := range eor {
+		d := float64(s(deck.Card{Rank: r})) - mean
+		sq += d * d
+	}
+	return math.Sqrt(sq / float64(len(eor)))
+}
+
+// BettingCorrelation returns how well a system's tags track the effects of
+// removal of the cards, from -1 to 1: the closer to 1, the more of the edge a
+// bet ramp on its count can find. Hi-Lo's is about 0.97.
+func BettingCorrelation(s System) float64 {
+	mean, eorMean := moments(s)
+	var cov, tags, eors float64
+	for r, e := range eor {
+		t := float64(s(deck.Card{Rank: r})) - mean
+		e -= eorMean
+		cov += t * e
+		tags += t * t
+		eors += e * e
+	}
+	if tags == 0 || eors == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(tags*eors)
+}
+
+// moments returns the mean tag of a system and the mean effect of removal over
+// the ranks of a deck.
+func moments(s System) (tag, eorMean float64) {
+	for r, e := range eor {
+		tag += float64(s(deck.Card{Rank: r}))
+		eorMean += e
+	}
+	return tag / float64(len(eor)), eorMean / float64(len(eor))
+}
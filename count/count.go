@@ -0,0 +1,115 @@
+// Package count implements card counting systems and a counter that keeps the
+// running and true counts through a shoe.
+package count
+
+import (
+	"reflect"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// System gives the tag a counting system assigns to a card.
+type System func(deck.Card) int
+
+// HiLo counts 2-6 as +1, 7-9 as 0 and tens and aces as -1.
+func HiLo(c deck.Card) int {
+	switch {
+	case c.Rank == deck.Ace || c.Rank.IsTenValue():
+		return -1
+	case c.Rank <= deck.Six:
+		return 1
+	}
+	return 0
+}
+
+// AceFive is the simplest count worth keeping, for beginners: fives count +1
+// and aces -1, the two cards whose removal matters most.
+func AceFive(c deck.Card) int {
+	switch c.Rank {
+	case deck.Five:
+		return 1
+	case deck.Ace:
+		return -1
+	}
+	return 0
+}
+
+// SpeedCount is Dan Pronovost's Speed Count, which only counts the small cards:
+// 2-6 count +1 and everything else 0, and the counter takes 2 off at the end of
+// each round (see PerRound) for the small cards an average round uses up.
+func SpeedCount(c deck.Card) int {
+	if c.Rank >= deck.Two && c.Rank <= deck.Six {
+		return 1
+	}
+	return 0
+}
+
+// Systems maps the name of each counting system to it.
+var Systems = map[string]System{
+	"hilo":    HiLo,
+	"acefive": AceFive,
+	"speed":   SpeedCount,
+}
+
+// PerRound returns what a system adds to the running count at the end of every
+// round, on top of its card tags: -2 for SpeedCount and 0 for the others.
+func PerRound(s System) int {
+	if s != nil && reflect.ValueOf(s).Pointer() == reflect.ValueOf(SpeedCount).Pointer() {
+		return -2
+	}
+	return 0
+}
+
+// Counter keeps the count of the cards seen since the last shuffle.
+type Counter struct {
+	System System // Counting system, HiLo if nil
+	Decks  int    // Number of decks in the shoe
+
+	running int
+	seen    int
+}
+
+// Observe counts the given cards.
+func (c *Counter) Observe(cards ...deck.Card) {
+	sys := c.System
+	if sys == nil {
+		sys = HiLo
+	}
+	for _, card := range cards {
+		if card.Suit == deck.Joker {
+			continue // Jokers aren't tagged by any system
+		}
+		c.running += sys(card)
+		c.seen++
+	}
+}
+
+// EndRound applies the system's adjustment for a round played, if any.
+func (c *Counter) EndRound() {
+	c.running += PerRound(c.System)
+}
+
+// Reset starts the count over for a new shoe.
+func (c *Counter) Reset() {
+	c.running, c.seen = 0, 0
+}
+
+// Running returns the running count.
+func (c *Counter) Running() int {
+	return c.running
+}
+
+// Seen returns the number of cards counted since the last shuffle.
+func (c *Counter) Seen() int {
+	return c.seen
+}
+
+// True returns the running count per deck left in the shoe. Less than half a
+// deck left counts as half a deck.
+func (c *Counter) True() float64 {
+	left := float64(c.Decks*52-c.seen) / 52
+	if left < 0.5 {
+		left = 0.5
+	}
+	return float64(c.running) / left
+}
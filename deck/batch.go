@@ -0,0 +1,148 @@
+package deck
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ShoeBatch holds shuffles made ahead of time, so that simulations can be timed
+// without the cost of shuffling, or run again on exactly the same shoes. A
+// batch is read-only once made: every game gets its own Stream over it.
+type ShoeBatch struct {
+	size  int    // Cards per shoe
+	shoes int    // Number of shoes
+	perms []byte // The permutations, size little-endian uint16s per shoe
+	close func() error
+}
+
+// batchMagic starts every batch file, followed by the size and number of shoes
+// as little-endian uint32s and then the permutations.
+const batchMagic = "BJSHOES1"
+
+const batchHeader = len(batchMagic) + 8
+
+// NewShoeBatch shuffles shoes shoes of size cards with p.
+func NewShoeBatch(p Permer, size, shoes int) *ShoeBatch {
+	if size > 1<<16 {
+		panic(fmt.Sprintf("Shoes of %d cards are too big for a batch", size))
+	}
+	b := &ShoeBatch{size: size, shoes: shoes, perms: make([]byte, 2*size*shoes)}
+	for i := 0; i < shoes; i++ {
+		for j, k := range p.Perm(size) {
+			binary.LittleEndian.PutUint16(b.perms[2*(i*size+j):], uint16(k))
+		}
+	}
+	return b
+}
+
+// Size returns the number of cards in each shoe.
+func (b *ShoeBatch) Size() int {
+	return b.size
+}
+
+// Len returns the number of shoes in the batch.
+func (b *ShoeBatch) Len() int {
+	return b.shoes
+}
+
+// perm writes the i-th shoe's permutation to dst.
+func (b *ShoeBatch) perm(i int, dst []int) {
+	p := b.perms[2*i*b.size:]
+	for j := range dst {
+		dst[j] = int(binary.LittleEndian.Uint16(p[2*j:]))
+	}
+}
+
+// Stream returns a Permer dealing the batch's shoes in order, starting with the
+// shoe at start and going round again after the last. Give each game its own
+// stream, from different starts to play different shoes.
+func (b *ShoeBatch) Stream(start int) Permer {
+	return &shoeStream{batch: b, next: start % b.shoes}
+}
+
+// shoeStream is a Permer over a ShoeBatch.
+type shoeStream struct {
+	batch *ShoeBatch
+	next  int
+}
+
+// Perm returns the next shoe of the batch. It panics if n isn't the batch's
+// shoe size.
+func (s *shoeStream) Perm(n int) []int {
+	if n != s.batch.size {
+		panic(fmt.Sprintf("Shoe batch has %d-card shoes, not %d", s.batch.size, n))
+	}
+	perm := make([]int, n)
+	s.batch.perm(s.next, perm)
+	s.next = (s.next + 1) % s.batch.shoes
+	return perm
+}
+
+// WriteTo writes the batch in the format read by ReadShoeBatch and
+// OpenShoeBatch.
+func (b *ShoeBatch) WriteTo(w io.Writer) (int64, error) {
+	header := make([]byte, batchHeader)
+	copy(header, batchMagic)
+	binary.LittleEndian.PutUint32(header[len(batchMagic):], uint32(b.size))
+	binary.LittleEndian.PutUint32(header[len(batchMagic)+4:], uint32(b.shoes))
+	n, err := w.Write(header)
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := w.Write(b.perms)
+	return int64(n + m), err
+}
+
+// ReadShoeBatch reads a batch written by WriteTo into memory.
+func ReadShoeBatch(r io.Reader) (*ShoeBatch, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return parseShoeBatch(data)
+}
+
+// parseShoeBatch checks the header and the permutations of a batch file and
+// returns the batch over them.
+func parseShoeBatch(data []byte) (*ShoeBatch, error) {
+	if len(data) < batchHeader || string(data[:len(batchMagic)]) != batchMagic {
+		return nil, errors.New("Not a shoe batch")
+	}
+	size := int(binary.LittleEndian.Uint32(data[len(batchMagic):]))
+	shoes := int(binary.LittleEndian.Uint32(data[len(batchMagic)+4:]))
+	if size == 0 || shoes == 0 || len(data)-batchHeader != 2*size*shoes {
+		return nil, fmt.Errorf("Shoe batch of %d shoes of %d cards is the wrong length", shoes, size)
+	}
+	b := &ShoeBatch{size: size, shoes: shoes, perms: data[batchHeader:]}
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// check returns an error unless every shoe is a permutation of the cards.
+func (b *ShoeBatch) check() error {
+	perm := make([]int, b.size)
+	seen := make([]int, b.size) // The last shoe each card was seen in, plus one
+	for i := 0; i < b.shoes; i++ {
+		b.perm(i, perm)
+		for _, k := range perm {
+			if k >= b.size || seen[k] == i+1 {
+				return fmt.Errorf("Shoe %d of the batch isn't a shuffle of %d cards", i+1, b.size)
+			}
+			seen[k] = i + 1
+		}
+	}
+	return nil
+}
+
+// Close releases a batch opened with OpenShoeBatch. Streams over it must not
+// be used afterwards.
+func (b *ShoeBatch) Close() error {
+	if b.close == nil {
+		return nil
+	}
+	return b.close()
+}
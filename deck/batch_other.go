@@ -0,0 +1,16 @@
+//go:build !unix
+
+package deck
+
+import "os"
+
+// OpenShoeBatch reads a batch file written by ShoeBatch.WriteTo. Where memory
+// mapping isn't available the whole file is read into memory.
+func OpenShoeBatch(path string) (*ShoeBatch, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadShoeBatch(f)
+}
@@ -0,0 +1,36 @@
+//go:build unix
+
+package deck
+
+import (
+	"os"
+	"syscall"
+)
+
+// OpenShoeBatch memory-maps a batch file written by ShoeBatch.WriteTo, so that
+// batches bigger than memory can be streamed from disk. Close unmaps it.
+func OpenShoeBatch(path string) (*ShoeBatch, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.Size() == 0 {
+		return parseShoeBatch(nil)
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	b, err := parseShoeBatch(data)
+	if err != nil {
+		syscall.Munmap(data)
+		return nil, err
+	}
+	b.close = func() error { return syscall.Munmap(data) }
+	return b, nil
+}
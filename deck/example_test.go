@@ -0,0 +1,56 @@
+package deck_test
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+func ExampleShoe() {
+	shoe := deck.NewShoe(deck.New())
+	shoe.Reshuffle(rand.New(rand.NewSource(1)))
+	hand, err := shoe.DrawN(2)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(hand, shoe.Len())
+	shoe.Discard(hand...)
+	fmt.Println(len(shoe.Discards))
+	// Output:
+	// [Nine of Diamonds Five of Spades] 50
+	// 2
+}
+
+func ExampleShoe_DrawN() {
+	shoe := deck.NewShoe(deck.New())
+	hand, _ := shoe.DrawN(3)
+	fmt.Println(hand, shoe.Len())
+	_, err := shoe.DrawN(50)
+	fmt.Println(err, shoe.Len())
+	// Output:
+	// [Ace of Spades Two of Spades Three of Spades] 49
+	// Not enough cards left in the shoe 49
+}
+
+func ExampleShoe_Peek() {
+	shoe := deck.NewShoe(deck.New())
+	fmt.Println(shoe.Peek(2), shoe.Len())
+	card, _ := shoe.Draw()
+	fmt.Println(card, shoe.Peek(1))
+	// Output:
+	// [Ace of Spades Two of Spades] 52
+	// Ace of Spades [Two of Spades]
+}
+
+func ExampleShoe_Reshuffle() {
+	shoe := deck.NewShoe(deck.New(deck.Deck(2)))
+	played, _ := shoe.DrawN(10)
+	shoe.Discard(played...)
+	fmt.Println(shoe.Len(), len(shoe.Discards))
+	shoe.Reshuffle(rand.New(rand.NewSource(7)))
+	fmt.Println(shoe.Len(), len(shoe.Discards), shoe.Peek(3))
+	// Output:
+	// 94 10
+	// 104 0 [Ace of Hearts Eight of Clubs Ace of Clubs]
+}
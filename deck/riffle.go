@@ -0,0 +1,103 @@
+package deck
+
+import "math/rand"
+
+// Perfect is the uniformly random shuffle used by Shuffle, as a Permer.
+var Perfect Permer = shuffleRand
+
+// Riffle models an imperfect, physical riffle shuffle with the Gilbert-Shannon-Reeds
+// (GSR) model: the deck is cut binomially in two packets which are then interleaved,
+// dropping a card from each packet with probability proportional to its size.
+// Seven passes are usually considered enough to mix a single deck; fewer passes
+// leave runs of cards together, which is what shuffle trackers exploit.
+type Riffle struct {
+	Passes int        // Number of riffles, zero leaves the cards in order
+	Rand   *rand.Rand // Source of randomness, the package shuffle source if nil
+}
+
+// Perm returns the permutation of n cards produced by the riffles: the card at
+// position i after the shuffle was at position Perm(n)[i] before it.
+func (r Riffle) Perm(n int) []int {
+	rnd := r.Rand
+	if rnd == nil {
+		rnd = shuffleRand
+	}
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	for p := 0; p < r.Passes; p++ {
+		perm = riffle(perm, rnd)
+	}
+	return perm
+}
+
+// Lazy models a hurried casino shuffle of a whole shoe. The stack is broken
+// into packets, each pair of neighbouring packets is riffled Passes times, and
+// the stack is cut. With a pass or two, much of the order the cards were picked
+// up in survives, and with it the clumps of cards from the same rounds, or the
+// suits and ranks of new decks.
+type Lazy struct {
+	Passes int        // Riffles of each pair of packets, 1 if 0
+	Packet int        // Cards in a packet, 52 if 0
+	Rand   *rand.Rand // Source of randomness, the package shuffle source if nil
+}
+
+// Perm returns the permutation of n cards produced by the shuffle, as
+// Riffle.Perm does.
+func (l Lazy) Perm(n int) []int {
+	rnd := l.Rand
+	if rnd == nil {
+		rnd = shuffleRand
+	}
+	passes, packet := max(l.Passes, 1), l.Packet
+	if packet == 0 {
+		packet = 52
+	}
+	perm := make([]int, 0, n)
+	for start := 0; start < n; start += 2 * packet {
+		pair := make([]int, 0, 2*packet)
+		for i := start; i < min(start+2*packet, n); i++ {
+			pair = append(pair, i)
+		}
+		for range passes {
+			pair = riffle(pair, rnd)
+		}
+		perm = append(perm, pair...)
+	}
+	if n == 0 {
+		return perm
+	}
+	cut := rnd.Intn(n)
+	return append(perm[cut:], perm[:cut]...)
+}
+
+// riffle performs a single GSR pass.
+func riffle(cards []int, rnd *rand.Rand) []int {
+	cut := 0
+	for range cards {
+		cut += rnd.Intn(2)
+	}
+	left, right := cards[:cut], cards[cut:]
+
+	ret := make([]int, 0, len(cards))
+	for len(left)+len(right) > 0 {
+		if rnd.Intn(len(left)+len(right)) < len(left) {
+			ret = append(ret, left[0])
+			left = left[1:]
+		} else {
+			ret = append(ret, right[0])
+			right = right[1:]
+		}
+	}
+	return ret
+}
+
+// Permute returns the cards reordered by perm, as returned by a Permer.
+func Permute(cards []Card, perm []int) []Card {
+	ret := make([]Card, len(cards))
+	for i, j := range perm {
+		ret[i] = cards[j]
+	}
+	return ret
+}
@@ -0,0 +1,92 @@
+package deck
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+)
+
+// Compare orders cards the way New deals them, by suit and then by rank, with
+// jokers last. It returns -1 if c comes before o, 1 if after and 0 if they're
+// the same card.
+func (c Card) Compare(o Card) int {
+	return cmp.Compare(absRank(c), absRank(o))
+}
+
+// SortBy returns an option for New that sorts the cards with less, keeping
+// cards less doesn't tell apart in the order they were.
+func SortBy(less func(a, b Card) bool) func([]Card) []Card {
+	return func(cards []Card) []Card {
+		sort.SliceStable(cards, func(i, j int) bool { return less(cards[i], cards[j]) })
+		return cards
+	}
+}
+
+// WithoutRanks returns an option for New that takes out every card of the
+// ranks, e.g. the tens for a Spanish deck.
+func WithoutRanks(ranks ...Rank) func([]Card) []Card {
+	return Filter(func(c Card) bool {
+		for _, r := range ranks {
+			if c.Suit != Joker && c.Rank == r {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// CountRanks returns the number of cards of each rank, indexed by Rank, with
+// jokers counted at index 0.
+func CountRanks(cards []Card) [King + 1]int {
+	var counts [King + 1]int
+	for _, c := range cards {
+		if c.Suit == Joker {
+			counts[0]++
+		} else {
+			counts[c.Rank]++
+		}
+	}
+	return counts
+}
+
+// Find returns where in cards a copy of each of want is, in the order of want,
+// using each position once, so asking for a card twice needs two copies. It
+// returns an error naming the first card there isn't a copy of left.
+func Find(cards []Card, want ...Card) ([]int, error) {
+	used := make([]bool, len(cards))
+	at := make([]int, 0, len(want))
+	for _, c := range want {
+		found := false
+		for i, card := range cards {
+			if !used[i] && card == c {
+				used[i], found = true, true
+				at = append(at, i)
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("No %s left", c)
+		}
+	}
+	return at, nil
+}
+
+// Remove returns a copy of cards without a copy of each of remove, or the
+// error of Find if one isn't there. The cards left keep their order.
+func Remove(cards []Card, remove ...Card) ([]Card, error) {
+	at, err := Find(cards, remove...)
+	if err != nil {
+		return nil, err
+	}
+	gone := make([]bool, len(cards))
+	for _, i := range at {
+		gone[i] = true
+	}
+	left := make([]Card, 0, len(cards)-len(at))
+	for i, c := range cards {
+		if !gone[i] {
+			left = append(left, c)
+		}
+	}
+	return left, nil
+}
@@ -0,0 +1,91 @@
+// Package deck models French-suited playing cards and the shoe they're dealt
+// from: building decks (New and its options), shuffling them perfectly or like
+// a dealer would (Permer, Riffle), and dealing and discarding (Shoe). Nothing
+// in it is specific to blackjack apart from Rank.Value.
+package deck
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEmpty is returned when drawing from a shoe without enough cards left.
+var ErrEmpty = errors.New("Not enough cards left in the shoe")
+
+// Draw removes the top card from cards and returns it with the cards left. It
+// panics if cards is empty; Shoe.Draw checks instead.
+func Draw(cards []Card) (Card, []Card) {
+	return cards[0], cards[1:]
+}
+
+// Shoe is a stack of cards to deal from with its discard pile, for any card
+// game: cards are drawn off the top, played cards go on the discard pile, and
+// a reshuffle gathers them back in.
+//
+//	shoe := deck.NewShoe(deck.New(deck.Deck(2)))
+//	shoe.Reshuffle(nil)
+//	hand, err := shoe.DrawN(5)
+//	...
+//	shoe.Discard(hand...)
+type Shoe struct {
+	Cards    []Card // Cards left to deal, the top one first
+	Discards []Card // Cards played since the last reshuffle, in the order they were discarded
+}
+
+// NewShoe returns a shoe dealing cards in the order given.
+func NewShoe(cards []Card) *Shoe {
+	return &Shoe{Cards: cards}
+}
+
+// Len returns the number of cards left to deal.
+func (s *Shoe) Len() int {
+	return len(s.Cards)
+}
+
+// Draw removes the top card and returns it, or ErrEmpty if there's none.
+func (s *Shoe) Draw() (Card, error) {
+	if len(s.Cards) == 0 {
+		return Card{}, ErrEmpty
+	}
+	var c Card
+	c, s.Cards = Draw(s.Cards)
+	return c, nil
+}
+
+// DrawN removes the top n cards and returns them in the order they were
+// drawn. If fewer than n are left it draws nothing and returns ErrEmpty.
+func (s *Shoe) DrawN(n int) ([]Card, error) {
+	if n < 0 {
+		panic(fmt.Sprintf("Can't draw %d cards", n))
+	}
+	if n > len(s.Cards) {
+		return nil, ErrEmpty
+	}
+	cards := append([]Card(nil), s.Cards[:n]...)
+	s.Cards = s.Cards[n:]
+	return cards, nil
+}
+
+// Peek returns the top n cards without drawing them, or all that are left if
+// there are fewer. The slice is the shoe's own, so it mustn't be changed.
+func (s *Shoe) Peek(n int) []Card {
+	return s.Cards[:min(max(n, 0), len(s.Cards))]
+}
+
+// Discard puts played cards on the discard pile.
+func (s *Shoe) Discard(cards ...Card) {
+	s.Discards = append(s.Discards, cards...)
+}
+
+// Reshuffle gathers the discards back into the shoe and shuffles everything
+// with p, or with Perfect if p is nil. Like a dealer, it stacks the discards on
+// top of the undealt cards before shuffling, which only matters to imperfect
+// shuffles such as Riffle.
+func (s *Shoe) Reshuffle(p Permer) {
+	if p == nil {
+		p = Perfect
+	}
+	stack := append(append([]Card(nil), s.Discards...), s.Cards...)
+	s.Cards = Permute(stack, p.Perm(len(stack)))
+	s.Discards = nil
+}
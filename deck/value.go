@@ -0,0 +1,21 @@
+package deck
+
+// Value returns the rank's blackjack value: 1 for an ace, 10 for a ten or a
+// face card, and the pip count otherwise.
+func (r Rank) Value() int {
+	return min(int(r), 10)
+}
+
+// IsTenValue reports whether the rank is worth ten: a ten or a face card.
+func (r Rank) IsTenValue() bool {
+	return r >= Ten
+}
+
+// BlackjackValue returns the card's blackjack value, counting an ace as 1.
+// Jokers are worth nothing.
+func (c Card) BlackjackValue() int {
+	if c.Suit == Joker {
+		return 0
+	}
+	return c.Rank.Value()
+}
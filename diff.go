@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/history"
+)
+
+// diff compares two hand histories or event logs decision by decision and
+// shows where they first diverge. It exits with 1 if they do.
+func diff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	context := fs.Int("context", 3, "number of matching decisions to show before the divergence")
+	parseFlags(fs, args)
+	if fs.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: diff [flags] a b (hand histories or -events logs)")
+		os.Exit(2)
+	}
+	a, err := loadRounds(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	b, err := loadRounds(fs.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	d := history.Diff(a, b)
+	if d == nil {
+		fmt.Printf("No differences in %d rounds\n", len(a))
+		return
+	}
+	var steps []history.Step
+	for _, r := range a {
+		steps = append(steps, history.Steps(r)...)
+	}
+	for i := max(d.Step-*context, 0); i < d.Step; i++ {
+		fmt.Println(" ", steps[i])
+	}
+	switch {
+	case d.A == nil:
+		fmt.Printf("%s ends after %d decisions\n", fs.Arg(0), d.Step)
+	case d.B == nil:
+		fmt.Printf("%s ends after %d decisions\n", fs.Arg(1), d.Step)
+	case d.Dealt:
+		fmt.Println("Different cards dealt after matching decisions (different seeds, or the game is not deterministic):")
+	default:
+		fmt.Println("Different decision on the same cards:")
+	}
+	if d.A != nil {
+		fmt.Println("-", d.A)
+	}
+	if d.B != nil {
+		fmt.Println("+", d.B)
+	}
+	os.Exit(1)
+}
+
+// loadRounds reads the rounds in a hand history file or an event log,
+// telling them apart by the JSON of the log.
+func loadRounds(name string) ([]blackjack.RoundResult, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var rounds []blackjack.RoundResult
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var events []blackjack.Event
+		if events, err = blackjack.ReadEvents(bytes.NewReader(data)); err == nil {
+			rounds, err = history.FromEvents(events)
+		}
+	} else {
+		rounds, err = history.Read(bufio.NewReader(bytes.NewReader(data)))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return rounds, nil
+}
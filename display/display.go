@@ -0,0 +1,215 @@
+// Package display renders cards, hands and money for the terminal, with optional
+// ANSI colors.
+package display
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// ANSI escape codes used for coloring.
+const (
+	reset = "\033[0m"
+	bold  = "\033[1m"
+	red   = "\033[31m"
+	green = "\033[32m"
+)
+
+// Printer writes formatted output.
+type Printer struct {
+	Out     io.Writer // Where output is written
+	In      io.Reader // Where the player's answers are read from, standard input if nil
+	Color   bool      // Whether to use ANSI colors
+	Verbose bool      // Screen-reader friendly output: full card names and sentences, no colors or symbols
+	Lang    Lang      // Language of the text, English if empty
+
+	Currency Currency // How amounts of money are written, plain numbers by default
+}
+
+// Stdout returns a Printer for standard output, with colors enabled when it's a
+// terminal and the NO_COLOR environment variable isn't set, in the language of
+// the environment.
+func Stdout() *Printer {
+	color := os.Getenv("NO_COLOR") == ""
+	if fi, err := os.Stdout.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		color = false
+	}
+	return &Printer{Out: os.Stdout, In: os.Stdin, Color: color, Lang: LangFromEnv()}
+}
+
+// Input returns where the player's answers are read from.
+func (p *Printer) Input() io.Reader {
+	if p.In == nil {
+		return os.Stdin
+	}
+	return p.In
+}
+
+// Println writes a line of output.
+func (p *Printer) Println(a ...interface{}) {
+	fmt.Fprintln(p.Out, a...)
+}
+
+// Printf writes formatted output.
+func (p *Printer) Printf(format string, a ...interface{}) {
+	fmt.Fprintf(p.Out, format, a...)
+}
+
+// paint wraps s in the given color codes if colors are enabled.
+func (p *Printer) paint(s string, codes ...string) string {
+	if !p.Color || p.Verbose {
+		return s
+	}
+	return strings.Join(codes, "") + s + reset
+}
+
+// ranks holds the short name of each rank, indexed by rank.
+var ranks = [...]string{"?", "A", "2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K"}
+
+// symbols holds the suit symbols, indexed by suit.
+var symbols = [...]string{"♠", "♦", "♣", "♥"}
+
+// Card renders a card compactly, e.g. "A♠", with red suits in red. Verbose
+// printers spell it out, e.g. "Ace of Spades".
+func (p *Printer) Card(c deck.Card) string {
+	if p.Verbose {
+		return p.cardName(c)
+	}
+	if c.Suit == deck.Joker {
+		return p.paint("Joker", bold)
+	}
+	s := ranks[c.Rank] + symbols[c.Suit]
+	if c.Suit == deck.Heart || c.Suit == deck.Diamond {
+		return p.paint(s, bold, red)
+	}
+	return p.paint(s, bold)
+}
+
+// Cards renders the cards separated by spaces, or as a list in a sentence for
+// verbose printers.
+func (p *Printer) Cards(cards []deck.Card) string {
+	s := make([]string, len(cards))
+	for i, c := range cards {
+		s[i] = p.Card(c)
+	}
+	if p.Verbose && len(s) > 1 {
+		return strings.Join(s[:len(s)-1], ", ") + " " + p.T(MsgAnd) + " " + s[len(s)-1]
+	}
+	return strings.Join(s, " ")
+}
+
+// Total renders a hand's score, marking soft totals and busts.
+func (p *Printer) Total(cards []deck.Card) string {
+	score := blackjack.Score(cards...)
+	switch {
+	case p.Verbose && blackjack.Blackjack(cards...):
+		return p.T(MsgBlackjackVerbose)
+	case p.Verbose && score > 21:
+		return p.T(MsgBustVerbose, score)
+	case p.Verbose && blackjack.Soft(cards...):
+		return p.T(MsgSoftVerbose, score)
+	case p.Verbose:
+		return p.T(MsgTotal, score)
+	case blackjack.Blackjack(cards...):
+		return p.paint(p.T(MsgBlackjack), bold, green)
+	case score > 21:
+		return p.paint(p.T(MsgBust, score), red)
+	case blackjack.Soft(cards...):
+		return p.T(MsgSoft, score)
+	default:
+		return fmt.Sprint(score)
+	}
+}
+
+// Hand renders the cards followed by the total, e.g. "A♠ 7♦ (soft 18)", or
+// "Ace of Spades and Seven of Diamonds, a soft 18" for verbose printers.
+func (p *Printer) Hand(cards []deck.Card) string {
+	if p.Verbose {
+		return fmt.Sprintf("%s, %s", p.Cards(cards), p.Total(cards))
+	}
+	return fmt.Sprintf("%s (%s)", p.Cards(cards), p.Total(cards))
+}
+
+// Money renders a win in green and a loss in red, with an explicit sign.
+func (p *Printer) Money(amount int) string {
+	switch {
+	case p.Verbose && amount > 0:
+		return p.T(MsgWin, p.Amount(amount))
+	case p.Verbose && amount < 0:
+		return p.T(MsgLoss, p.Amount(-amount))
+	case p.Verbose:
+		return p.T(MsgEven)
+	case amount > 0:
+		return p.paint("+"+p.Amount(amount), green)
+	case amount < 0:
+		return p.paint(p.Amount(amount), red)
+	default:
+		return p.Amount(0)
+	}
+}
+
+// Amount renders an amount of money, such as a bet, in the printer's currency.
+func (p *Printer) Amount(amount int) string {
+	return p.Currency.Format(amount)
+}
+
+// Average renders a fractional amount of money, such as the EV per round, in
+// the printer's currency with at least two decimals.
+func (p *Printer) Average(amount float64) string {
+	return p.Currency.FormatFloat(amount, 2)
+}
+
+// Table writes rows with aligned columns. Colors are left out of the width
+// calculation, so colored cells still line up. Verbose printers write each row
+// as a sentence instead, since padding means nothing to a screen reader.
+func (p *Printer) Table(rows [][]string) {
+	if p.Verbose {
+		for _, row := range rows {
+			if len(row) > 0 {
+				fmt.Fprintln(p.Out, row[0], strings.Join(row[1:], ", ")+".")
+			}
+		}
+		return
+	}
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], width(cell))
+		}
+	}
+	for _, row := range rows {
+		var b strings.Builder
+		for i, cell := range row {
+			b.WriteString(cell)
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-width(cell)+2))
+			}
+		}
+		fmt.Fprintln(p.Out, b.String())
+	}
+}
+
+// width returns the number of characters s takes up on screen, skipping ANSI
+// escape sequences.
+func width(s string) int {
+	n, escaped := 0, false
+	for _, r := range s {
+		switch {
+		case r == '\033':
+			escaped = true
+		case escaped:
+			escaped = r != 'm'
+		default:
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,333 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Lang identifies the language of the interactive text.
+type Lang string
+
+const (
+	English Lang = "en"
+	Spanish Lang = "es"
+)
+
+// Langs lists the languages with a message catalog.
+var Langs = []Lang{English, Spanish}
+
+// LangFromEnv picks a language from the LC_ALL, LC_MESSAGES or LANG environment
+// variables, falling back to English.
+func LangFromEnv() Lang {
+	for _, v := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if env := os.Getenv(v); env != "" {
+			for _, l := range Langs {
+				if strings.HasPrefix(env, string(l)) {
+					return l
+				}
+			}
+			return English
+		}
+	}
+	return English
+}
+
+// Message identifies a piece of interactive text in the catalogs.
+type Message int
+
+const (
+	MsgShuffled Message = iota
+	MsgBetPrompt
+	MsgPlayer
+	MsgDealer
+	MsgActionPrompt
+	MsgInvalidOption
+	MsgFinalHands
+	MsgPlayerHand
+	MsgWinnings
+	MsgBlackjack
+	MsgBust
+	MsgSoft
+	MsgAnd
+	MsgWin
+	MsgLoss
+	MsgEven
+	MsgTotal
+	MsgCardName
+	MsgYourHand
+	MsgDealerShows
+	MsgRound
+	MsgDealerShuffles
+	MsgBet
+	MsgVersus
+	MsgFinalHand
+	MsgHit
+	MsgStand
+	MsgDouble
+	MsgSplit
+	MsgSurrender
+	MsgCount
+	MsgOutcomeWin
+	MsgOutcomeLoss
+	MsgOutcomePush
+	MsgOutcomeBlackjack
+	MsgOutcomeSurrender
+	MsgOutcomeBust
+	MsgOutcomeDealerBust
+	MsgInvalidBet
+	MsgBurned
+	MsgSeatUp
+	MsgBankroll
+	MsgDeal
+	MsgClearBet
+	MsgGameOver
+	MsgQuizRunning
+	MsgQuizTrue
+	MsgQuizRight
+	MsgQuizWrong
+	MsgWhatIf
+	MsgEVs
+	MsgEVBest
+	MsgEVCost
+	MsgStandOdds
+	MsgInsure
+	MsgInsurance
+	MsgSurrenderPrompt
+
+	// Verbose variants, used by screen-reader friendly printers
+	MsgBetPromptVerbose
+	MsgActionPromptVerbose
+	MsgFinalHandsVerbose
+	MsgBlackjackVerbose
+	MsgBustVerbose
+	MsgSoftVerbose
+	MsgCountVerbose
+	MsgInsureVerbose
+	MsgSurrenderVerbose
+)
+
+// catalogs holds the text of every message, per language. English is complete;
+// other languages fall back to it for anything missing.
+var catalogs = map[Lang]map[Message]string{
+	English: {
+		MsgShuffled:            "The deck was just shuffled",
+		MsgBetPrompt:           "What would you like to bet?",
+		MsgPlayer:              "Player:",
+		MsgDealer:              "Dealer:",
+		MsgActionPrompt:        "What will you do? (h)it, (s)tand, (d)ouble or s(p)lit",
+		MsgInvalidOption:       "Not a valid option.",
+		MsgFinalHands:          "=== FINAL HANDS ===",
+		MsgPlayerHand:          "Player hand %d:",
+		MsgWinnings:            "Winnings:",
+		MsgBlackjack:           "Blackjack",
+		MsgBust:                "%d bust",
+		MsgSoft:                "soft %d",
+		MsgAnd:                 "and",
+		MsgWin:                 "a win of %s",
+		MsgLoss:                "a loss of %s",
+		MsgEven:                "even",
+		MsgTotal:               "a total of %d",
+		MsgCardName:            "%s of %s",
+		MsgYourHand:            "Your hand is %s.",
+		MsgDealerShows:         "The dealer shows the %s.",
+		MsgRound:               "--- Round %d ---",
+		MsgDealerShuffles:      "The dealer shuffles the shoe.",
+		MsgBet:                 "Bet: %s",
+		MsgVersus:              "Player: %s vs dealer showing %s",
+		MsgFinalHand:           "Final hand: %s",
+		MsgHit:                 "hit",
+		MsgStand:               "stand",
+		MsgDouble:              "double",
+		MsgSplit:               "split",
+		MsgSurrender:           "surrender",
+		MsgCount:               "Count: %+d running, %+.1f true",
+		MsgOutcomeWin:          "Win",
+		MsgOutcomeLoss:         "Loss",
+		MsgOutcomePush:         "Push",
+		MsgOutcomeBlackjack:    "Blackjack",
+		MsgOutcomeSurrender:    "Surrendered",
+		MsgOutcomeBust:         "Bust",
+		MsgOutcomeDealerBust:   "Dealer busts",
+		MsgInvalidBet:          "Not a valid bet: %v.",
+		MsgBurned:              "The dealer burns %s",
+		MsgSeatUp:              "%s, it's your turn (%s so far)",
+		MsgBankroll:            "Bankroll: %s",
+		MsgDeal:                "Deal (%s)",
+		MsgClearBet:            "Clear",
+		MsgGameOver:            "Game over",
+		MsgQuizRunning:         "Count check! What's the running count?",
+		MsgQuizTrue:            "And the true count?",
+		MsgQuizRight:           "Right: the running count is %+d, the true count %+.1f.",
+		MsgQuizWrong:           "Not quite: the running count is %+d, the true count %+.1f.",
+		MsgWhatIf:              "Basic strategy says %s rather than %s on %s against %s: the round would have come to %s instead of %s.",
+		MsgEVs:                 "Expected value per unit bet: %s",
+		MsgEVBest:              "Best move here: %s (%+.3f).",
+		MsgEVCost:              "You chose %s (%+.3f) over %s (%+.3f), which cost %.3f of your bet.",
+		MsgStandOdds:           "Standing on %s against %s wins %.0f%% of the time, pushes %.0f%% and loses %.0f%%.",
+		MsgInsure:              "Insurance? (y)es or (n)o",
+		MsgInsurance:           "Insurance:",
+		MsgSurrenderPrompt:     "What will you do? (h)it, (s)tand, (d)ouble, s(p)lit or su(r)render",
+		MsgBetPromptVerbose:    "What would you like to bet? Type an amount and press Enter.",
+		MsgActionPromptVerbose: "Type h to hit, s to stand, d to double down, or p to split, then press Enter.",
+		MsgFinalHandsVerbose:   "The round is over. Final hands:",
+		MsgBlackjackVerbose:    "blackjack",
+		MsgBustVerbose:         "bust with %d",
+		MsgSoftVerbose:         "a soft %d",
+		MsgCountVerbose:        "The running count is %d and the true count is %.1f.",
+		MsgInsureVerbose:       "The dealer shows an ace. Type y to take insurance for half your bet, or n to decline, then press Enter.",
+		MsgSurrenderVerbose:    "Type h to hit, s to stand, d to double down, p to split, or r to surrender half your bet, then press Enter.",
+	},
+	Spanish: {
+		MsgShuffled:            "Se acaba de barajar el mazo",
+		MsgBetPrompt:           "¿Cuánto quieres apostar?",
+		MsgPlayer:              "Jugador:",
+		MsgDealer:              "Crupier:",
+		MsgActionPrompt:        "¿Qué harás? (h) pedir, (s) plantarte, (d) doblar o (p) separar",
+		MsgInvalidOption:       "Esa opción no es válida.",
+		MsgFinalHands:          "=== MANOS FINALES ===",
+		MsgPlayerHand:          "Mano %d del jugador:",
+		MsgWinnings:            "Ganancias:",
+		MsgBlackjack:           "Blackjack",
+		MsgBust:                "%d, te pasaste",
+		MsgSoft:                "%d blando",
+		MsgAnd:                 "y",
+		MsgWin:                 "una ganancia de %s",
+		MsgLoss:                "una pérdida de %s",
+		MsgEven:                "sin cambios",
+		MsgTotal:               "un total de %d",
+		MsgCardName:            "%s de %s",
+		MsgYourHand:            "Tu mano es %s.",
+		MsgDealerShows:         "El crupier muestra el %s.",
+		MsgRound:               "--- Ronda %d ---",
+		MsgDealerShuffles:      "El crupier baraja el zapato.",
+		MsgBet:                 "Apuesta: %s",
+		MsgVersus:              "Jugador: %s contra %s del crupier",
+		MsgFinalHand:           "Mano final: %s",
+		MsgHit:                 "pedir",
+		MsgStand:               "plantarse",
+		MsgDouble:              "doblar",
+		MsgSplit:               "separar",
+		MsgSurrender:           "rendirse",
+		MsgCount:               "Cuenta: %+d corrida, %+.1f real",
+		MsgOutcomeWin:          "Ganas",
+		MsgOutcomeLoss:         "Pierdes",
+		MsgOutcomePush:         "Empate",
+		MsgOutcomeBlackjack:    "Blackjack",
+		MsgOutcomeSurrender:    "Te rendiste",
+		MsgOutcomeBust:         "Te pasaste",
+		MsgOutcomeDealerBust:   "El crupier se pasa",
+		MsgInvalidBet:          "Esa apuesta no es válida: %v.",
+		MsgBurned:              "El crupier quema %s",
+		MsgSeatUp:              "%s, te toca (%s hasta ahora)",
+		MsgBankroll:            "Banca: %s",
+		MsgDeal:                "Repartir (%s)",
+		MsgClearBet:            "Borrar",
+		MsgGameOver:            "Fin de la partida",
+		MsgQuizRunning:         "¡Control de cuenta! ¿Cuál es la cuenta corrida?",
+		MsgQuizTrue:            "¿Y la cuenta real?",
+		MsgQuizRight:           "Correcto: la cuenta corrida es %+d y la real %+.1f.",
+		MsgQuizWrong:           "No exactamente: la cuenta corrida es %+d y la real %+.1f.",
+		MsgWhatIf:              "La estrategia básica dice %s en vez de %s con %s contra %s: la ronda habría quedado en %s en lugar de %s.",
+		MsgEVs:                 "Valor esperado por unidad apostada: %s",
+		MsgEVBest:              "Mejor jugada aquí: %s (%+.3f).",
+		MsgEVCost:              "Elegiste %s (%+.3f) en vez de %s (%+.3f), lo que te costó %.3f de la apuesta.",
+		MsgStandOdds:           "Plantándote con %s contra %s ganas el %.0f%% de las veces, empatas el %.0f%% y pierdes el %.0f%%.",
+		MsgInsure:              "¿Seguro? (y) sí o (n) no",
+		MsgInsurance:           "Seguro:",
+		MsgSurrenderPrompt:     "¿Qué harás? (h) pedir, (s) plantarte, (d) doblar, (p) separar o (r) rendirte",
+		MsgBetPromptVerbose:    "¿Cuánto quieres apostar? Escribe una cantidad y pulsa Intro.",
+		MsgActionPromptVerbose: "Escribe h para pedir carta, s para plantarte, d para doblar o p para separar, y pulsa Intro.",
+		MsgFinalHandsVerbose:   "La ronda ha terminado. Manos finales:",
+		MsgBlackjackVerbose:    "blackjack",
+		MsgBustVerbose:         "te pasaste con %d",
+		MsgSoftVerbose:         "un %d blando",
+		MsgCountVerbose:        "La cuenta corrida es %d y la cuenta real es %.1f.",
+		MsgInsureVerbose:       "El crupier muestra un as. Escribe y para asegurar con la mitad de tu apuesta, o n para no hacerlo, y pulsa Intro.",
+		MsgSurrenderVerbose:    "Escribe h para pedir carta, s para plantarte, d para doblar, p para separar o r para rendirte y perder la mitad de la apuesta, y pulsa Intro.",
+	},
+}
+
+// rankNames and suitNames hold the spelled-out card names, per language.
+var (
+	rankNames = map[Lang][]string{
+		English: {"", "Ace", "Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten", "Jack", "Queen", "King"},
+		Spanish: {"", "As", "Dos", "Tres", "Cuatro", "Cinco", "Seis", "Siete", "Ocho", "Nueve", "Diez", "Jota", "Reina", "Rey"},
+	}
+	suitNames = map[Lang][]string{
+		English: {"Spades", "Diamonds", "Clubs", "Hearts", "Joker"},
+		Spanish: {"Picas", "Diamantes", "Tréboles", "Corazones", "Comodín"},
+	}
+)
+
+// T returns the message in the printer's language, formatted with args.
+func (p *Printer) T(m Message, args ...interface{}) string {
+	text, ok := catalogs[p.Lang][m]
+	if !ok {
+		text = catalogs[English][m]
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// cardName spells out a card in the printer's language, e.g. "Ace of Spades".
+func (p *Printer) cardName(c deck.Card) string {
+	ranks, ok := rankNames[p.Lang]
+	if !ok {
+		ranks = rankNames[English]
+	}
+	suits, ok := suitNames[p.Lang]
+	if !ok {
+		suits = suitNames[English]
+	}
+	if c.Suit == deck.Joker {
+		return suits[deck.Joker]
+	}
+	return p.T(MsgCardName, ranks[c.Rank], suits[c.Suit])
+}
+
+// outcomes maps each outcome to its message.
+var outcomes = map[blackjack.Outcome]Message{
+	blackjack.OutcomeWin:        MsgOutcomeWin,
+	blackjack.OutcomeLoss:       MsgOutcomeLoss,
+	blackjack.OutcomePush:       MsgOutcomePush,
+	blackjack.OutcomeBlackjack:  MsgOutcomeBlackjack,
+	blackjack.OutcomeSurrender:  MsgOutcomeSurrender,
+	blackjack.OutcomeBust:       MsgOutcomeBust,
+	blackjack.OutcomeDealerBust: MsgOutcomeDealerBust,
+}
+
+// Outcome describes how a hand was settled. The engine explains it in
+// English, so other languages get the outcome's name instead.
+func (p *Printer) Outcome(h blackjack.HandResult) string {
+	if (p.Lang == English || p.Lang == "") && h.Explanation != "" {
+		return h.Explanation
+	}
+	if m, ok := outcomes[h.Outcome]; ok {
+		return p.T(m)
+	}
+	return string(h.Outcome)
+}
+
+// Move names a built-in move in the printer's language.
+func (p *Printer) Move(m blackjack.Move) string {
+	switch m.String() {
+	case "hit":
+		return p.T(MsgHit)
+	case "stand":
+		return p.T(MsgStand)
+	case "double":
+		return p.T(MsgDouble)
+	case "split":
+		return p.T(MsgSplit)
+	case "surrender":
+		return p.T(MsgSurrender)
+	default:
+		return m.String()
+	}
+}
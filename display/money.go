@@ -0,0 +1,111 @@
+package display
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Currency is how amounts of money are written. The zero Currency writes plain
+// numbers, as the engine counts them.
+type Currency struct {
+	Prefix    string  // Written before the number, e.g. "$"
+	Suffix    string  // Written after it, e.g. " €" or "u"
+	Unit      float64 // Engine amount per unit written, e.g. 100 to count in units of a 100 bet; 1 if 0
+	Thousands string  // Separates groups of three digits, e.g. ","; none if empty
+	Point     string  // Decimal point, "." if empty
+}
+
+// Currencies are the currencies known by name to ParseCurrency.
+var Currencies = map[string]Currency{
+	"plain":   {},
+	"dollars": {Prefix: "$", Thousands: ","},
+	"euros":   {Suffix: " €", Thousands: ".", Point: ","},
+	"pounds":  {Prefix: "£", Thousands: ","},
+	"units":   {Suffix: "u", Unit: 100, Thousands: ","},
+}
+
+// ParseCurrency returns the currency called name in Currencies. Units may be
+// given a size, as in "units:25" for units of 25.
+func ParseCurrency(name string) (Currency, error) {
+	name, size, sized := strings.Cut(name, ":")
+	c, ok := Currencies[name]
+	if !ok {
+		var names []string
+		for n := range Currencies {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return c, fmt.Errorf("Unknown currency %q, try one of: %s", name, strings.Join(names, ", "))
+	}
+	if sized {
+		unit, err := strconv.ParseFloat(size, 64)
+		if err != nil || !(unit > 0) || name != "units" {
+			return c, fmt.Errorf("Invalid unit size %q for %s", size, name)
+		}
+		c.Unit = unit
+	}
+	return c, nil
+}
+
+// Format writes an amount, e.g. "$1,250" or "-12.5u". Amounts in units get
+// as many decimals as they need to be exact, and no more.
+func (c Currency) Format(amount int) string {
+	if c.Unit <= 1 {
+		return c.format(float64(amount), 0)
+	}
+	s := strings.TrimSuffix(c.format(float64(amount), c.unitDigits()), c.Suffix)
+	s = strings.TrimSuffix(strings.TrimRight(s, "0"), c.point())
+	return s + c.Suffix
+}
+
+// FormatFloat writes a fractional amount, such as an average, with decimals
+// digits after the point, plus as many as a unit takes.
+func (c Currency) FormatFloat(amount float64, decimals int) string {
+	if decimals > 0 {
+		decimals += c.unitDigits()
+	}
+	return c.format(amount, decimals)
+}
+
+// unitDigits returns the decimals it takes to write an engine amount of 1.
+func (c Currency) unitDigits() int {
+	if c.Unit <= 1 {
+		return 0
+	}
+	return int(math.Ceil(math.Log10(c.Unit)))
+}
+
+func (c Currency) point() string {
+	if c.Point == "" {
+		return "."
+	}
+	return c.Point
+}
+
+// format writes amount in units with decimals digits after the point.
+func (c Currency) format(amount float64, decimals int) string {
+	x := amount
+	if c.Unit > 0 {
+		x /= c.Unit
+	}
+	sign := ""
+	if x < 0 {
+		sign, x = "-", -x
+	}
+	whole, frac, _ := strings.Cut(strconv.FormatFloat(x, 'f', decimals, 64), ".")
+	if sign != "" && strings.Trim(whole+frac, "0") == "" {
+		sign = "" // Don't write -0
+	}
+	if c.Thousands != "" {
+		for i := len(whole) - 3; i > 0; i -= 3 {
+			whole = whole[:i] + c.Thousands + whole[i:]
+		}
+	}
+	if frac != "" {
+		whole += c.point() + frac
+	}
+	return sign + c.Prefix + whole + c.Suffix
+}
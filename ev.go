@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/sim"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// ev estimates the basic AI's expected result per round, shoe by shoe.
+func ev(args []string) {
+	fs := flag.NewFlagSet("ev", flag.ExitOnError)
+	decks := fs.Int("decks", 4, "number of decks used")
+	pairs := fs.Int("pairs", 5000, "number of pairs of shoes to play")
+	antithetic := fs.Bool("antithetic", false, "pair every shoe with its mirror image to reduce variance")
+	printer := outputFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+
+	opts := blackjack.Options{Decks: *decks}
+	newAI := func() blackjack.AI { return strategy.BasicAI(*decks) }
+	var r sim.EVReport
+	if *antithetic {
+		r = sim.RunAntithetic(opts, newAI, *pairs)
+	} else {
+		r = sim.RunIndependent(opts, newAI, *pairs)
+	}
+	out.Table([][]string{
+		{"Shoes played:", fmt.Sprint(2 * r.Samples)},
+		{"Rounds played:", fmt.Sprint(r.Rounds)},
+		{"EV per round:", fmt.Sprintf("%s ± %s", out.Average(r.EV), out.Average(r.StdErr))},
+	})
+}
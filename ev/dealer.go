@@ -0,0 +1,80 @@
+package ev
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// DealerOdds is how likely the dealer's hand is to end each way.
+type DealerOdds struct {
+	Totals    [5]float64 // Chance of standing on 17 to 21
+	Bust22    float64    // Chance of busting on exactly 22, a push under Push22
+	Bust      float64    // Chance of busting on more than 22
+	Blackjack float64    // Chance of a blackjack, none once the dealer has peeked
+}
+
+// Dealer returns the odds of each final dealer hand with the upcard, the
+// hole card and any draws coming from shoe, which holds the cards that might
+// still be dealt. Where the rules have the dealer peek under the upcard, a
+// blackjack has already been ruled out.
+func Dealer(r blackjack.Rules, shoe Shoe, up deck.Card) DealerOdds {
+	c := newCalc(r, shoe, up.BlackjackValue(), nil)
+	bj := 0.0
+	if !r.Peek.Peeks(up) {
+		for v := 1; v <= 10; v++ {
+			if blackjackWith(up.BlackjackValue(), v) {
+				bj += float64(shoe[v-1]) / float64(shoe.total())
+			}
+		}
+	}
+	d := DealerOdds{Bust22: c.dealer[dealer22], Bust: c.dealer[dealerBust], Blackjack: bj}
+	copy(d.Totals[:], c.dealer[:dealer22])
+	for i := range d.Totals {
+		d.Totals[i] *= 1 - bj
+	}
+	d.Bust22 *= 1 - bj
+	d.Bust *= 1 - bj
+	return d
+}
+
+// Odds is how likely a hand is to win, push or lose.
+type Odds struct {
+	Win  float64
+	Push float64
+	Lose float64
+}
+
+// Standing returns the odds of standing on hand against the dealer's final
+// hands. split reports whether the hand came from a split, so a two card 21
+// isn't a natural.
+func (d DealerOdds) Standing(r blackjack.Rules, hand []deck.Card, split bool) Odds {
+	total, soft := 0, false
+	for _, card := range hand {
+		total, soft = add(total, soft, card.BlackjackValue())
+	}
+	switch {
+	case total > 21:
+		return Odds{Lose: 1}
+	case total == 21 && len(hand) == 2 && !split:
+		return Odds{Win: 1 - d.Blackjack, Push: d.Blackjack}
+	case total == 21 && r.Variant == blackjack.SuperFun21:
+		return Odds{Win: 1 - d.Blackjack, Lose: d.Blackjack} // A player 21 always wins
+	}
+	o := Odds{Win: d.Bust, Lose: d.Blackjack}
+	if r.Push22 {
+		o.Push += d.Bust22
+	} else {
+		o.Win += d.Bust22
+	}
+	for i, p := range d.Totals {
+		switch dealer := 17 + i; {
+		case total > dealer:
+			o.Win += p
+		case total < dealer || r.DealerWinsTies:
+			o.Lose += p
+		default:
+			o.Push += p
+		}
+	}
+	return o
+}
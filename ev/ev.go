@@ -0,0 +1,355 @@
+// Package ev computes the expected value of each decision a player can make,
+// from the rules of the game and the composition of the shoe.
+//
+// The dealer's outcomes are worked out exactly for the cards left in the shoe
+// once the player's cards and the upcard are removed, given that the dealer
+// has already checked for blackjack where the peek rule says so; under other
+// upcards a dealer blackjack takes every bet on the table once the hand is
+// played. The player's own draws are taken from
+// that same composition, so the small effect of the player's hit cards on the
+// shoe is ignored, and split hands are played without resplitting.
+package ev
+
+import (
+	"math"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Shoe is the number of cards of each value left in a shoe: index 0 holds the
+// aces, 1 the twos and so on up to 9, which holds all the ten-value cards.
+type Shoe [10]int
+
+// NewShoe returns a full shoe for the rules' number of decks and variant.
+func NewShoe(r blackjack.Rules) Shoe {
+	var s Shoe
+	for _, c := range r.Variant.Shoe(r.Decks) {
+		s[c.BlackjackValue()-1]++
+	}
+	return s
+}
+
+// FromComposition returns the shoe holding the unseen cards of a composition,
+// such as the engine keeps (Game.Composition). Jokers are left out.
+func FromComposition(c blackjack.Composition) Shoe {
+	var s Shoe
+	for v := 1; v <= 10; v++ {
+		s[v-1] = c.Value(v)
+	}
+	return s
+}
+
+// Remove returns the shoe without the given cards.
+func (s Shoe) Remove(cards ...deck.Card) Shoe {
+	for _, c := range cards {
+		s[c.BlackjackValue()-1]--
+	}
+	return s
+}
+
+func (s Shoe) total() int {
+	n := 0
+	for _, c := range s {
+		n += c
+	}
+	return n
+}
+
+// EVs holds the expected value of every action for a hand, per unit of the
+// original bet. Actions that aren't available are NaN.
+type EVs struct {
+	Stand     float64
+	Hit       float64
+	Double    float64
+	Split     float64
+	Surrender float64
+}
+
+// Best returns the action with the highest expected value and that value.
+func (e EVs) Best() (blackjack.Move, float64) {
+	move, best := blackjack.MoveStand, e.Stand
+	for _, a := range []struct {
+		move blackjack.Move
+		ev   float64
+	}{
+		{blackjack.MoveHit, e.Hit},
+		{blackjack.MoveDouble, e.Double},
+		{blackjack.MoveSplit, e.Split},
+		{blackjack.MoveSurrender, e.Surrender},
+	} {
+		if a.ev > best {
+			move, best = a.move, a.ev
+		}
+	}
+	return move, best
+}
+
+// Hand returns the expected values of the actions for a hand against the
+// dealer's upcard. shoe holds the cards that might still be dealt, without
+// the hand and the upcard. split reports whether the hand came from a split,
+// which rules out surrender and, without DoubleAfterSplit, doubling.
+func Hand(r blackjack.Rules, shoe Shoe, hand []deck.Card, up deck.Card, split bool) EVs {
+	return HandGiven(r, shoe, hand, up, split, nil)
+}
+
+// Likelihood weighs each possible hole card by how likely what the player
+// knows about it would be if it were that card, indexed like a Shoe. A tell
+// that's right 70% of the time that the dealer has a stiff gives 0.7 to the
+// values that make one and 0.3 to the rest.
+type Likelihood [10]float64
+
+// HandGiven is Hand for a player who knows something about the hole card,
+// weighing the dealer's hands by like. A nil like knows nothing.
+func HandGiven(r blackjack.Rules, shoe Shoe, hand []deck.Card, up deck.Card, split bool, like *Likelihood) EVs {
+	c := newCalc(r, shoe, up.BlackjackValue(), like)
+	total, soft := 0, false
+	for _, card := range hand {
+		total, soft = add(total, soft, card.BlackjackValue())
+	}
+
+	e := EVs{
+		Stand:     c.stand(total),
+		Hit:       c.hit(total, soft),
+		Double:    math.NaN(),
+		Split:     math.NaN(),
+		Surrender: math.NaN(),
+	}
+	if len(hand) == 2 && r.DoubleOn.Allows(total) && (!split || r.DoubleAfterSplit) {
+		e.Double = c.double(total, soft)
+	}
+	if len(hand) == 2 && hand[0].Rank == hand[1].Rank {
+		e.Split = 2 * c.splitHand(hand[0].BlackjackValue())
+	}
+	if r.Surrender && !split && (len(hand) == 2 || r.Variant == blackjack.SuperFun21) {
+		e.Surrender = -0.5
+	}
+	if !r.Peek.Peeks(up) {
+		e = unpeeked(r, e, shoe, up.BlackjackValue(), like)
+	}
+	return e
+}
+
+// unpeeked weighs in the dealer blackjacks that weren't peeked for, which take
+// the original bet and, unless only original bets are lost, any doubled or
+// split bets. Late surrender loses the whole bet to them too; Super Fun 21's
+// gives back half all the same.
+func unpeeked(r blackjack.Rules, e EVs, shoe Shoe, up int, like *Likelihood) EVs {
+	bj, all := 0.0, 0.0
+	for v := 1; v <= 10; v++ {
+		w := like.weigh(shoe, v)
+		if blackjackWith(up, v) {
+			bj += w
+		}
+		all += w
+	}
+	p := bj / all
+	if p == 0 {
+		return e
+	}
+	e.Stand = p*-1 + (1-p)*e.Stand
+	e.Hit = p*-1 + (1-p)*e.Hit
+	lost := -2.0
+	if r.OriginalBetsOnly {
+		lost = -1
+	}
+	e.Double = p*lost + (1-p)*e.Double
+	e.Split = p*lost + (1-p)*e.Split
+	if r.Variant != blackjack.SuperFun21 {
+		e.Surrender = p*-1 + (1-p)*e.Surrender
+	}
+	return e
+}
+
+// Dealer outcomes, indexed by final total less 17. A total of 22 is kept
+// apart from other busts for Push22.
+const (
+	dealer22   = 5
+	dealerBust = 6
+)
+
+type calc struct {
+	r      blackjack.Rules
+	p      [10]float64 // Chance of drawing each value
+	dealer [7]float64  // Chance of each dealer outcome
+
+	hitEV  [22][2]float64
+	hitSet [22][2]bool
+}
+
+func newCalc(r blackjack.Rules, shoe Shoe, up int, like *Likelihood) *calc {
+	c := &calc{r: r}
+	n := float64(shoe.total())
+	for i, k := range shoe {
+		c.p[i] = float64(k) / n
+	}
+	c.dealerOutcomes(shoe, up, like)
+	return c
+}
+
+// weigh returns the weight of hole card value v: the cards of that value left
+// in the shoe, times its likelihood if there is one.
+func (l *Likelihood) weigh(shoe Shoe, v int) float64 {
+	w := float64(shoe[v-1])
+	if l != nil {
+		w *= l[v-1]
+	}
+	return w
+}
+
+// add adds a card value to a hand total, counting an ace as 11 when it fits.
+func add(total int, soft bool, v int) (int, bool) {
+	total += v
+	if v == 1 && total <= 11 {
+		total, soft = total+10, true
+	}
+	if total > 21 && soft {
+		total, soft = total-10, false
+	}
+	return total, soft
+}
+
+// dealerOutcomes works out the dealer's final totals, drawing the hole card
+// from the cards that don't give the dealer blackjack, weighed by like.
+func (c *calc) dealerOutcomes(shoe Shoe, up int, like *Likelihood) {
+	total, soft := add(0, false, up)
+	weights := 0.0
+	for v := 1; v <= 10; v++ {
+		if blackjackWith(up, v) {
+			continue
+		}
+		weights += like.weigh(shoe, v)
+	}
+	for v := 1; v <= 10; v++ {
+		if blackjackWith(up, v) || like.weigh(shoe, v) == 0 {
+			continue
+		}
+		p := like.weigh(shoe, v) / weights
+		shoe[v-1]--
+		t, s := add(total, soft, v)
+		c.dealerDraw(&shoe, t, s, p)
+		shoe[v-1]++
+	}
+}
+
+func blackjackWith(up, hole int) bool {
+	return up == 1 && hole == 10 || up == 10 && hole == 1
+}
+
+// dealerDraw plays out the dealer's hand from total, adding p times the
+// chance of each outcome. shoe is restored before returning.
+func (c *calc) dealerDraw(shoe *Shoe, total int, soft bool, p float64) {
+	if total > 17 || total == 17 && (!soft || c.r.StandSoft17) {
+		switch {
+		case total == 22:
+			c.dealer[dealer22] += p
+		case total > 22:
+			c.dealer[dealerBust] += p
+		default:
+			c.dealer[total-17] += p
+		}
+		return
+	}
+	n := float64(shoe.total())
+	for v := 1; v <= 10; v++ {
+		k := shoe[v-1]
+		if k == 0 {
+			continue
+		}
+		shoe[v-1]--
+		t, s := add(total, soft, v)
+		c.dealerDraw(shoe, t, s, p*float64(k)/n)
+		shoe[v-1]++
+	}
+}
+
+// stand returns the expected value of standing on total.
+func (c *calc) stand(total int) float64 {
+	if total > 21 {
+		return -1
+	}
+	if total == 21 && c.r.Variant == blackjack.SuperFun21 {
+		return 1 // A player 21 always wins
+	}
+	ev := c.dealer[dealerBust]
+	if !c.r.Push22 {
+		ev += c.dealer[dealer22]
+	}
+	for i := 0; i < dealer22; i++ {
+		switch d := 17 + i; {
+		case total > d:
+			ev += c.dealer[i]
+		case total < d:
+			ev -= c.dealer[i]
+		case c.r.DealerWinsTies:
+			ev -= c.dealer[i]
+		}
+	}
+	return ev
+}
+
+// best returns the expected value of playing on a hand that can no longer be
+// doubled, split or surrendered, except where Super Fun 21 allows surrender.
+func (c *calc) best(total int, soft bool) float64 {
+	if total > 21 {
+		return -1
+	}
+	ev := max(c.stand(total), c.hit(total, soft))
+	if c.r.Surrender && c.r.Variant == blackjack.SuperFun21 {
+		ev = max(ev, -0.5)
+	}
+	return ev
+}
+
+// hit returns the expected value of taking a card and then playing on as well
+// as possible.
+func (c *calc) hit(total int, soft bool) float64 {
+	if total > 21 {
+		return -1
+	}
+	s := 0
+	if soft {
+		s = 1
+	}
+	if c.hitSet[total][s] {
+		return c.hitEV[total][s]
+	}
+	ev := 0.0
+	for v := 1; v <= 10; v++ {
+		if c.p[v-1] == 0 {
+			continue
+		}
+		t, sf := add(total, soft, v)
+		ev += c.p[v-1] * c.best(t, sf)
+	}
+	c.hitEV[total][s], c.hitSet[total][s] = ev, true
+	return ev
+}
+
+// double returns the expected value of doubling, per unit of the original bet.
+func (c *calc) double(total int, soft bool) float64 {
+	ev := 0.0
+	for v := 1; v <= 10; v++ {
+		t, _ := add(total, soft, v)
+		ev += c.p[v-1] * c.stand(t)
+	}
+	return 2 * ev
+}
+
+// splitHand returns the expected value of one hand split off a pair of v.
+func (c *calc) splitHand(v int) float64 {
+	total, soft := add(0, false, v)
+	ev := 0.0
+	for w := 1; w <= 10; w++ {
+		if c.p[w-1] == 0 {
+			continue
+		}
+		t, s := add(total, soft, w)
+		best := c.best(t, s)
+		if c.r.DoubleAfterSplit && c.r.DoubleOn.Allows(t) {
+			best = max(best, c.double(t, s))
+		}
+		ev += c.p[w-1] * best
+	}
+	return ev
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+)
+
+// openEvents opens an event log for writing. When resuming, the events already
+// in the file are read back and new ones are appended; otherwise the file is
+// truncated.
+func openEvents(name string, resume bool) (*os.File, []blackjack.Event, error) {
+	if !resume {
+		f, err := os.Create(name)
+		return f, nil, err
+	}
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_APPEND, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	events, err := blackjack.ReadEvents(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return f, events, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	evpkg "github.com/Scrimzay/blackjacksimulator/ev"
+)
+
+// evTable prints the exact EV of every move for every two card hand against
+// every upcard: the numbers the strategy chart is read off.
+func evTable(args []string) {
+	fs := flag.NewFlagSet("evtable", flag.ExitOnError)
+	decks := fs.Int("decks", 4, "number of decks used")
+	s17 := fs.Bool("s17", false, "dealer stands on soft 17")
+	noDAS := fs.Bool("no-das", false, "no doubling after splits")
+	asCSV := fs.Bool("csv", false, "write the table as CSV, for a spreadsheet")
+	rules := presetFlags(fs)
+	printer := outputFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+
+	opts := blackjack.Options{Decks: *decks, StandSoft17: *s17, NoDoubleAfterSplit: *noDAS}
+	if err := rules(&opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	g := blackjack.New(opts)
+	r := g.Rules()
+	rows := [][]string{{"Hand", "Up", "Stand", "Hit", "Double", "Split", "Surrender", "Best"}}
+	for _, hand := range startingHands() {
+		for _, v := range []int{2, 3, 4, 5, 6, 7, 8, 9, 10, 1} {
+			rows = append(rows, evRow(r, hand, valueCard(v)))
+		}
+	}
+	if *asCSV {
+		w := csv.NewWriter(os.Stdout)
+		w.WriteAll(rows)
+		if err := w.Error(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	dealer := "H17"
+	if r.StandSoft17 {
+		dealer = "S17"
+	}
+	out.Printf("EV per unit bet of each move, %d decks, %s:\n", r.Decks, dealer)
+	out.Table(rows)
+}
+
+// startingHands returns the 55 two card hands by value: hard hands by total,
+// then the soft hands, then the pairs.
+func startingHands() [][]deck.Card {
+	var hard, soft, pairs [][]deck.Card
+	for lo := 1; lo <= 10; lo++ {
+		for hi := lo; hi <= 10; hi++ {
+			hand := []deck.Card{valueCard(hi), valueCard(lo)}
+			switch {
+			case lo == hi:
+				pairs = append(pairs, hand)
+			case lo == 1:
+				hand[0], hand[1] = hand[1], hand[0]
+				soft = append(soft, hand)
+			default:
+				hard = append(hard, hand)
+			}
+		}
+	}
+	sort.SliceStable(hard, func(i, j int) bool {
+		return blackjack.Score(hard[i]...) < blackjack.Score(hard[j]...)
+	})
+	pairs = append(pairs[1:], pairs[0]) // Aces last, as on the chart
+	return append(append(hard, soft...), pairs...)
+}
+
+// valueCard returns a card of the given value, 1 for an ace and a ten for 10.
+func valueCard(v int) deck.Card {
+	return deck.Card{Rank: deck.Rank(v), Suit: deck.Spade}
+}
+
+// evRow works out the EVs of hand against up, with the rest of a full shoe
+// left. A natural is worth the blackjack payout standing, pushing the dealer
+// blackjacks that haven't been peeked for.
+func evRow(r blackjack.Rules, hand []deck.Card, up deck.Card) []string {
+	shoe := evpkg.NewShoe(r).Remove(append(hand, up)...)
+	e := evpkg.Hand(r, shoe, hand, up, false)
+	if blackjack.Blackjack(hand...) {
+		e.Stand = r.BlackjackPayout * (1 - evpkg.Dealer(r, shoe, up).Blackjack)
+	}
+	best, _ := e.Best()
+	row := []string{rankString(hand), rankString([]deck.Card{up})}
+	for _, v := range []float64{e.Stand, e.Hit, e.Double, e.Split, e.Surrender} {
+		if math.IsNaN(v) {
+			row = append(row, "")
+			continue
+		}
+		row = append(row, fmt.Sprintf("%+.4f", v))
+	}
+	return append(row, best.String())
+}
+
+// rankString writes cards as their ranks, the way parseRanks reads them.
+func rankString(cards []deck.Card) string {
+	s := ""
+	for _, c := range cards {
+		s += string("A23456789TJQK"[c.Rank-1])
+	}
+	return s
+}
@@ -0,0 +1,229 @@
+// Package experiment keeps a record of simulation runs, their configuration,
+// the code they ran and what came out, so runs can be found and compared
+// later. The store is a plain file of JSON lines, one run per line.
+package experiment
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/stats"
+)
+
+// Run is one recorded simulation run.
+type Run struct {
+	ID       int               `json:"id"`       // Number of the run in its store, from 1
+	Time     time.Time         `json:"time"`     // When the run finished
+	Command  string            `json:"command"`  // Command that ran, e.g. "stats"
+	Config   map[string]string `json:"config"`   // Every setting of the run, by flag name
+	Hash     string            `json:"hash"`     // Hash of Config without the seed, the same for runs of the same experiment
+	Version  string            `json:"version"`  // Code the run was built from
+	Strategy string            `json:"strategy"` // Strategy played, e.g. "basic" or a chart file
+	Seed     int64             `json:"seed"`     // Seed of the shuffle, 0 if random
+	Summary  Summary           `json:"summary"`
+}
+
+// Summary is the headline figures of a run.
+type Summary struct {
+	Rounds  int64   `json:"rounds"`
+	Hands   int64   `json:"hands"`
+	Wagered int64   `json:"wagered"`
+	Net     int64   `json:"net"`
+	EV      float64 `json:"ev"`      // Average result per round
+	StdErr  float64 `json:"stderr"`  // Standard error of EV
+	StdDev  float64 `json:"stddev"`  // Standard deviation of a round's result
+	WinRate float64 `json:"winRate"` // Fraction of hands won
+}
+
+// Edge returns the result as a fraction of the amount wagered.
+func (s Summary) Edge() float64 {
+	return float64(s.Net) / float64(max(s.Wagered, 1))
+}
+
+// Summarize takes the headline figures from a run's statistics.
+func Summarize(s stats.Stats) Summary {
+	return Summary{
+		Rounds:  s.Rounds,
+		Hands:   s.Hands,
+		Wagered: s.Wagered,
+		Net:     s.Net,
+		EV:      s.EV(),
+		StdErr:  s.StdErr(),
+		StdDev:  s.StdDev(),
+		WinRate: float64(s.Wins) / float64(max(s.Hands, 1)),
+	}
+}
+
+// Hash returns a short hash of a configuration, leaving out the seed so that
+// runs differing only in their shoes share it.
+func Hash(config map[string]string) string {
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		if k != "seed" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%s\n", k, config[k])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))[:12]
+}
+
+// Version returns the version of the code running: the module version, or the
+// commit it was built from, marked dirty if it had uncommitted changes.
+func Version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	var rev, modified string
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			rev = s.Value
+		case "vcs.modified":
+			modified = s.Value
+		}
+	}
+	if rev == "" {
+		return info.Main.Version
+	}
+	if len(rev) > 12 {
+		rev = rev[:12]
+	}
+	if modified == "true" {
+		rev += "-dirty"
+	}
+	return rev
+}
+
+// Store is a file of recorded runs.
+type Store struct {
+	Path string
+}
+
+// Add records a run, numbering it and hashing its configuration, and returns
+// it as stored.
+func (s Store) Add(r Run) (Run, error) {
+	runs, err := s.Runs()
+	if err != nil {
+		return r, err
+	}
+	r.ID = len(runs) + 1
+	if len(runs) > 0 {
+		r.ID = runs[len(runs)-1].ID + 1
+	}
+	r.Hash = Hash(r.Config)
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return r, err
+	}
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return r, err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return r, err
+	}
+	return r, f.Close()
+}
+
+// Runs returns every run in the store, oldest first. A store that doesn't
+// exist yet has none.
+func (s Store) Runs() ([]Run, error) {
+	f, err := os.Open(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var runs []Run
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<20)
+	for line := 1; sc.Scan(); line++ {
+		if strings.TrimSpace(sc.Text()) == "" {
+			continue
+		}
+		var r Run
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", s.Path, line, err)
+		}
+		runs = append(runs, r)
+	}
+	return runs, sc.Err()
+}
+
+// Get returns a run by its number, or the latest run for "last".
+func (s Store) Get(id string) (Run, error) {
+	runs, err := s.Runs()
+	if err != nil {
+		return Run{}, err
+	}
+	if id == "last" && len(runs) > 0 {
+		return runs[len(runs)-1], nil
+	}
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return Run{}, fmt.Errorf("Run %q isn't a number", id)
+	}
+	for _, r := range runs {
+		if r.ID == n {
+			return r, nil
+		}
+	}
+	return Run{}, fmt.Errorf("No run %d in %s", n, s.Path)
+}
+
+// Change is a setting that differs between two runs.
+type Change struct {
+	Name string
+	A, B string // The setting in each run, empty if it wasn't there
+}
+
+// Changes returns the settings that differ between two runs, by name.
+func Changes(a, b Run) []Change {
+	var changes []Change
+	seen := map[string]bool{}
+	for _, config := range []map[string]string{a.Config, b.Config} {
+		for k := range config {
+			if !seen[k] && a.Config[k] != b.Config[k] {
+				changes = append(changes, Change{Name: k, A: a.Config[k], B: b.Config[k]})
+			}
+			seen[k] = true
+		}
+	}
+	for _, c := range []Change{{"version", a.Version, b.Version}, {"strategy", a.Strategy, b.Strategy}} {
+		if c.A != c.B {
+			changes = append(changes, c)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// Z returns how many standard errors apart the EVs of two runs are. Beyond 2
+// or so the difference is unlikely to be luck.
+func Z(a, b Run) float64 {
+	se := math.Hypot(a.Summary.StdErr, b.Summary.StdErr)
+	if se == 0 {
+		return 0
+	}
+	return (b.Summary.EV - a.Summary.EV) / se
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Scrimzay/blackjacksimulator/experiment"
+	"github.com/Scrimzay/blackjacksimulator/stats"
+)
+
+// untracked are the flags that change how a run is shown or profiled, not
+// what it measures, so they're left out of its recorded configuration.
+var untracked = map[string]bool{
+	"no-color":   true,
+	"accessible": true,
+	"lang":       true,
+	"cpuprofile": true,
+	"memprofile": true,
+	"examples":   true,
+	"situations": true,
+	"track":      true,
+}
+
+// trackRun records a finished run in the experiment store at path.
+func trackRun(path string, fs *flag.FlagSet, strategy string, seed int64, s stats.Stats) (experiment.Run, error) {
+	config := map[string]string{}
+	fs.VisitAll(func(f *flag.Flag) {
+		if !untracked[f.Name] {
+			config[f.Name] = f.Value.String()
+		}
+	})
+	return experiment.Store{Path: path}.Add(experiment.Run{
+		Command:  fs.Name(),
+		Config:   config,
+		Version:  experiment.Version(),
+		Strategy: strategy,
+		Seed:     seed,
+		Summary:  experiment.Summarize(s),
+	})
+}
+
+// experiments lists the runs recorded in an experiment store.
+func experiments(args []string) {
+	fs := flag.NewFlagSet("experiments", flag.ExitOnError)
+	store := fs.String("store", "experiments.jsonl", "experiment store, as written by stats -track")
+	printer := outputFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+
+	runs, err := experiment.Store{Path: *store}.Runs()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if len(runs) == 0 {
+		out.Printf("No runs in %s yet; record some with stats -track %s\n", *store, *store)
+		return
+	}
+	rows := [][]string{{"run", "time", "config", "version", "strategy", "seed", "rounds", "EV/round", "edge"}}
+	for _, r := range runs {
+		rows = append(rows, []string{
+			fmt.Sprint(r.ID),
+			r.Time.Format("2006-01-02 15:04"),
+			r.Hash,
+			r.Version,
+			r.Strategy,
+			fmt.Sprint(r.Seed),
+			fmt.Sprint(r.Summary.Rounds),
+			fmt.Sprintf("%+.3f ± %.3f", r.Summary.EV, 1.96*r.Summary.StdErr),
+			fmt.Sprintf("%+.3f%%", 100*r.Summary.Edge()),
+		})
+	}
+	out.Table(rows)
+}
+
+// compare shows what differs between two recorded runs, in their settings and
+// their results.
+func compare(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	store := fs.String("store", "experiments.jsonl", "experiment store, as written by stats -track")
+	printer := outputFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: compare [flags] RUN RUN (run numbers, or last)")
+		fs.PrintDefaults()
+	}
+	parseFlags(fs, args)
+	out := printer()
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	s := experiment.Store{Path: *store}
+	var runs [2]experiment.Run
+	for i := range runs {
+		r, err := s.Get(fs.Arg(i))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		runs[i] = r
+	}
+	a, b := runs[0], runs[1]
+
+	out.Printf("Run %d (%s) against run %d (%s)\n\n", a.ID, a.Time.Format("2006-01-02 15:04"), b.ID, b.Time.Format("2006-01-02 15:04"))
+	if changes := experiment.Changes(a, b); len(changes) == 0 {
+		out.Println("Same settings and code.")
+	} else {
+		rows := [][]string{{"setting", fmt.Sprint(a.ID), fmt.Sprint(b.ID)}}
+		for _, c := range changes {
+			rows = append(rows, []string{c.Name, c.A, c.B})
+		}
+		out.Table(rows)
+	}
+
+	out.Println()
+	sa, sb := a.Summary, b.Summary
+	rows := [][]string{
+		{"", fmt.Sprint(a.ID), fmt.Sprint(b.ID), "difference"},
+		{"Rounds:", fmt.Sprint(sa.Rounds), fmt.Sprint(sb.Rounds), fmt.Sprintf("%+d", sb.Rounds-sa.Rounds)},
+		{"Net:", out.Money(int(sa.Net)), out.Money(int(sb.Net)), out.Money(int(sb.Net - sa.Net))},
+		{"EV per round:", out.Average(sa.EV), out.Average(sb.EV), out.Average(sb.EV - sa.EV)},
+		{"Edge:", fmt.Sprintf("%+.3f%%", 100*sa.Edge()), fmt.Sprintf("%+.3f%%", 100*sb.Edge()), fmt.Sprintf("%+.3f%%", 100*(sb.Edge()-sa.Edge()))},
+		{"Std deviation:", fmt.Sprintf("%.2f", sa.StdDev), fmt.Sprintf("%.2f", sb.StdDev), fmt.Sprintf("%+.2f", sb.StdDev-sa.StdDev)},
+		{"Hands won:", fmt.Sprintf("%.2f%%", 100*sa.WinRate), fmt.Sprintf("%.2f%%", 100*sb.WinRate), fmt.Sprintf("%+.2f%%", 100*(sb.WinRate-sa.WinRate))},
+	}
+	out.Table(rows)
+	z := experiment.Z(a, b)
+	verdict := "within the noise"
+	if z > 2 || z < -2 {
+		verdict = "unlikely to be luck"
+	}
+	out.Printf("\nThe EVs are %.1f standard errors apart, %s.\n", z, verdict)
+}
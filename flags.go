@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// probing is set while the completion command collects every command's flags:
+// parseFlags then hands the flag set back in a probed panic instead of parsing
+// the arguments, so the command goes no further than defining its flags.
+var probing bool
+
+type probed struct{ fs *flag.FlagSet }
+
+// parseFlags parses a command's arguments and checks the flags given make
+// sense together, exiting with a message saying what to change if they don't.
+func parseFlags(fs *flag.FlagSet, args []string) {
+	if probing {
+		panic(probed{fs})
+	}
+	fs.Parse(args)
+	if err := checkFlags(fs); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
+
+// flagRule is a flag that only makes sense with another, or not with others.
+// The rule applies when the flag is given on the command line, whatever its
+// value.
+type flagRule struct {
+	command string   // Name of the command's flag set
+	flag    string   // Flag the rule is about
+	needs   string   // Flag that has to be given with it, if any
+	without []string // Flags that can't be given with it
+	message string   // What's wrong, and what to do about it
+}
+
+// flagRules are the combinations the commands won't take, rather than quietly
+// ignore one of the flags.
+var flagRules = []flagRule{
+	{command: "blackjack", flag: "resume", needs: "events", message: "-resume needs the -events file to carry on from"},
+	{command: "blackjack", flag: "show-burn", needs: "burn", message: "-show-burn turns the burned cards up, so it needs some burned, e.g. -burn 1"},
+	{command: "blackjack", flag: "seat-bankroll", needs: "seats", message: "-seat-bankroll is what each of the -seats brings, name the players with e.g. -seats Ann,Bob"},
+	{command: "blackjack", flag: "delay", needs: "demo", message: "-delay paces the steps of -demo, add -demo to see them"},
+	{command: "blackjack", flag: "quiz", needs: "train", message: "-quiz asks for the count while training, add -train to be asked"},
+	{command: "blackjack", flag: "show-ev", without: []string{"seats"}, message: "-show-ev guides a single player, it can't be combined with -seats"},
+	{command: "blackjack", flag: "show-odds", without: []string{"seats"}, message: "-show-odds guides a single player, it can't be combined with -seats"},
+	{command: "blackjack", flag: "learn", without: []string{"chart", "strategy"}, message: "-strategy, -chart and -learn each choose the AI, pick one of them"},
+	{command: "blackjack", flag: "chart", without: []string{"strategy"}, message: "-strategy, -chart and -learn each choose the AI, pick one of them"},
+	{command: "stats", flag: "strategy", without: []string{"cd", "exact", "chart"}, message: "-strategy, -cd, -exact and -chart each choose the strategy played, pick one of them"},
+	{command: "stats", flag: "cd", without: []string{"exact", "chart"}, message: "-strategy, -cd, -exact and -chart each choose the strategy played, pick one of them"},
+	{command: "stats", flag: "exact", without: []string{"chart"}, message: "-strategy, -cd, -exact and -chart each choose the strategy played, pick one of them"},
+	{command: "stats", flag: "export-tc", needs: "export", message: "-export-tc is the count at which -export saves the cards left, name the file with e.g. -export tc3.txt"},
+	{command: "stats", flag: "shoes", without: []string{"from", "bias"}, message: "-shoes deals whole shoes, it can't be combined with -from or -bias"},
+	{command: "stats", flag: "beginners", without: []string{"shoes"}, message: "-beginners deals the same shoes again from the seed, which -shoes can't do; leave one of them out"},
+	{command: "sessions", flag: "learn", without: []string{"strategy"}, message: "-strategy and -learn each choose the AI, pick one of them"},
+	{command: "sessions", flag: "rebuys", needs: "bankroll", message: "-rebuys buys back in for -bankroll, set how much with e.g. -bankroll 1000"},
+	{command: "sessions", flag: "double-for-less", needs: "bankroll", message: "-double-for-less doubles with what's left of -bankroll, set how much with e.g. -bankroll 1000"},
+	{command: "sessions", flag: "card-time", needs: "round-time", message: "-card-time adds to the time a round takes, set that too with e.g. -round-time 40s"},
+	{command: "sessions", flag: "shuffle-time", needs: "round-time", message: "-shuffle-time is only counted on the clock, start it with e.g. -round-time 40s"},
+	{command: "sessions", flag: "break-every", needs: "round-time", message: "-break-every is only counted on the clock, start it with e.g. -round-time 40s"},
+	{command: "sessions", flag: "break", needs: "break-every", message: "-break needs -break-every to say how often, e.g. -break-every 1h"},
+	{command: "sessions", flag: "round-time", without: []string{"rate"}, message: "-round-time sets the pace of play on the clock, leave out -rate"},
+}
+
+// checkFlags returns the message of the first rule the parsed flags break.
+func checkFlags(fs *flag.FlagSet) error {
+	given := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	for _, r := range flagRules {
+		if r.command != fs.Name() || !given[r.flag] {
+			continue
+		}
+		broken := r.needs != "" && !given[r.needs]
+		for _, name := range r.without {
+			broken = broken || given[name]
+		}
+		if broken {
+			return errors.New(r.message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Command gui plays blackjack in a window instead of the terminal. It lives in
+// its own module so that the simulator itself doesn't depend on a graphics
+// library; run it with go run ./gui from this directory.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/display"
+	"github.com/Scrimzay/blackjacksimulator/spectate"
+)
+
+func main() {
+	decks := flag.Int("decks", 6, "number of decks used")
+	seed := flag.Int64("seed", 0, "seed the shuffle to deal the same shoes every run (0 for random)")
+	bankroll := flag.Int("bankroll", 1000, "money brought to the table, 0 for no limit")
+	minBet := flag.Int("min", 10, "table minimum")
+	maxBet := flag.Int("max", 500, "table maximum, 0 for no limit")
+	lang := flag.String("lang", "", "language of the table (en, es), taken from $LANG if empty")
+	money := flag.String("money", "plain", "how to write amounts of money: plain, dollars, euros, pounds, or units (units:25 for units of 25)")
+	flag.Parse()
+
+	out := &display.Printer{Out: os.Stdout, Lang: display.LangFromEnv()}
+	if *lang != "" {
+		out.Lang = display.Lang(*lang)
+	}
+	c, err := display.ParseCurrency(*money)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	out.Currency = c
+
+	// The window draws what a spectator would see, so the hole card stays
+	// hidden until the dealer turns it over.
+	hub := &spectate.Hub{}
+	events, _ := hub.Subscribe()
+	game := blackjack.New(blackjack.Options{
+		Decks:           *decks,
+		BlackjackPayout: 1.5,
+		Seed:            *seed,
+		Bankroll:        *bankroll,
+		MinBet:          *minBet,
+		MaxBet:          *maxBet,
+		Events:          hub,
+	})
+	s := newSeat()
+	done := make(chan int, 1)
+	go func() {
+		done <- game.Play(s)
+	}()
+
+	ebiten.SetWindowSize(width, height)
+	ebiten.SetWindowTitle("Blackjack")
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	if err := ebiten.RunGame(newTable(out, events, s, done, *bankroll)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
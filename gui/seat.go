@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// seat is the AI the engine plays against: it hands every decision over to
+// the window and waits for the player to click.
+type seat struct {
+	bets   chan blackjack.BetContext // Bets the engine is waiting for
+	placed chan int                  // The player's bets
+	views  chan blackjack.GameView   // Decisions the engine is waiting for
+	moves  chan blackjack.Move       // The player's moves
+}
+
+func newSeat() *seat {
+	return &seat{
+		bets:   make(chan blackjack.BetContext),
+		placed: make(chan int),
+		views:  make(chan blackjack.GameView),
+		moves:  make(chan blackjack.Move),
+	}
+}
+
+// Bet waits for the player to place a bet.
+func (s *seat) Bet(ctx blackjack.BetContext) int {
+	s.bets <- ctx
+	return <-s.placed
+}
+
+// PlayView waits for the player to pick one of the moves the view allows.
+func (s *seat) PlayView(v blackjack.GameView) blackjack.Move {
+	s.views <- v
+	return <-s.moves
+}
+
+// Play is never called, since the seat is a Viewer.
+func (s *seat) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return blackjack.MoveStand
+}
+
+// Results is a no-op: the window draws the table from the game's events.
+func (s *seat) Results(hands [][]deck.Card, dealer []deck.Card) {}
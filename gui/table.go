@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font/gofont/goregular"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/display"
+)
+
+// Size of the window and of the things drawn in it, in pixels.
+const (
+	width      = 960
+	height     = 640
+	cardWidth  = 64
+	cardHeight = 90
+	cardGap    = 8
+)
+
+// Colors of the table.
+var (
+	felt      = color.RGBA{0x1b, 0x5e, 0x20, 0xff}
+	cardFace  = color.RGBA{0xfa, 0xfa, 0xfa, 0xff}
+	cardBack  = color.RGBA{0x8e, 0x24, 0x2a, 0xff}
+	redSuit   = color.RGBA{0xc6, 0x28, 0x28, 0xff}
+	blackSuit = color.RGBA{0x21, 0x21, 0x21, 0xff}
+	ink       = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	highlight = color.RGBA{0xff, 0xd5, 0x4f, 0xff}
+	disabled  = color.RGBA{0x55, 0x55, 0x55, 0xff}
+	button    = color.RGBA{0x0d, 0x47, 0xa1, 0xff}
+)
+
+// defaultChips are the chips offered when the table doesn't set any.
+var defaultChips = []int{5, 25, 100, 500}
+
+// hand is a seat's hand as the events have shown it.
+type hand struct {
+	cards   []deck.Card
+	bet     int
+	outcome blackjack.Outcome // Empty until it's settled
+	net     int               // What it won or lost, once settled
+}
+
+// table is the window: it draws the game from its events and turns clicks and
+// keys into the seat's decisions.
+type table struct {
+	out      *display.Printer // Words for the table, in the player's language
+	face     *text.GoTextFace
+	events   <-chan blackjack.Event
+	seat     *seat
+	done     <-chan int // Gets the winnings once the game is over
+	bankroll int        // Options.Bankroll, so the window shows money in hand
+
+	hands    []hand
+	dealer   []deck.Card
+	settled  bool // Whether the round on the table is over
+	balance  int  // Won or lost so far
+	bought   int  // Bought back in for
+	shuffled bool // Whether the shoe was shuffled for the round
+	over     bool // Whether the game is over
+
+	bet    *blackjack.BetContext // The bet the engine is waiting for, if any
+	amount int                   // Bet being put together
+	view   *blackjack.GameView   // The decision the engine is waiting for, if any
+
+	buttons []control // Controls drawn this frame
+}
+
+// noKey marks a control without a keyboard shortcut.
+const noKey ebiten.Key = -1
+
+// control is a button in the window, with its keyboard shortcut.
+type control struct {
+	label   string
+	key     ebiten.Key
+	x, y    float32
+	w, h    float32
+	enabled bool
+	press   func()
+}
+
+func newTable(out *display.Printer, events <-chan blackjack.Event, s *seat, done <-chan int, bankroll int) *table {
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+	if err != nil {
+		panic(err)
+	}
+	return &table{
+		out:      out,
+		face:     &text.GoTextFace{Source: src, Size: 18},
+		events:   events,
+		seat:     s,
+		done:     done,
+		bankroll: bankroll,
+	}
+}
+
+// Update catches up with the game, then takes the player's input.
+func (t *table) Update() error {
+	for caughtUp := false; !caughtUp; {
+		select {
+		case e := <-t.events:
+			t.apply(e)
+		default:
+			caughtUp = true
+		}
+	}
+	select {
+	case ctx := <-t.seat.bets:
+		t.bet = &ctx
+		t.amount = min(max(t.amount, ctx.MinBet), max(ctx.MaxBet, ctx.MinBet))
+	case v := <-t.seat.views:
+		t.view = &v
+	case <-t.done:
+		t.over = true
+	default:
+	}
+	t.layout()
+	x, y := ebiten.CursorPosition()
+	click := inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft)
+	for _, c := range t.buttons {
+		if !c.enabled {
+			continue
+		}
+		hit := click && float32(x) >= c.x && float32(x) < c.x+c.w && float32(y) >= c.y && float32(y) < c.y+c.h
+		if hit || (c.key != noKey && inpututil.IsKeyJustPressed(c.key)) {
+			c.press()
+			break
+		}
+	}
+	return nil
+}
+
+// apply updates the table with an event.
+func (t *table) apply(e blackjack.Event) {
+	switch e.Kind {
+	case blackjack.EventShuffle:
+		t.shuffled = true
+	case blackjack.EventBet:
+		t.hands = []hand{{bet: e.Amount}}
+		t.dealer = nil
+		t.settled = false
+	case blackjack.EventCard:
+		if e.Hand == blackjack.DealerHand {
+			t.dealer = append(t.dealer, *e.Card)
+		} else if e.Hand < len(t.hands) {
+			t.hands[e.Hand].cards = append(t.hands[e.Hand].cards, *e.Card)
+		}
+	case blackjack.EventMove:
+		if e.Hand == blackjack.DealerHand || e.Hand >= len(t.hands) {
+			break
+		}
+		h := &t.hands[e.Hand]
+		switch e.Move {
+		case "double":
+			if e.Amount > 0 {
+				h.bet += e.Amount
+			} else {
+				h.bet *= 2 // Logs from before doubling for less
+			}
+		case "split":
+			second := h.cards[1]
+			h.cards = h.cards[:1]
+			t.hands = slices.Insert(t.hands, e.Hand+1, hand{cards: []deck.Card{second}, bet: h.bet})
+		}
+	case blackjack.EventPayout:
+		if e.Hand < len(t.hands) {
+			t.hands[e.Hand].outcome, t.hands[e.Hand].net = e.Outcome, e.Amount
+		}
+	case blackjack.EventEnd:
+		t.balance += e.Amount
+		t.settled = true
+		t.shuffled = false
+	case blackjack.EventRebuy:
+		t.bought += e.Amount
+	}
+}
+
+// layout works out which controls the player has.
+func (t *table) layout() {
+	t.buttons = t.buttons[:0]
+	x := float32(40)
+	add := func(label string, key ebiten.Key, enabled bool, press func()) {
+		w, _ := text.Measure(label, t.face, 0)
+		c := control{label: label, key: key, x: x, y: height - 70, w: float32(w) + 28, h: 40, enabled: enabled, press: press}
+		t.buttons = append(t.buttons, c)
+		x += c.w + 12
+	}
+	switch {
+	case t.bet != nil:
+		ctx := *t.bet
+		chips := ctx.Chips
+		if len(chips) == 0 {
+			chips = defaultChips
+		}
+		for _, c := range chips {
+			add("+"+t.out.Amount(c), noKey, true, func() { t.amount += c })
+		}
+		add(t.out.T(display.MsgClearBet), ebiten.KeyBackspace, true, func() { t.amount = 0 })
+		add(t.out.T(display.MsgDeal, t.out.Amount(t.amount)), ebiten.KeyEnter, ctx.Check(t.amount) == nil, func() {
+			t.bet = nil
+			t.seat.placed <- t.amount
+		})
+	case t.view != nil:
+		v := *t.view
+		move := func(m blackjack.Move) func() {
+			return func() {
+				t.view = nil
+				t.seat.moves <- m
+			}
+		}
+		add(t.out.Move(blackjack.MoveHit), ebiten.KeyH, v.CanHit, move(blackjack.MoveHit))
+		add(t.out.Move(blackjack.MoveStand), ebiten.KeyS, true, move(blackjack.MoveStand))
+		add(t.out.Move(blackjack.MoveDouble), ebiten.KeyD, v.CanDouble, move(blackjack.MoveDouble))
+		add(t.out.Move(blackjack.MoveSplit), ebiten.KeyP, v.CanSplit, move(blackjack.MoveSplit))
+		add(t.out.Move(blackjack.MoveSurrender), ebiten.KeyR, v.CanSurrender, move(blackjack.MoveSurrender))
+	}
+}
+
+// Draw draws the table.
+func (t *table) Draw(screen *ebiten.Image) {
+	screen.Fill(felt)
+
+	t.print(screen, t.out.T(display.MsgDealer), 40, 30, ink)
+	hidden := len(t.dealer) == 1 && !t.settled
+	if !hidden && len(t.dealer) > 0 {
+		t.print(screen, t.out.Total(t.dealer), 140, 30, ink)
+	}
+	for i, c := range t.dealer {
+		t.card(screen, c, 40+float32(i)*(cardWidth+cardGap), 60)
+	}
+	if hidden {
+		t.back(screen, 40+cardWidth+cardGap, 60)
+	}
+
+	x := float32(40)
+	for i, h := range t.hands {
+		y := float32(300)
+		clr := ink
+		if t.view != nil && t.view.HandIndex == i {
+			clr = highlight
+		}
+		t.print(screen, fmt.Sprintf("%s  %d", t.out.T(display.MsgPlayerHand, i+1), h.bet), x, y-30, clr)
+		for j, c := range h.cards {
+			t.card(screen, c, x+float32(j)*(cardWidth/2), y+float32(j)*12)
+		}
+		below := y + cardHeight + float32(len(h.cards))*12 + 10
+		if len(h.cards) > 0 {
+			t.print(screen, t.out.Total(h.cards), x, below, clr)
+		}
+		if h.outcome != "" {
+			t.print(screen, fmt.Sprintf("%s  %s", t.out.Outcome(blackjack.HandResult{Outcome: h.outcome}), t.out.Money(h.net)), x, below+24, clr)
+		}
+		x += cardWidth + float32(max(len(h.cards)-1, 0))*(cardWidth/2) + 60
+	}
+
+	money := fmt.Sprintf("%s %s", t.out.T(display.MsgWinnings), t.out.Money(t.balance))
+	if t.bankroll > 0 {
+		money += "   " + t.out.T(display.MsgBankroll, t.out.Amount(t.bankroll+t.bought+t.balance))
+	}
+	t.print(screen, money, 40, height-110, ink)
+	switch {
+	case t.over:
+		t.print(screen, t.out.T(display.MsgGameOver), 600, height-110, highlight)
+	case t.shuffled && t.bet != nil:
+		t.print(screen, t.out.T(display.MsgShuffled), 600, height-110, highlight)
+	}
+
+	for _, c := range t.buttons {
+		fill := button
+		if !c.enabled {
+			fill = disabled
+		}
+		vector.FillRect(screen, c.x, c.y, c.w, c.h, fill, true)
+		t.print(screen, c.label, c.x+14, c.y+10, ink)
+	}
+}
+
+// card draws a card face up.
+func (t *table) card(screen *ebiten.Image, c deck.Card, x, y float32) {
+	vector.FillRect(screen, x, y, cardWidth, cardHeight, cardFace, true)
+	vector.StrokeRect(screen, x, y, cardWidth, cardHeight, 1, blackSuit, true)
+	clr := blackSuit
+	if c.Suit == deck.Heart || c.Suit == deck.Diamond {
+		clr = redSuit
+	}
+	t.print(screen, t.out.Card(c), x+6, y+4, clr)
+}
+
+// back draws a card face down.
+func (t *table) back(screen *ebiten.Image, x, y float32) {
+	vector.FillRect(screen, x, y, cardWidth, cardHeight, cardBack, true)
+	vector.StrokeRect(screen, x+4, y+4, cardWidth-8, cardHeight-8, 2, cardFace, true)
+}
+
+// print draws text with its top left corner at x, y.
+func (t *table) print(screen *ebiten.Image, s string, x, y float32, clr color.Color) {
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorScale.ScaleWithColor(clr)
+	text.Draw(screen, s, t.face, op)
+}
+
+// Layout keeps the table the same size however big the window is.
+func (t *table) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return width, height
+}
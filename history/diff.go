@@ -0,0 +1,63 @@
+package history
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Divergence is the first point where two histories stop agreeing.
+type Divergence struct {
+	Step   int   // Index of the decision where they diverge
+	A, B   *Step // The decision in each history, nil if that history ended first
+	Dealt  bool  // Whether the cards differ, rather than only the move made on them
+	Rounds int   // Number of rounds both histories have, for context
+}
+
+// Diff compares two histories decision by decision and returns where they
+// first diverge, or nil if every decision matches. Histories played from the
+// same seed deal the same cards until a decision differs, so a divergence in
+// the cards dealt before any differing move points at nondeterminism, while a
+// different move on the same cards points at the strategy.
+func Diff(a, b []blackjack.RoundResult) *Divergence {
+	sa, sb := allSteps(a), allSteps(b)
+	for i := 0; i < len(sa) || i < len(sb); i++ {
+		d := &Divergence{Step: i, Rounds: min(len(a), len(b))}
+		if i < len(sa) {
+			d.A = &sa[i]
+		}
+		if i < len(sb) {
+			d.B = &sb[i]
+		}
+		if d.A == nil || d.B == nil {
+			return d
+		}
+		if !sameCards(d.A.Cards, d.B.Cards) || d.A.Dealer != d.B.Dealer || d.A.Round != d.B.Round || d.A.Hand != d.B.Hand {
+			d.Dealt = true
+			return d
+		}
+		if d.A.Move != d.B.Move {
+			return d
+		}
+	}
+	return nil
+}
+
+func allSteps(rounds []blackjack.RoundResult) []Step {
+	var steps []Step
+	for _, r := range rounds {
+		steps = append(steps, Steps(r)...)
+	}
+	return steps
+}
+
+func sameCards(a, b []deck.Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,281 @@
+// Package history writes and reads rounds in a compact text notation, one
+// round per line, so that hand histories can be grepped and diffed as easily as
+// they are parsed back.
+//
+// A round looks like
+//
+//   - B100 P:AS,7D v T H:4C S =loss | D:TD,9H | -100
+//
+// The optional leading * marks the first round of a new shoe. B gives the bet,
+// B0 for a round the seat sat out. Each hand follows as P: with the cards it
+// started with, the dealer's upcard rank after v, and its moves: H, D, S, P and
+// R for hit, double, stand, split and surrender, with the card drawn after a
+// colon; a split draws the second card of the hand being played. After the
+// moves, = gives the hand's outcome (blackjack.Outcome), if known. Hands split
+// off later are listed after the first, separated by semicolons, starting with
+// the two cards they were played from. Then come the dealer's final cards and the net result. Cards are
+// written as a rank (A, 2-9, T, J, Q, K) followed by a suit (S, D, C, H).
+//
+// Per-hand bets and results are not part of the notation, so rounds read back
+// carry only the total.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+const (
+	ranks = "A23456789TJQK"
+	suits = "SDCH"
+)
+
+var moves = map[string]string{
+	"hit":       "H",
+	"stand":     "S",
+	"double":    "D",
+	"split":     "P",
+	"surrender": "R",
+}
+
+// Format writes r as a single line of notation, without a trailing newline.
+func Format(r blackjack.RoundResult) string {
+	var b bytes.Buffer
+	write(&b, r)
+	return b.String()
+}
+
+// write appends the notation of r to b.
+func write(b *bytes.Buffer, r blackjack.RoundResult) {
+	if r.Shuffled {
+		b.WriteString("* ")
+	}
+	b.WriteString("B")
+	b.Write(strconv.AppendInt(b.AvailableBuffer(), int64(r.Bet), 10))
+	up := "?"
+	if len(r.Dealer) > 0 {
+		up = ranks[r.Dealer[0].Rank-1 : r.Dealer[0].Rank]
+	}
+	for i, h := range r.Hands {
+		if i > 0 {
+			b.WriteString(" ;")
+		}
+		b.WriteString(" P:")
+		writeCards(b, h.Start)
+		b.WriteString(" v ")
+		b.WriteString(up)
+		for _, a := range h.Actions {
+			m, ok := moves[a.Move]
+			if !ok {
+				m = "?"
+			}
+			b.WriteString(" " + m)
+			if a.Drew() {
+				b.WriteString(":")
+				writeCard(b, a.Card)
+			}
+		}
+		if h.Outcome != "" {
+			b.WriteString(" =")
+			b.WriteString(string(h.Outcome))
+		}
+	}
+	b.WriteString(" | D:")
+	writeCards(b, r.Dealer)
+	b.WriteString(" | ")
+	if r.Net > 0 {
+		b.WriteString("+")
+	}
+	b.Write(strconv.AppendInt(b.AvailableBuffer(), int64(r.Net), 10))
+}
+
+// Parse reads a line written by Format. Round is left at zero.
+func Parse(s string) (blackjack.RoundResult, error) {
+	var r blackjack.RoundResult
+	parts := strings.Split(strings.TrimSpace(s), "|")
+	if len(parts) != 3 {
+		return r, fmt.Errorf("Expected 3 sections separated by |, got %d", len(parts))
+	}
+
+	fields := strings.Fields(parts[0])
+	if len(fields) > 0 && fields[0] == "*" {
+		r.Shuffled = true
+		fields = fields[1:]
+	}
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "B") {
+		return r, errors.New("Missing bet")
+	}
+	bet, err := strconv.Atoi(fields[0][1:])
+	if err != nil {
+		return r, fmt.Errorf("Invalid bet %q", fields[0])
+	}
+	r.Bet = bet
+	r.Seated = bet > 0
+
+	var h *blackjack.HandResult
+	for i := 1; i < len(fields); i++ {
+		f := fields[i]
+		switch {
+		case f == ";":
+			h = nil
+		case strings.HasPrefix(f, "P:") && i+1 < len(fields) && fields[i+1] == "v":
+			cards, err := ParseCards(f[2:])
+			if err != nil {
+				return r, err
+			}
+			r.Hands = append(r.Hands, blackjack.HandResult{Start: cards, Cards: append([]deck.Card(nil), cards...)})
+			h = &r.Hands[len(r.Hands)-1]
+		case f == "v":
+			i++ // The upcard is repeated in the dealer's cards
+		case strings.HasPrefix(f, "="):
+			if h == nil || !slices.Contains(blackjack.Outcomes, blackjack.Outcome(f[1:])) {
+				return r, fmt.Errorf("Invalid outcome %q", f)
+			}
+			h.Outcome = blackjack.Outcome(f[1:])
+		default:
+			if h == nil {
+				return r, fmt.Errorf("Move %q before any hand", f)
+			}
+			a, err := parseAction(f)
+			if err != nil {
+				return r, err
+			}
+			if a.Move == "split" && len(h.Cards) > 1 {
+				h.Cards = h.Cards[:1]
+			}
+			if a.Drew() {
+				h.Cards = append(h.Cards, a.Card)
+			}
+			h.Actions = append(h.Actions, a)
+		}
+	}
+	if len(r.Hands) == 0 {
+		return r, errors.New("Missing player hand")
+	}
+
+	dealer := strings.TrimSpace(parts[1])
+	if !strings.HasPrefix(dealer, "D:") {
+		return r, fmt.Errorf("Invalid dealer cards %q", dealer)
+	}
+	if r.Dealer, err = ParseCards(dealer[2:]); err != nil {
+		return r, err
+	}
+
+	net, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(parts[2]), "+"))
+	if err != nil {
+		return r, fmt.Errorf("Invalid result %q", strings.TrimSpace(parts[2]))
+	}
+	r.Net = net
+	return r, nil
+}
+
+// Writer returns a function for Options.OnRound that writes every round to w,
+// one per line. It formats into the same buffer every time, so writing a
+// history adds no garbage to a long simulation.
+func Writer(w io.Writer) func(blackjack.RoundResult) {
+	var b bytes.Buffer
+	return func(r blackjack.RoundResult) {
+		b.Reset()
+		write(&b, r)
+		b.WriteByte('\n')
+		w.Write(b.Bytes())
+	}
+}
+
+// Read parses every round in r, numbering them from 1. Blank lines and lines
+// starting with # are skipped.
+func Read(r io.Reader) ([]blackjack.RoundResult, error) {
+	var rounds []blackjack.RoundResult
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		s := strings.TrimSpace(sc.Text())
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+		round, err := Parse(s)
+		if err != nil {
+			return rounds, fmt.Errorf("Line %d: %v", line, err)
+		}
+		round.Round = len(rounds) + 1
+		rounds = append(rounds, round)
+	}
+	return rounds, sc.Err()
+}
+
+func writeCard(b *bytes.Buffer, c deck.Card) {
+	b.WriteByte(ranks[c.Rank-1])
+	b.WriteByte(suits[c.Suit])
+}
+
+func writeCards(b *bytes.Buffer, cards []deck.Card) {
+	for i, c := range cards {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeCard(b, c)
+	}
+}
+
+// FormatCards writes cards in the notation, separated by commas, e.g. "AS,7D".
+func FormatCards(cards []deck.Card) string {
+	var b bytes.Buffer
+	writeCards(&b, cards)
+	return b.String()
+}
+
+func parseCard(s string) (deck.Card, error) {
+	if len(s) != 2 {
+		return deck.Card{}, fmt.Errorf("Invalid card %q", s)
+	}
+	r, su := strings.IndexByte(ranks, s[0]), strings.IndexByte(suits, s[1])
+	if r < 0 || su < 0 {
+		return deck.Card{}, fmt.Errorf("Invalid card %q", s)
+	}
+	return deck.Card{Rank: deck.Rank(r + 1), Suit: deck.Suit(su)}, nil
+}
+
+// ParseCards reads cards written by FormatCards.
+func ParseCards(s string) ([]deck.Card, error) {
+	var cards []deck.Card
+	for _, f := range strings.Split(s, ",") {
+		c, err := parseCard(strings.TrimSpace(f))
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
+
+func parseAction(s string) (blackjack.Action, error) {
+	code, card, drew := strings.Cut(s, ":")
+	var a blackjack.Action
+	for name, c := range moves {
+		if c == code {
+			a.Move = name
+		}
+	}
+	if code == "?" {
+		a.Move = "custom move"
+	}
+	if a.Move == "" {
+		return a, fmt.Errorf("Invalid move %q", s)
+	}
+	if drew {
+		c, err := parseCard(card)
+		if err != nil {
+			return a, err
+		}
+		a.Card = c
+	}
+	return a, nil
+}
@@ -0,0 +1,157 @@
+package history
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Step is one decision made during a round, with the cards it was made on.
+type Step struct {
+	Round  int         // Number of the round
+	Hand   int         // Index of the hand in the round
+	Cards  []deck.Card // Cards in the hand when the decision was made
+	Dealer deck.Card   // Dealer's upcard
+	Move   string      // Move made
+}
+
+// String describes the step in the history notation, e.g.
+// "round 12 hand 1: TD,6S v T stand".
+func (s Step) String() string {
+	up := "?"
+	if s.Dealer.Rank != 0 {
+		up = string(ranks[s.Dealer.Rank-1])
+	}
+	return fmt.Sprintf("round %d hand %d: %s v %s %s", s.Round, s.Hand+1, FormatCards(s.Cards), up, s.Move)
+}
+
+// Steps replays the actions of every hand in r from its starting cards.
+func Steps(r blackjack.RoundResult) []Step {
+	if len(r.Dealer) == 0 {
+		return nil
+	}
+	var steps []Step
+	for i, h := range r.Hands {
+		cards := append([]deck.Card(nil), h.Start...)
+		for _, a := range h.Actions {
+			steps = append(steps, Step{
+				Round:  r.Round,
+				Hand:   i,
+				Cards:  append([]deck.Card(nil), cards...),
+				Dealer: r.Dealer[0],
+				Move:   a.Move,
+			})
+			if a.Move == "split" && len(cards) > 1 {
+				cards = cards[:1]
+			}
+			if a.Drew() {
+				cards = append(cards, a.Card)
+			}
+		}
+	}
+	return steps
+}
+
+// FromEvents turns an event log into rounds, so that event logs can be
+// reviewed, diffed or written as text histories. A round the log stops in
+// the middle of is left out.
+func FromEvents(events []blackjack.Event) ([]blackjack.RoundResult, error) {
+	var (
+		rounds   []blackjack.RoundResult
+		r        blackjack.RoundResult
+		shuffled bool
+		left     int // Cards left in the shoe, once a shuffle has shown it
+	)
+	for _, e := range events {
+		switch e.Kind {
+		case blackjack.EventBurn, blackjack.EventJoker:
+			r.Drawn++
+			left--
+		case blackjack.EventDealerError:
+			if e.Error == blackjack.DealerOverdraw {
+				r.Drawn++
+				left--
+			}
+		case blackjack.EventShuffle:
+			shuffled = true
+			left = len(e.Cards)
+		case blackjack.EventBet:
+			r = blackjack.RoundResult{
+				Round:    e.Round,
+				Shuffled: shuffled,
+				Seated:   e.Amount > 0,
+				Bet:      e.Amount,
+				Hands:    []blackjack.HandResult{{Bet: e.Amount}},
+			}
+			shuffled = false
+		case blackjack.EventCard:
+			if e.Card == nil {
+				return rounds, fmt.Errorf("Event %d: card missing", e.Seq)
+			}
+			r.Drawn++
+			left--
+			if e.Hand == blackjack.DealerHand {
+				r.Dealer = append(r.Dealer, *e.Card)
+				continue
+			}
+			if e.Hand < 0 || e.Hand >= len(r.Hands) {
+				return rounds, fmt.Errorf("Event %d: no hand %d", e.Seq, e.Hand)
+			}
+			h := &r.Hands[e.Hand]
+			h.Cards = append(h.Cards, *e.Card)
+			if n := len(h.Actions); n > 0 {
+				h.Actions[n-1].Card = *e.Card
+			} else {
+				h.Start = append(h.Start, *e.Card)
+			}
+		case blackjack.EventMove:
+			if e.Hand == blackjack.DealerHand {
+				continue
+			}
+			if e.Hand < 0 || e.Hand >= len(r.Hands) {
+				return rounds, fmt.Errorf("Event %d: no hand %d", e.Seq, e.Hand)
+			}
+			h := &r.Hands[e.Hand]
+			h.Actions = append(h.Actions, blackjack.Action{Move: e.Move})
+			if e.Move == "double" {
+				h.Bet += doubled(e, h.Bet)
+			}
+			if e.Move == "split" && len(h.Cards) == 2 {
+				split := h.Cards[1]
+				h.Cards = h.Cards[:1:1]
+				r.Hands = slices.Insert(r.Hands, e.Hand+1, blackjack.HandResult{
+					Start: []deck.Card{split},
+					Cards: []deck.Card{split},
+					Bet:   r.Bet,
+				})
+			}
+		case blackjack.EventInsurance:
+			r.Insurance = e.Amount
+		case blackjack.EventPayout:
+			if e.Hand >= 0 && e.Hand < len(r.Hands) {
+				r.Hands[e.Hand].Net = e.Amount
+				r.Hands[e.Hand].Outcome = e.Outcome
+			}
+		case blackjack.EventEnd:
+			r.Net = e.Amount
+			if r.Insurance > 0 {
+				r.InsuranceNet = blackjack.InsurancePays(r.Insurance, r.Dealer)
+			}
+			r.Left = max(left, 0)
+			rounds = append(rounds, r)
+			r = blackjack.RoundResult{}
+		}
+	}
+	return rounds, nil
+}
+
+// doubled returns what a double event added to a hand bet bet: its amount,
+// or the whole bet in logs from before doubling for less.
+func doubled(e blackjack.Event, bet int) int {
+	if e.Amount > 0 {
+		return e.Amount
+	}
+	return bet
+}
@@ -1,118 +1,364 @@
 package main
 
 import (
+	"bufio"
+	"flag"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
-	"github.com/Scrimzay/blackjacksimulator/ai"
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/count"
 	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/display"
+	"github.com/Scrimzay/blackjacksimulator/history"
+	"github.com/Scrimzay/blackjacksimulator/record"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
 )
 
-// basicAI represents a simple card-counting AI that adjusts bets and decisions 
-// based on the number of high/low cards seen in the game.
-type basicAI struct {
-	score int // Running count of the card values seen
-	seen  int // Number of cards seen so far
-	decks int // Number of decks in play
+// commands maps subcommand names to their entry points. Without a subcommand
+// the simulator plays a single long game.
+var commands = map[string]func(args []string){
+	"sessions":    sessions,
+	"ev":          ev,
+	"tail":        tail,
+	"shuffletest": shuffletest,
+	"review":      reviewHistory,
+	"chart":       chart,
+	"diff":        diff,
+	"scenario":    runScenarios,
+	"rate":        rate,
+	"stats":       report,
+	"bench":       bench,
+	"shoes":       shoes,
+	"replay":      replay,
+	"telegram":    telegramBot,
+	"systems":     systems,
+	"experiments": experiments,
+	"compare":     compare,
+	"tells":       tells,
+	"validate":    validateRules,
+	"cluster":     clusterRun,
+	"repl":        repl,
+	"trip":        trip,
+	"clumping":    clumping,
+	"sequencing":  sequencing,
+	"strategies":  strategies,
+	"evtable":     evTable,
 }
 
-// Bet calculates the betting amount based on the true count (score adjusted for unseen cards).
-// If the deck is shuffled, it resets the counting variables.
-func (bi *basicAI) Bet(shuffled bool) int {
-	if shuffled {
-		bi.score = 0
-		bi.seen = 0
-	}
-	// Calculate the true count: running count divided by the number of remaining decks
-	trueScore := bi.score / ((bi.decks*52 - bi.seen) / 52)
+func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			cmd(os.Args[2:])
+			return
+		}
+	}
+	play(os.Args[1:])
+}
 
-	// Adjust bet size based on the true count value
-	switch {
-	case trueScore >= 14:
-		return 100000 // Very high confidence in a favorable deck
-	case trueScore >= 8:
-		return 5000   // Medium confidence
-	default:
-		return 100    // Default minimal bet
+// outputFlags registers the flags shared by every command that prints to the
+// terminal and returns a function building the Printer once they're parsed.
+func outputFlags(fs *flag.FlagSet) func() *display.Printer {
+	noColor := fs.Bool("no-color", false, "disable colored output")
+	accessible := fs.Bool("accessible", false, "screen-reader friendly output: full card names and sentences")
+	lang := fs.String("lang", "", "language of the interactive text (en, es), taken from $LANG if empty")
+	money := fs.String("money", "plain", "how to write amounts of money: plain, dollars, euros, pounds, or units (units:25 for units of 25)")
+	return func() *display.Printer {
+		out := display.Stdout()
+		if *noColor {
+			out.Color = false
+		}
+		out.Verbose = *accessible
+		if *lang != "" {
+			out.Lang = display.Lang(*lang)
+		}
+		c, err := display.ParseCurrency(*money)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		out.Currency = c
+		return out
 	}
 }
 
-// Play determines the AI's move based on basic blackjack strategy and card counting.
-func (bi *basicAI) Play(hand []deck.Card, dealer deck.Card) ai.Move {
-	score := ai.Score(hand...)
+// play runs a single game with the basic AI, or with the user at the keyboard.
+func play(args []string) {
+	fs := flag.NewFlagSet("blackjack", flag.ExitOnError)
+	decks := fs.Int("decks", 4, "number of decks used")
+	hands := fs.Int("hands", 999999, "number of hands to simulate")
+	interactive := fs.Bool("interactive", false, "play the hands yourself instead of the basic AI")
+	train := fs.Bool("train", false, "play the hands yourself and get a report on your decisions and counting at the end")
+	whatIf := fs.Bool("what-if", false, "play the hands yourself and, after a round where you left basic strategy, see how it would have gone had you followed it")
+	rewind := fs.Bool("review", false, "play the hands yourself, then replay any round to see how the other moves would have turned out")
+	quiz := fs.Int("quiz", 5, "with -train, ask for the Hi-Lo count every this many rounds (0 for never)")
+	seats := fs.String("seats", "", "names of the players taking turns at the keyboard, e.g. Ann,Bob (implies -interactive)")
+	seatBankroll := fs.Int("seat-bankroll", 0, "money each of the -seats brings to the table, 0 for no limit")
+	demo := fs.Bool("demo", false, "print every deal and decision, for presentations")
+	delay := fs.Duration("delay", 0, "pause after each step in demo mode, e.g. 1s")
+	chartFile := fs.String("chart", "", "play a strategy chart exported by the chart command instead of the basic AI")
+	learn := fs.Float64("learn", 0, "play an AI that learns as it goes instead of the basic AI, trying a random move this often, e.g. 0.1")
+	state := fs.String("state", "", "load the AI's learned state from this file before playing, and save it back after")
+	ramp := fs.Int("ramp", 0, "bet one unit per point of Hi-Lo true count, up to this many units (the engine keeps the count)")
+	showCount := fs.Bool("show-count", false, "show the Hi-Lo running and true count before each decision (with -interactive)")
+	showOdds := fs.Bool("show-odds", false, "show how often standing on your hand wins, pushes and loses against the upcard before each decision (implies -interactive)")
+	showEV := fs.String("show-ev", "", "show the expected value of each move from the cards left, before or after you choose (implies -interactive)")
+	seed := fs.Int64("seed", 0, "seed the shuffle to deal the same shoes every run (0 for random)")
+	eventsFile := fs.String("events", "", "record every state transition to this file, one JSON event per line")
+	resume := fs.Bool("resume", false, "carry on the game recorded in the -events file instead of starting a new one")
+	spectateAddr := fs.String("spectate", "", "let spectators follow the game over WebSocket at this address, e.g. :8080")
+	spectateTo := fs.String("spectate-to", "", "stream the game's events to this file or terminal as it's played, e.g. /dev/pts/3")
+	recordFile := fs.String("record", "", "record the session, with its timing, to this file for the replay command")
+	historyFile := fs.String("history", "", "write every round to this file in hand history notation")
+	drill := fs.String("drill", "", "deal the same starting cards every round, e.g. \"TS,6H v TD\" for 16 against a ten")
+	increment := fs.Int("increment", 0, "bets must be a multiple of this, e.g. 5")
+	chips := fs.String("chips", "", "chip denominations bets are made up of, e.g. 5,25,100")
+	burn := fs.Int("burn", 0, "cards burned at the start of each shoe")
+	showBurn := fs.Bool("show-burn", false, "turn the burned cards face up, so they can be counted")
+	exposedHole := fs.Float64("exposed-hole", 0, "chance per round the dealer flashes the hole card, e.g. 0.002")
+	overdraw := fs.Float64("overdraw", 0, "chance per round the dealer draws a card too many, e.g. 0.001")
+	strategyName := fs.String("strategy", "classic", "AI to play the hands, one of those the strategies command lists")
+	audit := fs.Bool("audit", false, "check the AI only ever gets copies of the game's cards, stopping if it could change them (slow)")
+	rules := presetFlags(fs)
+	printer := outputFlags(fs)
+	profile := profileFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+	defer profile()()
+	if *recordFile != "" {
+		f, err := os.Create(*recordFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		rec := record.New(f, strings.Join(append([]string{"blackjack"}, args...), " "))
+		out.Out, out.In = rec.Output(out.Out), rec.Input(out.Input())
+	}
 
-	// If the player has two cards
-	if len(hand) == 2 {
-		// Check for pair splitting strategy
-		if hand[0] == hand[1] {
-			cardScore := ai.Score(hand[0])
-			if cardScore >= 8 && cardScore != 10 {
-				return ai.MoveSplit // Split pairs if the value is favorable
+	// Define game options
+	opts := blackjack.Options{
+		Decks:           *decks, // Number of decks used
+		Hands:           *hands, // Number of hands to simulate
+		BlackjackPayout: 1.5,    // Standard blackjack payout ratio
+		MinBet:          100,    // Table minimum
+	}
+	if err := rules(&opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	opts.Seed = *seed
+	opts.BetIncrement = *increment
+	opts.Burn, opts.ShowBurn = *burn, *showBurn
+	opts.DealerErrors = blackjack.DealerErrors{ExposedHole: *exposedHole, Overdraw: *overdraw}
+	opts.Audit = *audit
+	if *chips != "" {
+		c, err := parseChips(*chips)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		opts.Chips = c
+	}
+	if *showCount || *train {
+		opts.Count = count.HiLo
+	}
+	if *showOdds {
+		*interactive = true
+		opts.Composition = true
+	}
+	switch *showEV {
+	case "":
+	case "before", "after":
+		*interactive = true
+		opts.Composition = true
+	default:
+		fmt.Fprintf(os.Stderr, "-show-ev %q: expected before or after\n", *showEV)
+		os.Exit(2)
+	}
+	var trained []blackjack.RoundResult
+	if *train {
+		*interactive = true
+		opts.OnRound = func(r blackjack.RoundResult) { trained = append(trained, r) }
+	}
+	if *ramp > 0 {
+		opts.Count = count.HiLo
+		opts.Bettor = strategy.Ramp{Spread: *ramp}
+	}
+	if *historyFile != "" {
+		f, err := os.Create(*historyFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w := bufio.NewWriter(f)
+		defer w.Flush()
+		write, keep := history.Writer(w), opts.OnRound
+		opts.OnRound = write
+		if keep != nil {
+			opts.OnRound = func(r blackjack.RoundResult) {
+				keep(r)
+				write(r)
 			}
 		}
+	}
 
-		// Double down strategy for hands with a total of 10 or 11 (excluding soft hands)
-		if score == 10 || (score == 11 && !ai.Soft(hand...)) {
-			return ai.MoveDouble
+	var past []blackjack.Event
+	if *eventsFile != "" {
+		f, events, err := openEvents(*eventsFile, *resume)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
 		}
+		defer f.Close()
+		past = events
+		opts.Events = blackjack.JSONEvents(f)
 	}
-
-	// Dealer strategy influences the decision
-	dScore := ai.Score(dealer)
-	if dScore >= 5 && dScore <= 6 {
-		return ai.MoveStand // Favorable situation, stand
+	if *spectateAddr != "" || *spectateTo != "" {
+		hub, done, err := startSpectators(*spectateAddr, *spectateTo)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer done()
+		if opts.Events != nil {
+			opts.Events = blackjack.Tee(opts.Events, hub)
+		} else {
+			opts.Events = hub
+		}
 	}
 
-	// If the player's score is low, hit
-	if score < 13 {
-		return ai.MoveHit
+	named, err := strategy.Lookup(*strategyName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
+	named.Options(&opts)
 
-	// Otherwise, stand
-	return ai.MoveStand
-}
+	// Create and run the game simulation using the chosen strategy
+	game := blackjack.New(opts)
+	if *resume {
+		if game, err = blackjack.Rebuild(opts, past); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	player := named.New(strategy.Setup{Decks: opts.Decks, Out: out})
+	if *chartFile != "" {
+		c, err := loadChart(*chartFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		player = strategy.ChartAI(c)
+	}
+	if *learn > 0 {
+		player = strategy.LearnerAI(*learn)
+	}
+	learner := player // Before any wrapping, which hides the Saver
+	if *state != "" {
+		if err := blackjack.LoadState(learner, *state); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	var counts strategy.CountScore
+	var moments []strategy.Moment
+	if *whatIf || *rewind {
+		*interactive = true
+	}
+	if *interactive {
+		player = strategy.HumanAIWith(out)
+		if *train && !*showCount {
+			player = strategy.CountQuiz(player, out, *quiz, &counts)
+		}
+		if *whatIf {
+			player = strategy.Coach(player, out)
+		}
+		if *rewind {
+			player = strategy.Rewind(player, &moments)
+		}
+		if *showEV != "" {
+			player = strategy.EVGuide(player, out, *showEV == "after")
+		}
+		if *showOdds {
+			player = strategy.StandOdds(player, out)
+		}
+	}
+	var table []*strategy.Seat
+	if *seats != "" {
+		if *seatBankroll > 0 && *seatBankroll < opts.MinBet {
+			fmt.Fprintf(os.Stderr, "-seat-bankroll %d doesn't cover the table minimum of %d\n", *seatBankroll, opts.MinBet)
+			os.Exit(2)
+		}
+		for _, name := range strings.Split(*seats, ",") {
+			table = append(table, &strategy.Seat{Name: strings.TrimSpace(name), Bankroll: *seatBankroll})
+		}
+		player = strategy.HotSeat(out, table)
+	}
+	if *demo {
+		player = strategy.Demo(player, out, *delay)
+	}
+	if *drill != "" {
+		cards, up, err := parseDrill(*drill)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		player = strategy.Drill(player, cards, up)
+	}
+	winnings := game.Play(player)
+	if *state != "" {
+		if err := blackjack.SaveState(learner, *state); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
 
-// Results processes the final hands of the round and updates the card count.
-func (bi *basicAI) Results(hands [][]deck.Card, dealer []deck.Card) {
-	// Count the dealer's cards
-	for _, card := range dealer {
-		bi.count(card)
+	// Print the total winnings from the simulation
+	out.Println(out.T(display.MsgWinnings), out.Money(winnings))
+	if *train {
+		trainingReport(out, trained, counts, opts.Decks)
+	}
+	if *rewind {
+		whatIfReview(out, moments)
 	}
-	// Count all player hands
-	for _, hand := range hands {
-		for _, card := range hand {
-			bi.count(card)
+	if len(table) > 0 {
+		rows := make([][]string, 0, len(table))
+		for _, s := range table {
+			rows = append(rows, []string{s.Name, out.Money(s.Balance)})
 		}
+		out.Table(rows)
 	}
 }
 
-// count updates the running card count based on the value of a given card.
-// - High-value cards (10, J, Q, K, A) decrease the count
-// - Low-value cards (2-6) increase the count
-func (bi *basicAI) count(card deck.Card) {
-	score := ai.Score(card)
-	switch {
-	case score >= 10:
-		bi.score-- // High-value cards are bad for the player
-	case score <= 6:
-		bi.score++ // Low-value cards are good for the player
-	}
-	bi.seen++ // Increment the total number of seen cards
+// parseDrill reads the -drill flag: the player's cards, "v" and the dealer's.
+func parseDrill(s string) (player, dealer []deck.Card, err error) {
+	p, d, ok := strings.Cut(s, " v ")
+	if !ok {
+		return nil, nil, fmt.Errorf("-drill %q: expected player cards v dealer cards", s)
+	}
+	if player, err = history.ParseCards(strings.TrimSpace(p)); err != nil {
+		return nil, nil, err
+	}
+	if dealer, err = history.ParseCards(strings.TrimSpace(d)); err != nil {
+		return nil, nil, err
+	}
+	return player, dealer, nil
 }
 
-func main() {
-	// Define game options
-	opts := ai.Options{
-		Decks:          4,       // Number of decks used
-		Hands:          999999,  // Number of hands to simulate
-		BlackjackPayout: 1.5,    // Standard blackjack payout ratio
+// parseChips reads the -chips flag: denominations separated by commas.
+func parseChips(s string) ([]int, error) {
+	var chips []int
+	for _, f := range strings.Split(s, ",") {
+		c, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil || c <= 0 {
+			return nil, fmt.Errorf("-chips %q: %q isn't a chip denomination", s, f)
+		}
+		chips = append(chips, c)
 	}
-
-	// Create and run the game simulation using the basicAI strategy
-	game := ai.New(opts)
-	winnings := game.Play(&basicAI{
-		decks: 4, // Initialize AI with 4 decks
-	})
-
-	// Print the total winnings from the simulation
-	fmt.Println(winnings)
-}
\ No newline at end of file
+	return chips, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+)
+
+// presetFlags registers the -preset, -penetration, -no-hole-card and -jokers
+// flags and returns a function applying them to a command's options once
+// they're parsed.
+// A preset sets the table's rules and decks, though an explicit -decks wins.
+func presetFlags(fs *flag.FlagSet) func(opts *blackjack.Options) error {
+	var names []string
+	for name := range blackjack.Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	preset := fs.String("preset", "", "start from the usual rules for a table: "+strings.Join(names, ", "))
+	penetration := fs.Float64("penetration", 0, "fraction of the shoe dealt before it's reshuffled, e.g. 0.75 (by the number of decks if 0)")
+	noHole := fs.Bool("no-hole-card", false, "deal the dealer's second card after the players act, European style, instead of a hole card")
+	surrender := fs.Bool("surrender", false, "late surrender: a hand's first two cards may be given up for half the bet")
+	jokers := fs.Int("jokers", 0, "home rule: shuffle this many jokers into the shoe, each paying the bet when it's dealt to you")
+	return func(opts *blackjack.Options) error {
+		opts.NoHoleCard = opts.NoHoleCard || *noHole
+		opts.Surrender = opts.Surrender || *surrender
+		if *jokers < 0 {
+			return fmt.Errorf("Can't shuffle %d jokers into the shoe", *jokers)
+		}
+		opts.Jokers = max(opts.Jokers, *jokers)
+		if *preset != "" {
+			p, ok := blackjack.Presets[*preset]
+			if !ok {
+				return fmt.Errorf("Unknown preset %q, try one of: %s", *preset, strings.Join(names, ", "))
+			}
+			decks := opts.Decks
+			opts.Decks = p.Decks
+			opts.BlackjackPayout = p.BlackjackPayout
+			opts.StandSoft17 = p.StandSoft17
+			opts.DoubleOn = p.DoubleOn
+			opts.NoDoubleAfterSplit = p.NoDoubleAfterSplit
+			opts.SplitHands = p.SplitHands
+			opts.Penetration = p.Penetration
+			fs.Visit(func(f *flag.Flag) {
+				if f.Name == "decks" {
+					opts.Decks = decks
+				}
+			})
+		}
+		if *penetration != 0 {
+			if *penetration < 0 || *penetration >= 1 {
+				return fmt.Errorf("Penetration must be a fraction of the shoe, not %g", *penetration)
+			}
+			opts.Penetration = *penetration
+		}
+		return nil
+	}
+}
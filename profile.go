@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// profileFlags registers -cpuprofile and -memprofile for the commands that run
+// simulations. The returned function starts profiling once the flags are
+// parsed and returns the function that stops it and writes the profiles:
+//
+//	defer profile()()
+func profileFlags(fs *flag.FlagSet) func() func() {
+	cpu := fs.String("cpuprofile", "", "write a CPU profile to this file, for go tool pprof")
+	mem := fs.String("memprofile", "", "write an allocation profile to this file when the run ends, for go tool pprof")
+	return func() func() {
+		var cpuFile *os.File
+		if *cpu != "" {
+			f, err := os.Create(*cpu)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			if err := pprof.StartCPUProfile(f); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			cpuFile = f
+		}
+		return func() {
+			if cpuFile != nil {
+				pprof.StopCPUProfile()
+				cpuFile.Close()
+			}
+			if *mem != "" {
+				f, err := os.Create(*mem)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					return
+				}
+				defer f.Close()
+				runtime.GC() // Up to date statistics
+				if err := pprof.Lookup("allocs").WriteTo(f, 0); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+				}
+			}
+		}
+	}
+}
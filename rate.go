@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/rating"
+	"github.com/Scrimzay/blackjacksimulator/sim"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// rate plays every pair of strategies against each other on common shoes and
+// updates their ratings, kept in a file from one run to the next.
+func rate(args []string) {
+	flags := flag.NewFlagSet("rate", flag.ExitOnError)
+	decks := flags.Int("decks", 6, "number of decks used")
+	shoes := flags.Int("shoes", 500, "number of shoes per match")
+	file := flags.String("ratings", "ratings.json", "file the ratings are read from and saved to")
+	printer := outputFlags(flags)
+	profile := profileFlags(flags)
+	parseFlags(flags, args)
+	out := printer()
+	defer profile()()
+	if flags.NArg() < 2 {
+		fmt.Fprintf(os.Stderr, "usage: rate [flags] strategy strategy... (built-in: %s, or chart files)\n", strings.Join(strategy.Names(), ", "))
+		os.Exit(2)
+	}
+
+	opts := blackjack.Options{Decks: *decks}
+	players := map[string]func() blackjack.AI{}
+	for _, name := range flags.Args() {
+		newAI, err := ratedAI(name, &opts)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		players[name] = newAI
+	}
+	ratings, err := loadRatings(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	names := flags.Args()
+	for i, a := range names {
+		for _, b := range names[i+1:] {
+			m := sim.HeadToHead(opts, players[a], players[b], *shoes)
+			before := ratings.Get(a)
+			ratings.Update(a, b, m.Score())
+			out.Printf("%s vs %s: %d-%d-%d, %s %+.1f\n", a, b, m.Wins, m.Losses, m.Draws, a, ratings.Get(a)-before)
+		}
+	}
+
+	out.Println()
+	var rows [][]string
+	for i, e := range ratings.Ranking() {
+		rows = append(rows, []string{fmt.Sprintf("%d.", i+1), e.Name, fmt.Sprintf("%.0f", e.Rating)})
+	}
+	out.Table(rows)
+
+	f, err := os.Create(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+	if err := ratings.Write(f); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// ratedAI returns a constructor for a registered strategy or a chart file,
+// setting up the matches' options for it.
+func ratedAI(name string, opts *blackjack.Options) (func() blackjack.AI, error) {
+	if named, err := strategy.Lookup(name); err == nil {
+		named.Options(opts)
+		return func() blackjack.AI { return named.New(strategy.Setup{Decks: opts.Decks}) }, nil
+	}
+	c, err := loadChart(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither a built-in strategy nor a chart file: %v", name, err)
+	}
+	return func() blackjack.AI { return strategy.ChartAI(c) }, nil
+}
+
+// loadRatings reads the ratings file, starting afresh if there isn't one.
+func loadRatings(name string) (rating.Ratings, error) {
+	f, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return rating.Ratings{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := rating.Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return r, nil
+}
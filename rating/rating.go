@@ -0,0 +1,78 @@
+// Package rating keeps Elo ratings for strategies across head-to-head
+// matches, so a long-term ranking builds up out of many tournaments rather
+// than any single one deciding it.
+package rating
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+)
+
+const (
+	Initial = 1500 // Rating of a strategy that hasn't played yet
+	K       = 32   // Largest change a single match can make
+)
+
+// Ratings maps strategy names to their rating.
+type Ratings map[string]float64
+
+// Entry is a strategy's place in the ranking.
+type Entry struct {
+	Name   string
+	Rating float64
+}
+
+// Get returns the strategy's rating, Initial if it hasn't played.
+func (r Ratings) Get(name string) float64 {
+	if rating, ok := r[name]; ok {
+		return rating
+	}
+	return Initial
+}
+
+// Expected returns the score a is expected to take off b, from 0 to 1.
+func (r Ratings) Expected(a, b string) float64 {
+	return 1 / (1 + math.Pow(10, (r.Get(b)-r.Get(a))/400))
+}
+
+// Update rates a match between a and b in which a took score (1 for a win,
+// 0.5 for a draw, 0 for a loss, or anything between for a match of many
+// games, e.g. sim.Match.Score).
+func (r Ratings) Update(a, b string, score float64) {
+	change := K * (score - r.Expected(a, b))
+	r[a] = r.Get(a) + change
+	r[b] = r.Get(b) - change
+}
+
+// Ranking returns the strategies from best to worst rated.
+func (r Ratings) Ranking() []Entry {
+	entries := make([]Entry, 0, len(r))
+	for name, rating := range r {
+		entries = append(entries, Entry{name, rating})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Rating != entries[j].Rating {
+			return entries[i].Rating > entries[j].Rating
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// Write saves the ratings as JSON.
+func (r Ratings) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Read loads ratings saved by Write.
+func Read(rd io.Reader) (Ratings, error) {
+	r := Ratings{}
+	if err := json.NewDecoder(rd).Decode(&r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
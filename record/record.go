@@ -0,0 +1,194 @@
+// Package record records interactive sessions with their timing and plays them
+// back, for sharing interesting sessions and bug reports.
+//
+// Recordings use asciinema's cast format (version 2), so they can also be
+// played with asciinema or embedded in a web page with its player. The first
+// line is a JSON header; every following line is an event: a JSON array of the
+// seconds since the start, "o" for output or "i" for input, and the text.
+//
+//	{"version":2,"width":80,"height":24,"timestamp":1700000000,"title":"blackjack"}
+//	[0.012,"o","What would you like to bet?\n"]
+//	[2.5,"i","100\n"]
+package record
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Header describes a recording.
+type Header struct {
+	Version   int    `json:"version"`         // Always 2
+	Width     int    `json:"width"`           // Terminal columns
+	Height    int    `json:"height"`          // Terminal rows
+	Timestamp int64  `json:"timestamp"`       // When recording started, in Unix seconds
+	Title     string `json:"title,omitempty"` // What was recorded, e.g. the command line
+}
+
+// Frame is a piece of text written or typed during a session.
+type Frame struct {
+	Time  time.Duration // Since the start of the recording
+	Input bool          // Whether the player typed it, rather than it being shown
+	Text  string        // What was written or typed
+}
+
+// MarshalJSON writes the frame as a cast event.
+func (f Frame) MarshalJSON() ([]byte, error) {
+	kind := "o"
+	if f.Input {
+		kind = "i"
+	}
+	return json.Marshal([]interface{}{f.Time.Seconds(), kind, f.Text})
+}
+
+// UnmarshalJSON reads a cast event.
+func (f *Frame) UnmarshalJSON(b []byte) error {
+	var (
+		seconds float64
+		kind    string
+	)
+	if err := json.Unmarshal(b, &[]interface{}{&seconds, &kind, &f.Text}); err != nil {
+		return err
+	}
+	if kind != "o" && kind != "i" {
+		return fmt.Errorf("Unknown event type %q", kind)
+	}
+	f.Time = time.Duration(seconds * float64(time.Second))
+	f.Input = kind == "i"
+	return nil
+}
+
+// Recorder writes a session to a cast file as it happens.
+type Recorder struct {
+	mu    sync.Mutex
+	enc   *json.Encoder
+	start time.Time
+	err   error // First error writing the recording, after which it stops
+}
+
+// New starts a recording on w, writing its header.
+func New(w io.Writer, title string) *Recorder {
+	r := &Recorder{enc: json.NewEncoder(w), start: time.Now()}
+	r.err = r.enc.Encode(Header{Version: 2, Width: 80, Height: 24, Timestamp: r.start.Unix(), Title: title})
+	return r
+}
+
+// record writes a frame of text, timed from the start.
+func (r *Recorder) record(text []byte, input bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.err = r.enc.Encode(Frame{Time: time.Since(r.start), Input: input, Text: string(text)})
+}
+
+// Output returns a writer passing everything written on to w and recording it
+// as output.
+func (r *Recorder) Output(w io.Writer) io.Writer {
+	return recordWriter{r: r, w: w}
+}
+
+// Input returns a reader recording everything read from rd as input, a line
+// at a time.
+func (r *Recorder) Input(rd io.Reader) io.Reader {
+	return &recordReader{r: r, rd: rd}
+}
+
+type recordWriter struct {
+	r *Recorder
+	w io.Writer
+}
+
+func (w recordWriter) Write(b []byte) (int, error) {
+	w.r.record(b, false)
+	return w.w.Write(b)
+}
+
+type recordReader struct {
+	r    *Recorder
+	rd   io.Reader
+	line []byte // Typed so far, since fmt reads a byte at a time
+}
+
+func (rd *recordReader) Read(b []byte) (int, error) {
+	n, err := rd.rd.Read(b)
+	rd.line = append(rd.line, b[:n]...)
+	if i := bytes.LastIndexByte(rd.line, '\n'); i >= 0 {
+		rd.r.record(rd.line[:i+1], true)
+		rd.line = append(rd.line[:0], rd.line[i+1:]...)
+	}
+	if err != nil && len(rd.line) > 0 {
+		rd.r.record(rd.line, true) // A last line without a newline
+		rd.line = rd.line[:0]
+	}
+	return n, err
+}
+
+// Read reads a recording written by a Recorder, or by asciinema.
+func Read(r io.Reader) (Header, []Frame, error) {
+	var h Header
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	if !sc.Scan() {
+		if sc.Err() != nil {
+			return h, nil, sc.Err()
+		}
+		return h, nil, fmt.Errorf("Empty recording")
+	}
+	if err := json.Unmarshal(sc.Bytes(), &h); err != nil {
+		return h, nil, fmt.Errorf("Header: %v", err)
+	}
+	if h.Version != 2 {
+		return h, nil, fmt.Errorf("Unsupported recording version %d", h.Version)
+	}
+	var frames []Frame
+	for line := 2; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var f Frame
+		if err := json.Unmarshal(sc.Bytes(), &f); err != nil {
+			return h, frames, fmt.Errorf("Line %d: %v", line, err)
+		}
+		frames = append(frames, f)
+	}
+	return h, frames, sc.Err()
+}
+
+// Player plays a recording back.
+type Player struct {
+	Speed   float64       // How many times faster than recorded, 1 if 0
+	MaxIdle time.Duration // Longest pause between frames, however long the player thought; no limit if 0
+	Input   bool          // Whether to show what was typed too, as a terminal echoes it
+}
+
+// Play writes the frames to w with the recorded timing, adjusted by the
+// player's settings.
+func (p Player) Play(w io.Writer, frames []Frame) error {
+	speed := p.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+	var last time.Duration
+	for _, f := range frames {
+		if f.Input && !p.Input {
+			continue
+		}
+		wait := f.Time - last
+		if p.MaxIdle > 0 && wait > p.MaxIdle {
+			wait = p.MaxIdle
+		}
+		time.Sleep(time.Duration(float64(wait) / speed))
+		last = f.Time
+		if _, err := io.WriteString(w, f.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/count"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/display"
+	evpkg "github.com/Scrimzay/blackjacksimulator/ev"
+	"github.com/Scrimzay/blackjacksimulator/stats"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// session is the state of the repl: the rules set so far and how to simulate.
+type session struct {
+	out   *display.Printer
+	opts  blackjack.Options
+	hands int   // Rounds a sim plays unless told otherwise
+	seed  int64 // Seed of every sim, random if 0
+	ramp  int   // Spread of the hi-lo bet ramp, flat bets if 0
+}
+
+// replCommand is a command of the repl.
+type replCommand struct {
+	name, args, help string
+	run              func(s *session, args []string) error
+}
+
+var replCommands []replCommand
+
+func init() {
+	// Set here rather than in the declaration, since help refers to the list
+	replCommands = []replCommand{
+		{"rules", "", "show the rules and settings", (*session).showRules},
+		{"set", "key value", "change a rule or setting: " + strings.Join(settingNames(), ", "), (*session).set},
+		{"sim", "[rounds]", "play basic strategy under the rules and show the EV", (*session).sim},
+		{"chart", "[hard|soft|pairs]", "show the basic strategy chart for the rules", (*session).chart},
+		{"ev", "hand upcard", "show the exact EV of every move, e.g. ev T6 T or ev A7 9", (*session).ev},
+		{"help", "", "list the commands", (*session).help},
+		{"quit", "", "leave (so does end of input)", nil},
+	}
+}
+
+// repl reads commands from the terminal until quit, so rules can be changed
+// and tried one step at a time without starting over.
+func repl(args []string) {
+	fs := flag.NewFlagSet("repl", flag.ExitOnError)
+	decks := fs.Int("decks", 6, "number of decks to start with")
+	printer := outputFlags(fs)
+	parseFlags(fs, args)
+	s := &session{out: printer(), opts: blackjack.Options{Decks: *decks}, hands: 100000}
+
+	s.out.Println(`Blackjack repl, "help" lists the commands.`)
+	in := bufio.NewScanner(s.out.Input())
+	for {
+		fmt.Fprint(s.out.Out, "> ")
+		if !in.Scan() {
+			s.out.Println()
+			return
+		}
+		fields := strings.Fields(in.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "quit" || fields[0] == "exit" {
+			return
+		}
+		if err := s.do(fields[0], fields[1:]); err != nil {
+			s.out.Println(err)
+		}
+	}
+}
+
+// do runs a command, turning the engine's panics over bad options into errors
+// so a typo doesn't end the session.
+func (s *session) do(name string, args []string) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("%v", p)
+		}
+	}()
+	for _, c := range replCommands {
+		if c.name == name && c.run != nil {
+			return c.run(s, args)
+		}
+	}
+	return fmt.Errorf("Unknown command %q, try help", name)
+}
+
+func (s *session) help(args []string) error {
+	var rows [][]string
+	for _, c := range replCommands {
+		rows = append(rows, []string{c.name + " " + c.args, c.help})
+	}
+	s.out.Table(rows)
+	return nil
+}
+
+// rules returns the rules as the engine fills them in.
+func (s *session) rules() blackjack.Rules {
+	g := blackjack.New(s.opts)
+	return g.Rules()
+}
+
+func (s *session) showRules(args []string) error {
+	r := s.rules()
+	onOff := map[bool]string{true: "on", false: "off"}
+	variant := "classic"
+	if r.Variant == blackjack.SuperFun21 {
+		variant = "superfun21"
+	}
+	pen := s.opts.Penetration
+	if pen == 0 {
+		pen = blackjack.DefaultPenetration(r.Decks)
+	}
+	seed := "random"
+	if s.seed != 0 {
+		seed = fmt.Sprint(s.seed)
+	}
+	ramp := "flat bets"
+	if s.ramp > 0 {
+		ramp = fmt.Sprintf("1-%d hi-lo ramp", s.ramp)
+	}
+	s.out.Table([][]string{
+		{"decks", fmt.Sprint(r.Decks)},
+		{"variant", variant},
+		{"s17", onOff[r.StandSoft17]},
+		{"das", onOff[r.DoubleAfterSplit]},
+		{"surrender", onOff[r.Surrender]},
+		{"payout", fmt.Sprintf("%g", r.BlackjackPayout)},
+		{"penetration", fmt.Sprintf("%.3g", pen)},
+		{"hands", fmt.Sprint(s.hands)},
+		{"seed", seed},
+		{"ramp", ramp},
+	})
+	return nil
+}
+
+// settings are what set can change, by name.
+var settings = map[string]func(s *session, v string) error{
+	"decks": func(s *session, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return fmt.Errorf("Can't play with %s decks", v)
+		}
+		s.opts.Decks = n
+		return nil
+	},
+	"preset": func(s *session, v string) error {
+		p, ok := blackjack.Presets[v]
+		if !ok {
+			return fmt.Errorf("Unknown preset %q", v)
+		}
+		s.opts = p
+		return nil
+	},
+	"variant": func(s *session, v string) error {
+		switch v {
+		case "classic":
+			s.opts.Variant = blackjack.Classic
+		case "superfun21":
+			s.opts.Variant = blackjack.SuperFun21
+		default:
+			return fmt.Errorf("Unknown variant %q, try classic or superfun21", v)
+		}
+		s.opts.BlackjackPayout = 0 // Back to the variant's own
+		return nil
+	},
+	"s17": func(s *session, v string) (err error) {
+		s.opts.StandSoft17, err = parseOnOff(v)
+		return err
+	},
+	"das": func(s *session, v string) error {
+		das, err := parseOnOff(v)
+		s.opts.NoDoubleAfterSplit = !das
+		return err
+	},
+	"surrender": func(s *session, v string) (err error) {
+		s.opts.Surrender, err = parseOnOff(v)
+		return err
+	},
+	"payout": func(s *session, v string) error {
+		p, err := parsePayout(v)
+		s.opts.BlackjackPayout = p
+		return err
+	},
+	"penetration": func(s *session, v string) error {
+		p, err := strconv.ParseFloat(v, 64)
+		if err != nil || p <= 0 || p >= 1 {
+			return fmt.Errorf("Penetration must be a fraction of the shoe, not %s", v)
+		}
+		s.opts.Penetration = p
+		return nil
+	},
+	"hands": func(s *session, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return fmt.Errorf("Can't simulate %s rounds", v)
+		}
+		s.hands = n
+		return nil
+	},
+	"seed": func(s *session, v string) (err error) {
+		s.seed, err = strconv.ParseInt(v, 10, 64)
+		return err
+	},
+	"ramp": func(s *session, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return fmt.Errorf("Invalid spread %q, 0 for flat bets", v)
+		}
+		s.ramp = n
+		return nil
+	},
+}
+
+func settingNames() []string {
+	var names []string
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseOnOff reads a setting switched on or off.
+func parseOnOff(v string) (bool, error) {
+	switch v {
+	case "on", "yes", "true":
+		return true, nil
+	case "off", "no", "false":
+		return false, nil
+	}
+	return false, fmt.Errorf("Expected on or off, not %q", v)
+}
+
+// parsePayout reads what a natural pays, as a ratio like 6:5 or a number.
+func parsePayout(v string) (float64, error) {
+	if a, b, ok := strings.Cut(v, ":"); ok {
+		x, errA := strconv.ParseFloat(a, 64)
+		y, errB := strconv.ParseFloat(b, 64)
+		if errA != nil || errB != nil || !(x > 0 && y > 0) {
+			return 0, fmt.Errorf("Invalid payout %q", v)
+		}
+		return x / y, nil
+	}
+	p, err := strconv.ParseFloat(v, 64)
+	if err != nil || !(p > 0) {
+		return 0, fmt.Errorf("Invalid payout %q", v)
+	}
+	return p, nil
+}
+
+func (s *session) set(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: set key value")
+	}
+	f, ok := settings[args[0]]
+	if !ok {
+		return fmt.Errorf("Unknown setting %q, try one of: %s", args[0], strings.Join(settingNames(), ", "))
+	}
+	before := *s
+	if err := f(s, args[1]); err != nil {
+		*s = before
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			*s = before // Keep the last rules the engine took
+			panic(p)
+		}
+	}()
+	s.rules() // Catch options the engine won't take now rather than at the next sim
+	return nil
+}
+
+func (s *session) sim(args []string) error {
+	hands := s.hands
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("Can't simulate %s rounds", args[0])
+		}
+		hands = n
+	}
+	var st stats.Stats
+	opts := s.opts
+	opts.Hands, opts.Seed, opts.OnRound, opts.Reuse = hands, s.seed, st.Add, true
+	if s.ramp > 0 {
+		opts.Count, opts.Bettor = count.HiLo, strategy.Ramp{Spread: s.ramp}
+	}
+	g := blackjack.New(opts)
+	g.Play(strategy.BasicStrategyAI())
+	s.out.Table([][]string{
+		{"Rounds:", fmt.Sprint(st.Rounds)},
+		{"EV per round:", fmt.Sprintf("%s ± %s", s.out.Average(st.EV()), s.out.Average(1.96*st.StdErr()))},
+		{"Edge:", fmt.Sprintf("%+.2f%% of the bet", 100*float64(st.Net)/float64(max(st.Wagered, 1)))},
+	})
+	return nil
+}
+
+func (s *session) chart(args []string) error {
+	c := strategy.ChartFor(s.rules())
+	tables := []struct {
+		name string
+		rows map[int]string
+	}{{"hard", c.Hard}, {"soft", c.Soft}, {"pairs", c.Pairs}}
+	for _, t := range tables {
+		if len(args) > 0 && args[0] != t.name {
+			continue
+		}
+		rows := [][]string{append([]string{t.name}, strings.Split("2 3 4 5 6 7 8 9 T A", " ")...)}
+		var totals []int
+		for total := range t.rows {
+			totals = append(totals, total)
+		}
+		sort.Ints(totals)
+		for _, total := range totals {
+			rows = append(rows, append([]string{fmt.Sprint(total)}, strings.Split(t.rows[total], "")...))
+		}
+		s.out.Table(rows)
+		s.out.Println()
+	}
+	return nil
+}
+
+func (s *session) ev(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: ev hand upcard, e.g. ev T6 T")
+	}
+	hand, err := parseRanks(args[0])
+	if err != nil {
+		return err
+	}
+	up, err := parseRanks(args[1])
+	if err != nil {
+		return err
+	}
+	if len(hand) < 2 || len(up) != 1 {
+		return fmt.Errorf("Expected a hand of two or more cards and one upcard, e.g. ev T6 T")
+	}
+	r := s.rules()
+	shoe := evpkg.NewShoe(r).Remove(append(hand, up[0])...)
+	e := evpkg.Hand(r, shoe, hand, up[0], false)
+	best, _ := e.Best()
+	var rows [][]string
+	for _, m := range []struct {
+		move blackjack.Move
+		ev   float64
+	}{
+		{blackjack.MoveStand, e.Stand},
+		{blackjack.MoveHit, e.Hit},
+		{blackjack.MoveDouble, e.Double},
+		{blackjack.MoveSplit, e.Split},
+		{blackjack.MoveSurrender, e.Surrender},
+	} {
+		if math.IsNaN(m.ev) {
+			continue
+		}
+		mark := ""
+		if m.move.String() == best.String() {
+			mark = "best"
+		}
+		rows = append(rows, []string{m.move.String(), fmt.Sprintf("%+.4f", m.ev), mark})
+	}
+	s.out.Printf("%s against %s, per unit bet:\n", s.out.Hand(hand), s.out.Card(up[0]))
+	s.out.Table(rows)
+	return nil
+}
+
+// parseRanks reads cards written as ranks only, e.g. "A7" or "T", giving them
+// suits in turn.
+func parseRanks(v string) ([]deck.Card, error) {
+	var cards []deck.Card
+	for i, c := range strings.ToUpper(v) {
+		r := strings.IndexRune("A23456789TJQK", c)
+		if r < 0 {
+			return nil, fmt.Errorf("Invalid card %q in %q, expected ranks like A, 7 or T", c, v)
+		}
+		cards = append(cards, deck.Card{Rank: deck.Rank(r + 1), Suit: deck.Suit(i % 4)})
+	}
+	return cards, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Scrimzay/blackjacksimulator/record"
+)
+
+// replay plays back a session recorded with -record.
+func replay(args []string) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	speed := fs.Float64("speed", 1, "playback speed, e.g. 2 for twice as fast")
+	idle := fs.Duration("idle", 0, "longest pause between steps, e.g. 2s to skip long thinks (0 for as recorded)")
+	input := fs.Bool("input", true, "show what the player typed")
+	parseFlags(fs, args)
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: replay [flags] recording")
+		os.Exit(2)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+	_, frames, err := record.Read(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", fs.Arg(0), err)
+		os.Exit(1)
+	}
+	p := record.Player{Speed: *speed, MaxIdle: *idle, Input: *input}
+	if err := p.Play(os.Stdout, frames); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
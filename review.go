@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Scrimzay/blackjacksimulator/analysis"
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/review"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// reviewHistory checks the decisions in a hand history or event log against basic
+// strategy and prints the player's leaks, and if the bets vary, tests them for
+// knowing cards they were placed before.
+func reviewHistory(args []string) {
+	fs := flag.NewFlagSet("review", flag.ExitOnError)
+	decks := fs.Int("decks", 4, "number of decks the history was played with")
+	indexes := fs.Bool("indexes", true, "expect the Hi-Lo Illustrious 18 index plays")
+	top := fs.Int("top", 10, "number of leaks to list")
+	printer := outputFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: review [flags] history-file (or -events log)")
+		os.Exit(2)
+	}
+
+	rounds, err := loadRounds(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	cfg := review.Config{Decks: *decks}
+	if *indexes {
+		cfg.Indexes = strategy.Illustrious18
+	}
+	r := review.Review(rounds, cfg)
+
+	out.Table([][]string{
+		{"Rounds:", fmt.Sprint(r.Rounds)},
+		{"Decisions:", fmt.Sprint(len(r.Decisions))},
+		{"Mistakes:", fmt.Sprint(r.Mistakes())},
+		{"Accuracy:", fmt.Sprintf("%.1f%%", 100*r.Accuracy())},
+	})
+	if spread(rounds) {
+		out.Println("\nBets placed at the same true count shouldn't know the cards that came after:")
+		rows := [][]string{{"Test", "Statistic", "p-value", ""}}
+		for _, c := range analysis.BetLeak(rounds, *decks) {
+			verdict := "ok"
+			if !c.Passed() {
+				verdict = "LEAK: the bets see cards they shouldn't"
+			}
+			rows = append(rows, []string{c.Name, fmt.Sprintf("%.2f", c.Statistic), fmt.Sprintf("%.4f", c.PValue), verdict})
+		}
+		out.Table(rows)
+	}
+	if len(r.Leaks) == 0 {
+		return
+	}
+
+	out.Println("\nLeaks:")
+	rows := [][]string{{"Hand", "Played", "Correct", "Times"}}
+	for i, l := range r.Leaks {
+		if i == *top {
+			break
+		}
+		correct := l.Correct
+		if l.Indexed {
+			correct += " (index)"
+		}
+		rows = append(rows, []string{l.Situation, l.Played, correct, fmt.Sprint(l.Times)})
+	}
+	out.Table(rows)
+}
+
+// spread reports whether the seat's bets vary over the rounds.
+func spread(rounds []blackjack.RoundResult) bool {
+	first := 0
+	for _, r := range rounds {
+		switch {
+		case !r.Seated:
+		case first == 0:
+			first = r.Bet
+		case r.Bet != first:
+			return true
+		}
+	}
+	return false
+}
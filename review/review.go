@@ -0,0 +1,170 @@
+// Package review re-evaluates the decisions in recorded rounds against basic
+// strategy and the count, to show a player where they are leaking money.
+package review
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/count"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/history"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// Config describes the game the rounds were played in and the strategy they
+// are checked against.
+type Config struct {
+	Decks   int                  // Number of decks in the shoe, 4 by default
+	Chart   *strategy.Chart      // Basic strategy, strategy.H17 if nil
+	Indexes []strategy.IndexPlay // Index plays expected of the player, none if nil
+	System  count.System         // Counting system the indexes are for, count.HiLo if nil
+}
+
+// Decision is a single move made by the player.
+type Decision struct {
+	Round     int         // Number of the round in the history
+	Hand      []deck.Card // Cards the player held when deciding
+	Dealer    deck.Card   // Dealer's upcard
+	TrueCount float64     // True count at the start of the round
+	Played    string      // Move the player made
+	Correct   string      // Move the strategy makes
+	Indexed   bool        // Whether the correct move comes from an index play
+}
+
+// Mistake reports whether the player departed from the strategy.
+func (d Decision) Mistake() bool {
+	return d.Played != d.Correct
+}
+
+// Situation names the decision by the hand's total and the dealer's upcard,
+// e.g. "16 v T", "soft 18 v 6" or "8,8 v A".
+func (d Decision) Situation() string {
+	up := upcard(d.Dealer)
+	if len(d.Hand) == 2 && blackjack.Score(d.Hand[0]) == blackjack.Score(d.Hand[1]) {
+		c := upcard(d.Hand[0])
+		return fmt.Sprintf("%s,%s v %s", c, c, up)
+	}
+	if blackjack.Soft(d.Hand...) {
+		return fmt.Sprintf("soft %d v %s", blackjack.Score(d.Hand...), up)
+	}
+	return fmt.Sprintf("%d v %s", blackjack.Score(d.Hand...), up)
+}
+
+func upcard(c deck.Card) string {
+	switch s := blackjack.Score(c); s {
+	case 11:
+		return "A"
+	case 10:
+		return "T"
+	default:
+		return fmt.Sprint(s)
+	}
+}
+
+// Leak is a mistake the player made repeatedly.
+type Leak struct {
+	Situation string // As returned by Decision.Situation
+	Played    string // Move the player made
+	Correct   string // Move the strategy makes
+	Indexed   bool   // Whether the correct move comes from an index play
+	Times     int    // Number of times the mistake was made
+}
+
+// Report is the result of reviewing a history.
+type Report struct {
+	Rounds    int        // Rounds reviewed, including ones the player sat out
+	Decisions []Decision // Every decision the player made
+	Leaks     []Leak     // Mistakes grouped by situation, most frequent first
+}
+
+// Mistakes returns the number of decisions that departed from the strategy.
+func (r Report) Mistakes() int {
+	n := 0
+	for _, d := range r.Decisions {
+		if d.Mistake() {
+			n++
+		}
+	}
+	return n
+}
+
+// Accuracy returns the fraction of decisions that matched the strategy.
+func (r Report) Accuracy() float64 {
+	if len(r.Decisions) == 0 {
+		return 1
+	}
+	return 1 - float64(r.Mistakes())/float64(len(r.Decisions))
+}
+
+// Review replays the decisions in rounds, keeping the count through each
+// shoe, and checks every one against the strategy in cfg.
+func Review(rounds []blackjack.RoundResult, cfg Config) Report {
+	if cfg.Decks == 0 {
+		cfg.Decks = 4
+	}
+	if cfg.Chart == nil {
+		cfg.Chart = &strategy.H17
+	}
+	counter := count.Counter{System: cfg.System, Decks: cfg.Decks}
+
+	r := Report{Rounds: len(rounds)}
+	for _, round := range rounds {
+		if round.Shuffled {
+			counter.Reset()
+		}
+		tc := counter.True()
+		if round.Seated {
+			for _, step := range history.Steps(round) {
+				// A hand left with one card after a split hasn't been dealt
+				// to yet, so there is no chart decision to check.
+				if len(step.Cards) < 2 {
+					continue
+				}
+				correct, indexed := cfg.Chart.MoveAt(step.Cards, step.Dealer, tc, cfg.Indexes)
+				r.Decisions = append(r.Decisions, Decision{
+					Round:     round.Round,
+					Hand:      step.Cards,
+					Dealer:    step.Dealer,
+					TrueCount: tc,
+					Played:    step.Move,
+					Correct:   correct.String(),
+					Indexed:   indexed,
+				})
+			}
+		}
+		for _, h := range round.Hands {
+			counter.Observe(h.Cards...)
+		}
+		counter.Observe(round.Dealer...)
+		counter.EndRound()
+	}
+	r.Leaks = leaks(r.Decisions)
+	return r
+}
+
+func leaks(ds []Decision) []Leak {
+	type key struct{ situation, played, correct string }
+	byKey := make(map[key]*Leak)
+	var ls []*Leak
+	for _, d := range ds {
+		if !d.Mistake() {
+			continue
+		}
+		k := key{d.Situation(), d.Played, d.Correct}
+		l, ok := byKey[k]
+		if !ok {
+			l = &Leak{Situation: k.situation, Played: k.played, Correct: k.correct, Indexed: d.Indexed}
+			byKey[k] = l
+			ls = append(ls, l)
+		}
+		l.Times++
+	}
+	sort.SliceStable(ls, func(i, j int) bool { return ls[i].Times > ls[j].Times })
+	ret := make([]Leak, len(ls))
+	for i, l := range ls {
+		ret[i] = *l
+	}
+	return ret
+}
@@ -0,0 +1,53 @@
+package review
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/ev"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// Alternative is one of the moves open at a decision, and how it would have
+// turned out.
+type Alternative struct {
+	Move   blackjack.Move
+	EV     float64               // Expected value per unit of the hand's bet, from the cards the player hadn't seen
+	Result blackjack.RoundResult // The round replayed from the same shoe with this move, then basic strategy
+	Played bool                  // Whether it's the move the player made
+}
+
+// WhatIf works out every move open at a frozen decision, best expected value
+// first. The EV says which move was right; the replayed round only says what
+// the shoe would have done this time.
+func WhatIf(m strategy.Moment) []Alternative {
+	v := m.View
+	rules := m.At.Rules()
+	e := ev.Hand(rules, ev.FromComposition(m.At.Composition()), v.Hand, v.Dealer, v.Hands > 1)
+	moves := []struct {
+		move blackjack.Move
+		ev   float64
+		ok   bool
+	}{
+		{blackjack.MoveStand, e.Stand, true},
+		{blackjack.MoveHit, e.Hit, v.CanHit},
+		{blackjack.MoveDouble, e.Double, v.CanDouble},
+		{blackjack.MoveSplit, e.Split, v.CanSplit},
+		{blackjack.MoveSurrender, e.Surrender, v.CanSurrender},
+	}
+	var alts []Alternative
+	for _, a := range moves {
+		if !a.ok || math.IsNaN(a.ev) {
+			continue
+		}
+		alts = append(alts, Alternative{
+			Move:   a.move,
+			EV:     a.ev,
+			Result: m.At.Branch(strategy.BasicStrategyAI(), a.move),
+			Played: a.move.String() == m.Played.String(),
+		})
+	}
+	sort.SliceStable(alts, func(i, j int) bool { return alts[i].EV > alts[j].EV })
+	return alts
+}
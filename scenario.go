@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Scrimzay/blackjacksimulator/scenario"
+)
+
+// runScenarios plays every scenario in the given files and reports which
+// failed. It exits with 1 if any did.
+func runScenarios(args []string) {
+	fs := flag.NewFlagSet("scenario", flag.ExitOnError)
+	verbose := fs.Bool("v", false, "print the history line of passing scenarios too")
+	parseFlags(fs, args)
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: scenario [flags] file...")
+		os.Exit(2)
+	}
+
+	passed, failed := 0, 0
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		scenarios, err := scenario.Load(f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(2)
+		}
+		for _, r := range scenario.RunAll(scenarios) {
+			s := r.Scenario
+			if r.Passed() {
+				passed++
+				fmt.Printf("PASS %s:%d %s\n", name, s.Line, s.Name)
+				switch {
+				case *verbose && r.Err != nil:
+					fmt.Println("    ", r.Err)
+				case *verbose:
+					fmt.Println("    ", r.Line)
+				}
+				continue
+			}
+			failed++
+			fmt.Printf("FAIL %s:%d %s\n", name, s.Line, s.Name)
+			for _, f := range r.Failures {
+				fmt.Println("    ", f)
+			}
+		}
+	}
+	fmt.Printf("%d passed, %d failed\n", passed, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,134 @@
+package scenario
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/history"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// Result is the outcome of running a scenario.
+type Result struct {
+	Scenario Scenario
+	Round    blackjack.RoundResult // The round as played
+	Line     string                // The round in history notation
+	Err      error                 // Why the engine refused the round, if it did
+	Failures []string              // Expectations that weren't met
+}
+
+// Passed reports whether every expectation was met.
+func (r Result) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// Run plays the scenario's round and checks it against the expectations.
+func Run(s Scenario) (res Result) {
+	res.Scenario = s
+	defer func() {
+		if p := recover(); p != nil {
+			res.Err = fmt.Errorf("%v", p)
+		}
+		res.check()
+	}()
+
+	opts := s.Options
+	opts.Hands = 1
+	opts.Shuffler = stacked{top: s.Cards, rand: rand.New(rand.NewSource(1))}
+	opts.Bettor = strategy.FlatBettor(s.Bet)
+	opts.Audit = true // Scenarios check the engine hands out copies too
+	opts.OnRound = func(r blackjack.RoundResult) {
+		r.Shuffled = false // Every scenario starts a shoe
+		res.Round, res.Line = r, history.Format(r)
+	}
+	var ai blackjack.AI = &script{moves: s.Moves}
+	if s.Strategy == "basic" {
+		ai = strategy.BasicStrategyAI()
+	}
+	g := blackjack.New(opts)
+	g.Play(ai)
+	return res
+}
+
+// RunAll runs every scenario.
+func RunAll(scenarios []Scenario) []Result {
+	results := make([]Result, len(scenarios))
+	for i, s := range scenarios {
+		results[i] = Run(s)
+	}
+	return results
+}
+
+// check compares the result with the scenario's expectations.
+func (r *Result) check() {
+	s := r.Scenario
+	switch {
+	case s.Error != "" && r.Err == nil:
+		r.Failures = append(r.Failures, fmt.Sprintf("expected error %q, the round was played", s.Error))
+	case s.Error != "" && !strings.Contains(r.Err.Error(), s.Error):
+		r.Failures = append(r.Failures, fmt.Sprintf("expected error %q, got %q", s.Error, r.Err))
+	case s.Error == "" && r.Err != nil:
+		r.Failures = append(r.Failures, fmt.Sprintf("unexpected error %q", r.Err))
+	}
+	if r.Err != nil {
+		return
+	}
+	if s.Expect != "" && s.Expect != r.Line {
+		r.Failures = append(r.Failures, fmt.Sprintf("expected %s\n     got %s", s.Expect, r.Line))
+	}
+	if s.Net != nil && *s.Net != r.Round.Net {
+		r.Failures = append(r.Failures, fmt.Sprintf("expected net %+d, got %+d", *s.Net, r.Round.Net))
+	}
+	if s.Drawn != nil && *s.Drawn != r.Round.Drawn {
+		r.Failures = append(r.Failures, fmt.Sprintf("expected %d cards drawn, got %d", *s.Drawn, r.Round.Drawn))
+	}
+}
+
+// script plays a fixed list of moves, then stands.
+type script struct {
+	moves []blackjack.Move
+}
+
+func (s *script) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	if len(s.moves) == 0 {
+		return blackjack.MoveStand
+	}
+	m := s.moves[0]
+	s.moves = s.moves[1:]
+	return m
+}
+
+func (s *script) Results(hands [][]deck.Card, dealer []deck.Card) {}
+
+// stacked shuffles the shoe with the given cards on top, in order.
+type stacked struct {
+	top  []deck.Card
+	rand *rand.Rand
+}
+
+func (s stacked) Perm(n int) []int {
+	return s.rand.Perm(n)
+}
+
+// PermCards puts the top cards first and shuffles the rest under them.
+func (s stacked) PermCards(cards []deck.Card) []int {
+	perm, err := deck.Find(cards, s.top...)
+	if err != nil {
+		panic(fmt.Sprintf("Not enough cards in the shoe: %v", err))
+	}
+	used := make([]bool, len(cards))
+	for _, j := range perm {
+		used[j] = true
+	}
+	rest := make([]int, 0, len(cards)-len(perm))
+	for j := range cards {
+		if !used[j] {
+			rest = append(rest, j)
+		}
+	}
+	s.rand.Shuffle(len(rest), func(i, j int) { rest[i], rest[j] = rest[j], rest[i] })
+	return append(perm, rest...)
+}
@@ -0,0 +1,179 @@
+// Package scenario runs single rounds dealt from a stacked shoe and checks
+// their outcome, so rules and AIs can be exercised on exactly the hands that
+// matter.
+//
+// A scenario file holds scenarios separated by blank lines. Each is a list of
+// "key: value" lines; lines starting with # are comments.
+//
+//	name:     hit 16 against a ten
+//	decks:    6
+//	rules:    s17 no-das
+//	cards:    TS,TD,6H,7C,9S
+//	moves:    H
+//	expect:   B100 P:TS,6H v T H:9S =bust | D:TD,7C | -100
+//	net:      -100
+//	drawn:    5
+//
+// cards is the top of the shoe in dealing order (player, upcard, player,
+// hole card, then every card drawn; under no-hole-card the dealer's second
+// card comes after the player's draws); the rest of the shoe is shuffled as
+// usual. moves scripts the player with the move letters of the history
+// notation, standing once they run out; strategy: basic plays
+// strategy.BasicStrategyAI instead. rules takes any of s17, no-das,
+// double-9-11, double-10-11, superfun21, push22, dealer-wins-ties,
+// resplit-aces, hit-split-aces, peek-ace, no-peek, no-hole-card, obo and
+// surrender; bet defaults
+// to 100. expect compares the round's history line, net its result, drawn
+// the number of cards it took from the shoe, and error: expects the round to be refused with a message containing the
+// text. Only name and cards are required.
+package scenario
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/history"
+)
+
+// Scenario is a single round to run.
+type Scenario struct {
+	Name     string
+	Line     int               // Line of the file the scenario starts on
+	Options  blackjack.Options // Rules to play under
+	Bet      int               // Bet placed, 100 by default
+	Cards    []deck.Card       // Top of the shoe, in dealing order
+	Moves    []blackjack.Move  // Scripted moves, if Strategy is empty
+	Strategy string            // Built-in strategy to play instead of Moves
+	Expect   string            // Expected history line, if not empty
+	Net      *int              // Expected result, if not nil
+	Drawn    *int              // Expected number of cards taken from the shoe, if not nil
+	Error    string            // Expected error, if not empty
+}
+
+var moveCodes = map[string]blackjack.Move{
+	"H": blackjack.MoveHit,
+	"S": blackjack.MoveStand,
+	"D": blackjack.MoveDouble,
+	"P": blackjack.MoveSplit,
+	"R": blackjack.MoveSurrender,
+}
+
+var ruleFlags = map[string]func(*blackjack.Options){
+	"s17":              func(o *blackjack.Options) { o.StandSoft17 = true },
+	"no-das":           func(o *blackjack.Options) { o.NoDoubleAfterSplit = true },
+	"double-9-11":      func(o *blackjack.Options) { o.DoubleOn = blackjack.DoubleNineToEleven },
+	"double-10-11":     func(o *blackjack.Options) { o.DoubleOn = blackjack.DoubleTenToEleven },
+	"superfun21":       func(o *blackjack.Options) { o.Variant = blackjack.SuperFun21 },
+	"push22":           func(o *blackjack.Options) { o.Push22 = true },
+	"dealer-wins-ties": func(o *blackjack.Options) { o.DealerWinsTies = true },
+	"resplit-aces":     func(o *blackjack.Options) { o.ResplitAces = true },
+	"hit-split-aces":   func(o *blackjack.Options) { o.HitSplitAces = true },
+	"peek-ace":         func(o *blackjack.Options) { o.Peek = blackjack.PeekAce },
+	"no-peek":          func(o *blackjack.Options) { o.Peek = blackjack.NoPeek },
+	"obo":              func(o *blackjack.Options) { o.OriginalBetsOnly = true },
+	"surrender":        func(o *blackjack.Options) { o.Surrender = true },
+	"no-hole-card":     func(o *blackjack.Options) { o.NoHoleCard = true },
+}
+
+// Load reads every scenario in r.
+func Load(r io.Reader) ([]Scenario, error) {
+	var (
+		scenarios []Scenario
+		s         *Scenario
+	)
+	finish := func() error {
+		if s == nil {
+			return nil
+		}
+		if s.Name == "" || len(s.Cards) == 0 {
+			return fmt.Errorf("Line %d: a scenario needs a name and cards", s.Line)
+		}
+		scenarios = append(scenarios, *s)
+		s = nil
+		return nil
+	}
+
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if strings.HasPrefix(text, "#") {
+			continue
+		}
+		if text == "" {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if s == nil {
+			s = &Scenario{Line: line, Bet: 100}
+		}
+		key, value, ok := strings.Cut(text, ":")
+		if !ok {
+			return nil, fmt.Errorf("Line %d: expected key: value", line)
+		}
+		if err := s.set(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return nil, fmt.Errorf("Line %d: %v", line, err)
+		}
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	return scenarios, sc.Err()
+}
+
+// set applies one line of a scenario.
+func (s *Scenario) set(key, value string) error {
+	var err error
+	switch key {
+	case "name":
+		s.Name = value
+	case "decks":
+		s.Options.Decks, err = strconv.Atoi(value)
+	case "bet":
+		s.Bet, err = strconv.Atoi(value)
+	case "rules":
+		for _, f := range strings.Fields(value) {
+			apply, ok := ruleFlags[f]
+			if !ok {
+				return fmt.Errorf("Unknown rule %q", f)
+			}
+			apply(&s.Options)
+		}
+	case "cards":
+		s.Cards, err = history.ParseCards(strings.Join(strings.Fields(strings.ReplaceAll(value, ",", " ")), ","))
+	case "moves":
+		for _, f := range strings.Fields(value) {
+			m, ok := moveCodes[f]
+			if !ok {
+				return fmt.Errorf("Unknown move %q", f)
+			}
+			s.Moves = append(s.Moves, m)
+		}
+	case "strategy":
+		if value != "basic" {
+			return fmt.Errorf("Unknown strategy %q", value)
+		}
+		s.Strategy = value
+	case "expect":
+		s.Expect = value
+	case "net":
+		var net int
+		net, err = strconv.Atoi(strings.TrimPrefix(value, "+"))
+		s.Net = &net
+	case "drawn":
+		var drawn int
+		drawn, err = strconv.Atoi(value)
+		s.Drawn = &drawn
+	case "error":
+		s.Error = value
+	default:
+		return fmt.Errorf("Unknown key %q", key)
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/stats"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// sequencing plays the same shoes flat and with ace sequencing, through
+// riffles of a few passes and a perfect shuffle, to show what following the
+// key cards through the shuffle is worth. Both play basic strategy, so the
+// only difference is the bet raised when an ace is expected.
+func sequencing(args []string) {
+	fs := flag.NewFlagSet("sequencing", flag.ExitOnError)
+	decks := fs.Int("decks", 6, "number of decks used")
+	hands := fs.Int("hands", 200000, "rounds to play with each shuffle")
+	seed := fs.Int64("seed", 1, "seed the shuffles; the flat and sequencing runs are dealt the same shoes")
+	bet := fs.Int("bet", 1000, "bet when a key card says an ace is coming, against the table minimum otherwise")
+	passes := fs.String("passes", "1,2,3,7", "riffle passes to try, separated by commas")
+	rules := presetFlags(fs)
+	printer := outputFlags(fs)
+	profile := profileFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+	defer profile()()
+
+	type shuffle struct {
+		name     string
+		shuffler func(rnd *rand.Rand) deck.Permer
+	}
+	shuffles := []shuffle{{"perfect", func(rnd *rand.Rand) deck.Permer { return rnd }}}
+	for _, f := range strings.Split(*passes, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil || n < 1 {
+			fmt.Fprintf(os.Stderr, "-passes %q: %q isn't a number of passes\n", *passes, f)
+			os.Exit(2)
+		}
+		name := fmt.Sprintf("riffle, %d passes", n)
+		if n == 1 {
+			name = "riffle, 1 pass"
+		}
+		shuffles = append(shuffles, shuffle{name, func(rnd *rand.Rand) deck.Permer { return deck.Riffle{Passes: n, Rand: rnd} }})
+	}
+	opts := blackjack.Options{Decks: *decks, Hands: *hands, Seed: *seed, Reuse: true}
+	if err := rules(&opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	rows := [][]string{{"Shuffle", "Flat edge", "Sequencing edge", "Raised bets", "Gain per 100 rounds"}}
+	for _, sh := range shuffles {
+		// The flat run's rounds, to pair with the same rounds played sequencing
+		var nets []int
+		var flat, seq stats.Stats
+		var gain stats.Moments
+		minBet, raised := 0, 0
+		o := opts
+		o.Shuffler = sh.shuffler(rand.New(rand.NewSource(*seed)))
+		o.OnRound = func(r blackjack.RoundResult) {
+			flat.Add(r)
+			nets, minBet = append(nets, r.Net), r.Bet
+		}
+		g := blackjack.New(o)
+		g.Play(strategy.BasicStrategyAI())
+
+		o.Shuffler = sh.shuffler(rand.New(rand.NewSource(*seed)))
+		o.OnRound = func(r blackjack.RoundResult) {
+			seq.Add(r)
+			gain.Add(float64(r.Net - nets[gain.N]))
+			if r.Bet > minBet {
+				raised++
+			}
+		}
+		g = blackjack.New(o)
+		g.Play(strategy.AceSequencing(strategy.BasicStrategyAI(), *bet))
+
+		rows = append(rows, []string{
+			sh.name,
+			fmt.Sprintf("%+.2f%%", edge(flat)),
+			fmt.Sprintf("%+.2f%%", edge(seq)),
+			fmt.Sprintf("%.1f%%", 100*float64(raised)/float64(max(seq.Rounds, 1))),
+			fmt.Sprintf("%+.1f ± %.1f", 100*gain.Mean, 196*gain.StdErr()),
+		})
+	}
+	out.Printf("Basic strategy, %d decks, %d rounds per shuffle; sequencing bets %d when an ace is expected (± is 95%%)\n\n", opts.Decks, *hands, *bet)
+	out.Table(rows)
+}
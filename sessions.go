@@ -0,0 +1,136 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/sim"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// sessions simulates many fixed-length sessions and prints the distribution of
+// their results.
+func sessions(args []string) {
+	fs := flag.NewFlagSet("sessions", flag.ExitOnError)
+	decks := fs.Int("decks", 4, "number of decks used")
+	n := fs.Int("n", 10000, "number of sessions to simulate")
+	hours := fs.Float64("hours", 4, "length of a session in hours")
+	rate := fs.Int("rate", 80, "hands played per hour")
+	roundTime := fs.Duration("round-time", 0, "time a round takes, e.g. 40s; sessions then last -hours on the clock instead of -hours times -rate hands")
+	cardTime := fs.Duration("card-time", 0, "time added for every card a round draws, e.g. 3s")
+	shuffleTime := fs.Duration("shuffle-time", 0, "time the dealer takes to shuffle a new shoe, e.g. 2m")
+	breakTime := fs.Duration("break", 0, "length of a break, e.g. 15m")
+	breakEvery := fs.Duration("break-every", 0, "time at the table between breaks, e.g. 1h (0 for no breaks)")
+	bankroll := fs.Int("bankroll", 0, "money brought to each session; the session ends when it can't cover the minimum bet (0 to play on regardless)")
+	rebuys := fs.Int("rebuys", 0, "times to buy back in for -bankroll when it runs out")
+	stopLoss := fs.Int("stop-loss", 0, "leave once the session is down this much (0 for no limit)")
+	stopWin := fs.Int("stop-win", 0, "leave once the session is up this much (0 for no limit)")
+	doubleForLess := fs.Bool("double-for-less", false, "double for what's left of -bankroll when it can't cover the whole bet")
+	strategyName := fs.String("strategy", "classic", "AI to play the sessions, one of those the strategies command lists")
+	learn := fs.Float64("learn", 0, "play an AI that learns as it goes instead of the basic AI, trying a random move this often, e.g. 0.1")
+	state := fs.String("state", "", "keep the AI's learned state in this file, so each session (and the next run) picks up where the last left off")
+	printer := outputFlags(fs)
+	profile := profileFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+	defer profile()()
+
+	game := blackjack.Options{
+		Decks:    *decks,
+		Bankroll: *bankroll,
+		Rebuys:   *rebuys,
+		StopLoss: *stopLoss,
+		StopWin:  *stopWin,
+	}
+	if *doubleForLess {
+		game.Bettor = strategy.DoubleForLess(nil)
+	}
+	named, err := strategy.Lookup(*strategyName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	named.Options(&game)
+	r := sim.RunSessions(sim.SessionConfig{
+		Game:         game,
+		Sessions:     *n,
+		Hours:        *hours,
+		HandsPerHour: *rate,
+		Clock: sim.Clock{
+			Round:      *roundTime,
+			Card:       *cardTime,
+			Shuffle:    *shuffleTime,
+			Break:      *breakTime,
+			BreakEvery: *breakEvery,
+		},
+		State: *state,
+	}, func() blackjack.AI {
+		if *learn > 0 {
+			return strategy.LearnerAI(*learn)
+		}
+		return named.New(strategy.Setup{Decks: *decks, Out: out})
+	})
+	if r.StateErr != nil {
+		fmt.Fprintln(os.Stderr, r.StateErr)
+		if len(r.Results) == 0 {
+			os.Exit(1)
+		}
+	}
+
+	timed := r.Time != sim.SessionTime{}
+	if timed {
+		out.Printf("%d sessions of %g hours, %d hands on average\n\n", len(r.Results), r.Hours, r.Hands)
+	} else {
+		out.Printf("%d sessions of %d hands\n\n", len(r.Results), r.Hands)
+	}
+	out.Table([][]string{
+		{"Average result:", out.Money(int(r.Mean))},
+		{"Per hour:", out.Average(r.PerHour())},
+		{"Per 8-hour day:", out.Average(8 * r.PerHour())},
+		{"Standard deviation:", out.Amount(int(math.Round(r.StdDev)))},
+		{"Winning sessions:", fmt.Sprintf("%.1f%%", 100*r.WinProbability())},
+		{"Losing sessions:", fmt.Sprintf("%.1f%%", 100*r.LossProbability())},
+	})
+	if *bankroll > 0 || *stopLoss > 0 || *stopWin > 0 {
+		out.Println("\nSession rules:")
+		out.Table([][]string{
+			{"Hit the stop-loss:", fmt.Sprintf("%.1f%%", 100*r.StopRate(blackjack.StoppedLoss))},
+			{"Hit the stop-win:", fmt.Sprintf("%.1f%%", 100*r.StopRate(blackjack.StoppedWin))},
+			{"Went broke:", fmt.Sprintf("%.1f%%", 100*r.StopRate(blackjack.StoppedBroke))},
+			{"Rebuys per session:", fmt.Sprintf("%.2f", r.MeanRebuys())},
+		})
+	}
+
+	if timed {
+		out.Println("\nTime per session:")
+		out.Table([][]string{
+			{"Playing:", r.Time.Playing.Round(time.Second).String()},
+			{"Shuffling:", r.Time.Shuffling.Round(time.Second).String()},
+			{"On breaks:", r.Time.Breaks.Round(time.Second).String()},
+		})
+	}
+
+	out.Println("\nPercentiles:")
+	var rows [][]string
+	for _, p := range []float64{1, 5, 10, 25, 50, 75, 90, 95, 99} {
+		rows = append(rows, []string{fmt.Sprintf("%g%%", p), out.Money(r.Percentile(p))})
+	}
+	out.Table(rows)
+
+	out.Println("\nDistribution:")
+	bounds, counts := r.Histogram(15)
+	most := 0
+	for _, c := range counts {
+		most = max(most, c)
+	}
+	rows = rows[:0]
+	for i, c := range counts {
+		rows = append(rows, []string{fmt.Sprintf("%d", bounds[i]), strings.Repeat("#", 50*c/most), fmt.Sprint(c)})
+	}
+	out.Table(rows)
+}
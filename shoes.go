@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// shoes shuffles a batch of shoes ahead of time and writes them to a file that
+// stats -shoes can play from.
+func shoes(args []string) {
+	fs := flag.NewFlagSet("shoes", flag.ExitOnError)
+	decks := fs.Int("decks", 6, "number of decks in each shoe")
+	n := fs.Int("n", 10000, "number of shoes to shuffle")
+	seed := fs.Int64("seed", 0, "seed the shuffles (0 for random)")
+	file := fs.String("o", "shoes.bin", "file to write the batch to")
+	parseFlags(fs, args)
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	batch := deck.NewShoeBatch(rand.New(rand.NewSource(*seed)), len(blackjack.Classic.Shoe(*decks)), *n)
+	f, err := os.Create(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	w := bufio.NewWriter(f)
+	if _, err := batch.WriteTo(w); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %d shoes of %d cards to %s\n", batch.Len(), batch.Size(), *file)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Scrimzay/blackjacksimulator/analysis"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// shuffletest checks a shuffle model for uniformity.
+func shuffletest(args []string) {
+	fs := flag.NewFlagSet("shuffletest", flag.ExitOnError)
+	model := fs.String("model", "perfect", "shuffle model to test: perfect, riffle or lazy")
+	passes := fs.Int("passes", 7, "number of riffles for the riffle and lazy models")
+	cards := fs.Int("cards", 52, "number of cards to shuffle")
+	trials := fs.Int("trials", 20000, "number of shuffles to draw")
+	printer := outputFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+
+	var p deck.Permer
+	switch *model {
+	case "perfect":
+		p = deck.Perfect
+	case "riffle":
+		p = deck.Riffle{Passes: *passes}
+	case "lazy":
+		p = deck.Lazy{Passes: *passes}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown shuffle model %q, want perfect, riffle or lazy\n", *model)
+		os.Exit(2)
+	}
+
+	rows := [][]string{{"Test", "Statistic", "p-value", ""}}
+	failed := false
+	for _, c := range analysis.CheckShuffler(p, *cards, *trials) {
+		verdict := "ok"
+		if !c.Passed() {
+			verdict, failed = "FAIL", true
+		}
+		rows = append(rows, []string{c.Name, fmt.Sprintf("%.2f", c.Statistic), fmt.Sprintf("%.4f", c.PValue), verdict})
+	}
+	out.Table(rows)
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,133 @@
+package sim
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Antithetic variates pair every random shoe with its mirror image: the same
+// cards dealt in reverse order. A reversed uniformly random shuffle is itself a
+// uniformly random shuffle, so each shoe of a pair is a fair sample and the
+// pairs' winnings over their rounds estimate the EV. The mirror deals the
+// cards the original left behind the cut card first, so when a strategy's result
+// depends on which part of the shoe gets played (count-based bet spreads most of
+// all) the two shoes land on opposite sides of the mean and their average varies
+// less than that of two independent shoes. For flat-betting basic strategy the
+// two halves are nearly uncorrelated and the gain is negligible; run
+// RunIndependent alongside to measure it for a given AI.
+//
+// This is only valid when:
+// - the shoe is perfectly shuffled (Options.Shuffler is ignored; a riffle
+//   model's mirror is not distributed like the riffle itself),
+// - each shoe is played from new decks by a new AI, so nothing carries over
+//   between the two halves of a pair,
+// - the standard error is computed from the pairs, as report does, never
+//   from the individual shoes, which are correlated.
+
+// EVReport is an estimate of the expected result per round.
+type EVReport struct {
+	Samples int     // Number of independent samples (shoes, or pairs of shoes)
+	Rounds  int     // Total rounds played
+	EV      float64 // Estimated average result per round
+	StdErr  float64 // Standard error of EV
+}
+
+// recorder is a Permer that shuffles at random and remembers the permutation.
+type recorder struct {
+	rnd  *rand.Rand
+	perm []int
+}
+
+// Perm returns and remembers a random permutation.
+func (r *recorder) Perm(n int) []int {
+	r.perm = r.rnd.Perm(n)
+	return r.perm
+}
+
+// mirror is a Permer that deals a recorded shoe in reverse order.
+type mirror struct{ r *recorder }
+
+// Perm returns the recorded permutation reversed.
+func (m mirror) Perm(n int) []int {
+	perm := make([]int, n)
+	for i, j := range m.r.perm {
+		perm[n-1-i] = j
+	}
+	return perm
+}
+
+// playShoe plays a single shoe and returns what it won and the rounds dealt.
+func playShoe(opts blackjack.Options, shuffler deck.Permer, ai blackjack.AI) (net, rounds int) {
+	opts.Hands = 0
+	opts.Shoes = 1
+	opts.Shuffler = shuffler
+	g := blackjack.New(opts)
+	net = g.Play(ai)
+	return net, g.Played()
+}
+
+// pair is what a pair of shoes won between them, over the rounds they dealt.
+type pair struct {
+	net, rounds int
+}
+
+// RunAntithetic estimates the EV per round from pairs of mirrored shoes, each
+// played by a new AI from newAI.
+func RunAntithetic(opts blackjack.Options, newAI func() blackjack.AI, pairs int) EVReport {
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	samples := make([]pair, pairs)
+	for i := range samples {
+		shoe := &recorder{rnd: rnd}
+		a, ra := playShoe(opts, shoe, newAI())
+		b, rb := playShoe(opts, mirror{shoe}, newAI())
+		samples[i] = pair{a + b, ra + rb}
+	}
+	return report(samples)
+}
+
+// RunIndependent estimates the EV per round the plain way, from pairs of
+// independent shoes, for comparison with RunAntithetic at the same cost.
+func RunIndependent(opts blackjack.Options, newAI func() blackjack.AI, pairs int) EVReport {
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	samples := make([]pair, pairs)
+	for i := range samples {
+		a, ra := playShoe(opts, rnd, newAI())
+		b, rb := playShoe(opts, rnd, newAI())
+		samples[i] = pair{a + b, ra + rb}
+	}
+	return report(samples)
+}
+
+// report estimates the EV as everything won over every round dealt, rather
+// than averaging the pairs' own EVs, which would count a pair of short shoes
+// as much as a pair of long ones. The standard error is the ratio
+// estimator's, from how far each pair's net strays from EV times its rounds;
+// it is 0 with fewer than 2 pairs, and so is everything with no rounds dealt.
+func report(samples []pair) EVReport {
+	net, rounds := 0, 0
+	for _, p := range samples {
+		net += p.net
+		rounds += p.rounds
+	}
+	r := EVReport{Samples: len(samples), Rounds: rounds}
+	if rounds == 0 {
+		return r
+	}
+	r.EV = float64(net) / float64(rounds)
+	if len(samples) < 2 {
+		return r
+	}
+	sq := 0.0
+	for _, p := range samples {
+		d := float64(p.net) - r.EV*float64(p.rounds)
+		sq += d * d
+	}
+	n := float64(len(samples))
+	variance := sq / (n - 1)
+	r.StdErr = math.Sqrt(variance*n) / float64(rounds)
+	return r
+}
@@ -0,0 +1,60 @@
+package sim
+
+import (
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+)
+
+// Clock models how long play takes at the table, so sessions can be measured
+// in hours at the casino rather than in hands.
+type Clock struct {
+	Round      time.Duration // Time to bet, deal and settle a round
+	Card       time.Duration // Added for every card a round draws, so splits and hits take longer
+	Shuffle    time.Duration // Time the dealer takes to shuffle up a new shoe
+	Break      time.Duration // Length of a break away from the table
+	BreakEvery time.Duration // Time at the table between breaks, no breaks if 0
+}
+
+// RoundTime returns how long round r took, the shuffle before it included.
+func (c Clock) RoundTime(r blackjack.RoundResult) time.Duration {
+	d := c.Round + time.Duration(r.Drawn)*c.Card
+	if r.Shuffled {
+		d += c.Shuffle
+	}
+	return d
+}
+
+// SessionTime is where a session's time went.
+type SessionTime struct {
+	Playing   time.Duration // Dealing and playing rounds
+	Shuffling time.Duration // Waiting on the dealer to shuffle
+	Breaks    time.Duration // Away from the table
+}
+
+// Total returns the time spent at the casino.
+func (t SessionTime) Total() time.Duration {
+	return t.Playing + t.Shuffling + t.Breaks
+}
+
+// timer runs a clock over one session.
+type timer struct {
+	clock      Clock
+	spent      SessionTime
+	sinceBreak time.Duration // Time at the table since the last break
+}
+
+// add moves the clock on by round r and the break after it, if one is due.
+func (t *timer) add(r blackjack.RoundResult) {
+	d := t.clock.RoundTime(r)
+	if r.Shuffled {
+		t.spent.Shuffling += t.clock.Shuffle
+		d -= t.clock.Shuffle
+	}
+	t.spent.Playing += d
+	t.sinceBreak += t.clock.RoundTime(r)
+	if t.clock.BreakEvery > 0 && t.sinceBreak >= t.clock.BreakEvery {
+		t.spent.Breaks += t.clock.Break
+		t.sinceBreak = 0
+	}
+}
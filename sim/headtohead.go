@@ -0,0 +1,54 @@
+package sim
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+)
+
+// Match is the result of a head-to-head between two strategies, shoe by shoe.
+type Match struct {
+	Wins   int // Shoes the first strategy came out ahead on
+	Losses int // Shoes the second strategy came out ahead on
+	Draws  int // Shoes they finished level on
+	NetA   int // First strategy's total result
+	NetB   int // Second strategy's total result
+}
+
+// Score returns the first strategy's share of the points, a draw counting half.
+func (m Match) Score() float64 {
+	n := m.Wins + m.Losses + m.Draws
+	if n == 0 {
+		return 0.5
+	}
+	return (float64(m.Wins) + float64(m.Draws)/2) / float64(n)
+}
+
+// HeadToHead plays two strategies on the same shoes: each shoe is dealt from
+// the same seed for both, so they see the same cards until their decisions
+// send them down different paths. Each shoe is played by new AIs from newA and
+// newB, which get the same AI seed too.
+func HeadToHead(opts blackjack.Options, newA, newB func() blackjack.AI, shoes int) Match {
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	opts.Hands = 0
+	opts.Shoes = 1
+	var m Match
+	for range shoes {
+		opts.Seed = rnd.Int63() | 1
+		opts.AISeed = rnd.Int63() | 1
+		a, b := blackjack.New(opts), blackjack.New(opts)
+		na, nb := a.Play(newA()), b.Play(newB())
+		m.NetA += na
+		m.NetB += nb
+		switch {
+		case na > nb:
+			m.Wins++
+		case na < nb:
+			m.Losses++
+		default:
+			m.Draws++
+		}
+	}
+	return m
+}
@@ -0,0 +1,187 @@
+// Package sim runs many games to study the distribution of their results.
+package sim
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+)
+
+// SessionConfig describes a batch of fixed-length playing sessions.
+type SessionConfig struct {
+	Game         blackjack.Options // Table rules and session rules (Bankroll, Rebuys, StopLoss, StopWin); Hands is replaced by the session length
+	Sessions     int               // Number of sessions to simulate, 1000 by default
+	Hours        float64           // Length of each session, 4 by default
+	HandsPerHour int               // Playing speed, 80 by default; ignored with a Clock
+	Clock        Clock             // How long rounds, shuffles and breaks take; sessions are played until Hours is up instead of for a fixed number of hands
+	State        string            // File an AI that learns keeps its state in (see blackjack.Saver), so every session picks up where the last left off
+}
+
+// SessionReport summarises the outcome of the simulated sessions.
+type SessionReport struct {
+	Hours   float64     // Length of each session
+	Hands   int         // Hands played per session, on average with a Clock
+	Time    SessionTime // Where the average session's time went, only with a Clock
+	Results []int       // Net result of every session, sorted from worst to best
+	Mean    float64     // Average session result
+	StdDev  float64     // Standard deviation of the session results
+
+	Stops  map[blackjack.StopReason]int // Sessions that ended early, by reason
+	Rebuys []int                        // Rebuys made in every session, in the order played
+
+	StateErr error // Why the AI's state couldn't be loaded or saved, which ends the run early; the results are those of the sessions played
+}
+
+// RunSessions plays cfg.Sessions independent sessions, each with a new shoe and
+// a new AI from newAI, and reports the distribution of their results. With
+// cfg.State, each AI loads the state the last one saved before it plays, so
+// the sessions aren't independent any more: they're training.
+func RunSessions(cfg SessionConfig, newAI func() blackjack.AI) SessionReport {
+	if cfg.Sessions == 0 {
+		cfg.Sessions = 1000
+	}
+	if cfg.Hours == 0 {
+		cfg.Hours = 4
+	}
+	if cfg.HandsPerHour == 0 {
+		cfg.HandsPerHour = 80
+	}
+	opts := cfg.Game
+	opts.Hands = int(cfg.Hours * float64(cfg.HandsPerHour))
+	timed := cfg.Clock != Clock{}
+	var t *timer
+	if timed {
+		length := time.Duration(cfg.Hours * float64(time.Hour))
+		opts.Hands = math.MaxInt
+		leave, onRound := opts.Leave, opts.OnRound
+		opts.Leave = func() bool { return t.spent.Total() >= length || leave != nil && leave() }
+		opts.OnRound = func(r blackjack.RoundResult) {
+			t.add(r)
+			if onRound != nil {
+				onRound(r)
+			}
+		}
+	}
+
+	r := SessionReport{
+		Hours:   cfg.Hours,
+		Hands:   opts.Hands,
+		Results: make([]int, cfg.Sessions),
+		Stops:   map[blackjack.StopReason]int{},
+		Rebuys:  make([]int, cfg.Sessions),
+	}
+	hands := 0
+	var spent SessionTime
+	for i := range r.Results {
+		t = &timer{clock: cfg.Clock}
+		g := blackjack.New(opts)
+		ai := newAI()
+		if cfg.State != "" {
+			if r.StateErr = blackjack.LoadState(ai, cfg.State); r.StateErr != nil {
+				r.Results, r.Rebuys = r.Results[:i], r.Rebuys[:i]
+				break
+			}
+		}
+		r.Results[i] = g.Play(ai)
+		s := g.Session()
+		if s.Stopped != "" && s.Stopped != blackjack.StoppedDone {
+			r.Stops[s.Stopped]++
+		}
+		hands += g.Played()
+		spent.Playing += t.spent.Playing
+		spent.Shuffling += t.spent.Shuffling
+		spent.Breaks += t.spent.Breaks
+		r.Rebuys[i] = s.Rebuys
+		if cfg.State != "" {
+			if r.StateErr = blackjack.SaveState(ai, cfg.State); r.StateErr != nil {
+				r.Results, r.Rebuys = r.Results[:i+1], r.Rebuys[:i+1]
+				break
+			}
+		}
+	}
+	sort.Ints(r.Results)
+	if len(r.Results) == 0 {
+		return r
+	}
+	if timed {
+		n := len(r.Results)
+		r.Hands = hands / n
+		r.Time = SessionTime{spent.Playing / time.Duration(n), spent.Shuffling / time.Duration(n), spent.Breaks / time.Duration(n)}
+	}
+
+	r.Mean, r.StdDev = meanStdDev(r.Results)
+	return r
+}
+
+// meanStdDev returns the mean and standard deviation of results.
+func meanStdDev(results []int) (mean, stdDev float64) {
+	sum := 0.0
+	for _, res := range results {
+		sum += float64(res)
+	}
+	mean = sum / float64(len(results))
+	sq := 0.0
+	for _, res := range results {
+		d := float64(res) - mean
+		sq += d * d
+	}
+	return mean, math.Sqrt(sq / float64(len(results)))
+}
+
+// PerHour returns the average result per hour at the casino, shuffles and
+// breaks included.
+func (r SessionReport) PerHour() float64 {
+	return r.Mean / r.Hours
+}
+
+// WinProbability returns the fraction of sessions that ended ahead.
+func (r SessionReport) WinProbability() float64 {
+	i := sort.SearchInts(r.Results, 1)
+	return float64(len(r.Results)-i) / float64(len(r.Results))
+}
+
+// LossProbability returns the fraction of sessions that ended behind.
+func (r SessionReport) LossProbability() float64 {
+	i := sort.SearchInts(r.Results, 0)
+	return float64(i) / float64(len(r.Results))
+}
+
+// StopRate returns the fraction of sessions that ended early for reason.
+func (r SessionReport) StopRate(reason blackjack.StopReason) float64 {
+	return float64(r.Stops[reason]) / float64(len(r.Results))
+}
+
+// MeanRebuys returns the average number of rebuys per session.
+func (r SessionReport) MeanRebuys() float64 {
+	n := 0
+	for _, b := range r.Rebuys {
+		n += b
+	}
+	return float64(n) / float64(len(r.Rebuys))
+}
+
+// Percentile returns the session result below which p percent of sessions fell.
+func (r SessionReport) Percentile(p float64) int {
+	i := int(p / 100 * float64(len(r.Results)))
+	return r.Results[max(0, min(i, len(r.Results)-1))]
+}
+
+// Histogram splits the range between the 1st and 99th percentiles into n equal
+// buckets and returns the lower bound of each bucket and the number of sessions
+// that fell in it. The rare sessions outside that range are counted in the first
+// or last bucket, so they don't squash the rest of the chart.
+func (r SessionReport) Histogram(n int) (bounds []int, counts []int) {
+	lo, hi := r.Percentile(1), r.Percentile(99)
+	width := max(1, (hi-lo+n)/n)
+	bounds = make([]int, n)
+	counts = make([]int, n)
+	for i := range bounds {
+		bounds[i] = lo + i*width
+	}
+	for _, res := range r.Results {
+		counts[max(0, min((res-lo)/width, n-1))]++
+	}
+	return bounds, counts
+}
@@ -0,0 +1,190 @@
+package sim
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Large session losses mostly come from a handful of extreme shoes, which plain
+// simulation deals far too rarely to measure. Importance sampling deals them
+// more often and corrects for it: every shoe is classified by the Hi-Lo count
+// of the cards in front of the cut card, the extreme classes are dealt
+// Oversample times more often than they naturally occur, and every session is
+// weighted by how much more (or less) likely its shoes were made. Within a
+// class the shoes are still perfectly random, so the weighted estimate is
+// unbiased up to the error in the class probabilities, which are measured once
+// from a large pilot run of shuffles.
+//
+// The weights multiply across the shoes of a session, so strong oversampling
+// quickly leaves a few sessions carrying all the weight; keep an eye on
+// TailReport.Effective and lower Oversample if it drops far below Sessions.
+
+// TailConfig describes a tail-risk estimate.
+type TailConfig struct {
+	SessionConfig
+	Loss       int     // Estimate the chance of losing at least this much in a session
+	Oversample float64 // How much more often the most extreme shoes are dealt, 3 by default
+}
+
+// TailReport is an importance-sampled estimate of the chance of a large loss.
+type TailReport struct {
+	Probability float64 // Estimated chance of a session losing at least Loss
+	StdErr      float64 // Standard error of Probability
+	Hits        int     // Simulated sessions that lost at least Loss, before weighting
+	Effective   float64 // Effective number of sessions after weighting (Kish)
+}
+
+// pilotShuffles is the number of shuffles used to measure the class probabilities.
+const pilotShuffles = 50000
+
+// hiLo returns the Hi-Lo tag of a card: +1 for 2-6, -1 for tens and aces.
+func hiLo(c deck.Card) int {
+	switch {
+	case c.Rank == deck.Ace || c.Rank.IsTenValue():
+		return -1
+	case c.Rank <= deck.Six:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// tilted is a shuffler that deals extreme shoes more often than chance and keeps
+// track of the likelihood ratio of the shoes it dealt.
+type tilted struct {
+	rnd    *rand.Rand
+	dealt  float64   // Fraction of the shoe dealt before the engine reshuffles
+	edges  []int     // Upper bounds of the classes, the last class is open
+	p, q   []float64 // Natural and sampling probability of each class
+	weight float64   // Product of p/q over the shoes dealt this session
+}
+
+// class returns the class of a shoe from its count in front of the cut card.
+func (t *tilted) class(count int) int {
+	return sort.SearchInts(t.edges, count)
+}
+
+// count returns the Hi-Lo count of the cards in front of the cut card.
+func count(cards []deck.Card, perm []int, dealt float64) int {
+	n, c := int(float64(len(perm))*dealt), 0
+	for _, j := range perm[:n] {
+		c += hiLo(cards[j])
+	}
+	return c
+}
+
+// Perm satisfies deck.Permer, but tilted needs the cards; see PermCards.
+func (t *tilted) Perm(n int) []int {
+	return t.rnd.Perm(n)
+}
+
+// PermCards picks a class with the sampling probabilities, then shuffles until
+// a shoe of that class comes up.
+func (t *tilted) PermCards(cards []deck.Card) []int {
+	u, want := t.rnd.Float64(), len(t.q)-1
+	for i, q := range t.q {
+		if u < q {
+			want = i
+			break
+		}
+		u -= q
+	}
+	for {
+		perm := t.rnd.Perm(len(cards))
+		if t.class(count(cards, perm, t.dealt)) == want {
+			t.weight *= t.p[want] / t.q[want]
+			return perm
+		}
+	}
+}
+
+// newTilted measures the class probabilities of a shoe and sets up the
+// oversampling of its tails.
+func newTilted(cards []deck.Card, dealt, oversample float64, rnd *rand.Rand) *tilted {
+	counts := make([]int, pilotShuffles)
+	for i := range counts {
+		counts[i] = count(cards, rnd.Perm(len(cards)), dealt)
+	}
+	sort.Ints(counts)
+	quantile := func(f float64) int { return counts[int(f*float64(len(counts)))] }
+
+	// Classes: the outer 1% tails, the next 4% on each side, and the middle
+	t := &tilted{
+		rnd:   rnd,
+		dealt: dealt,
+		edges: []int{quantile(0.01), quantile(0.05), quantile(0.95) - 1, quantile(0.99) - 1},
+	}
+	t.p = make([]float64, len(t.edges)+1)
+	for _, c := range counts {
+		t.p[t.class(c)]++
+	}
+	boost := []float64{oversample, math.Sqrt(oversample), 1, math.Sqrt(oversample), oversample}
+	t.q = make([]float64, len(t.p))
+	total := 0.0
+	for i := range t.p {
+		t.p[i] /= float64(len(counts))
+		t.q[i] = t.p[i] * boost[i]
+		total += t.q[i]
+	}
+	for i := range t.q {
+		t.q[i] /= total
+	}
+	return t
+}
+
+// RunTail estimates the chance of a session losing at least cfg.Loss by
+// importance sampling the shoes. Shuffler options in cfg.Game are ignored.
+func RunTail(cfg TailConfig, newAI func() blackjack.AI) TailReport {
+	if cfg.Sessions == 0 {
+		cfg.Sessions = 1000
+	}
+	if cfg.Hours == 0 {
+		cfg.Hours = 4
+	}
+	if cfg.HandsPerHour == 0 {
+		cfg.HandsPerHour = 80
+	}
+	if cfg.Oversample == 0 {
+		cfg.Oversample = 3
+	}
+	opts := cfg.Game
+	opts.Hands = int(cfg.Hours * float64(cfg.HandsPerHour))
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	decks := opts.Decks
+	if decks == 0 {
+		decks = 3 // The engine's default
+	}
+	dealt := opts.Penetration
+	if dealt == 0 {
+		dealt = blackjack.DefaultPenetration(decks)
+	}
+	t := newTilted(opts.Variant.Shoe(decks), dealt, cfg.Oversample, rnd)
+	opts.Shuffler = t
+
+	var r TailReport
+	sum, sumSq, sumW, sumW2 := 0.0, 0.0, 0.0, 0.0
+	for i := 0; i < cfg.Sessions; i++ {
+		t.weight = 1
+		g := blackjack.New(opts)
+		x := 0.0
+		if g.Play(newAI()) <= -cfg.Loss {
+			r.Hits++
+			x = t.weight
+		}
+		sum += x
+		sumSq += x * x
+		sumW += t.weight
+		sumW2 += t.weight * t.weight
+	}
+	n := float64(cfg.Sessions)
+	r.Probability = sum / n
+	r.StdErr = math.Sqrt(max(0, sumSq/n-r.Probability*r.Probability) / n)
+	r.Effective = sumW * sumW / sumW2
+	return r
+}
@@ -0,0 +1,283 @@
+package sim
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+)
+
+// Shift is a stretch of a trip played under the same table conditions: one
+// dealer, one set of rules and the limits for that time of day.
+type Shift struct {
+	Name         string
+	Line         int               // Line of the schedule the shift starts on
+	Hours        float64           // Length of the shift
+	HandsPerHour int               // Playing speed, 80 if 0
+	Table        blackjack.Options // The table's decks, rules, penetration and limits (MinBet, MaxBet)
+	Penetration  [2]float64        // Range the shift's dealer cuts the shoe at, drawn anew every trip; Table.Penetration if zero
+}
+
+// LoadSchedule reads a trip's schedule: shifts separated by blank lines, each
+// a list of "key: value" lines. Lines starting with # are comments.
+//
+//	name:        friday night
+//	hours:       6
+//	table:       shoe
+//	penetration: 0.65-0.8
+//	min:         25
+//	max:         1000
+//
+// Every shift starts from the conditions of the one before it, so it only
+// needs to say what changed, but name and hours are always required. table
+// takes one of blackjack.Presets, decks the number of decks, payout what a
+// natural pays (1.2 for 6:5), penetration a fraction of the shoe or a range to
+// draw each trip's dealer from, min and max the table limits and rate the
+// hands played per hour.
+func LoadSchedule(r io.Reader) ([]Shift, error) {
+	var (
+		shifts []Shift
+		s      *Shift
+		last   Shift
+	)
+	finish := func() error {
+		if s == nil {
+			return nil
+		}
+		if s.Name == "" || s.Hours == 0 {
+			return fmt.Errorf("Line %d: a shift needs a name and hours", s.Line)
+		}
+		shifts = append(shifts, *s)
+		last, s = *s, nil
+		return nil
+	}
+
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if strings.HasPrefix(text, "#") {
+			continue
+		}
+		if text == "" {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if s == nil {
+			s = &Shift{Line: line, HandsPerHour: last.HandsPerHour, Table: last.Table, Penetration: last.Penetration}
+		}
+		key, value, ok := strings.Cut(text, ":")
+		if !ok {
+			return nil, fmt.Errorf("Line %d: expected key: value", line)
+		}
+		if err := s.set(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return nil, fmt.Errorf("Line %d: %v", line, err)
+		}
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	if len(shifts) == 0 && sc.Err() == nil {
+		return nil, fmt.Errorf("The schedule has no shifts")
+	}
+	return shifts, sc.Err()
+}
+
+// set applies one line of a shift.
+func (s *Shift) set(key, value string) error {
+	var err error
+	switch key {
+	case "name":
+		s.Name = value
+	case "hours":
+		s.Hours, err = strconv.ParseFloat(value, 64)
+		if err == nil && !(s.Hours > 0) {
+			return fmt.Errorf("A shift can't last %s hours", value)
+		}
+	case "table":
+		p, ok := blackjack.Presets[value]
+		if !ok {
+			return fmt.Errorf("Unknown table %q", value)
+		}
+		p.MinBet, p.MaxBet = s.Table.MinBet, s.Table.MaxBet
+		s.Table, s.Penetration = p, [2]float64{}
+	case "decks":
+		s.Table.Decks, err = strconv.Atoi(value)
+	case "payout":
+		s.Table.BlackjackPayout, err = strconv.ParseFloat(value, 64)
+	case "penetration":
+		lo, hi, ranged := strings.Cut(value, "-")
+		s.Penetration[0], err = strconv.ParseFloat(lo, 64)
+		s.Penetration[1] = s.Penetration[0]
+		if ranged && err == nil {
+			s.Penetration[1], err = strconv.ParseFloat(hi, 64)
+		}
+		if err == nil && !(0 < s.Penetration[0] && s.Penetration[0] <= s.Penetration[1] && s.Penetration[1] < 1) {
+			return fmt.Errorf("Penetration must be a fraction of the shoe or a range of them, not %s", value)
+		}
+	case "min":
+		s.Table.MinBet, err = strconv.Atoi(value)
+	case "max":
+		s.Table.MaxBet, err = strconv.Atoi(value)
+	case "rate":
+		s.HandsPerHour, err = strconv.Atoi(value)
+	default:
+		return fmt.Errorf("Unknown key %q", key)
+	}
+	return err
+}
+
+// options returns the options a shift is played with: the table's conditions
+// over what stays the same all trip, with the dealer's penetration drawn from
+// rnd.
+func (s Shift) options(trip blackjack.Options, rnd *rand.Rand) blackjack.Options {
+	opts := trip
+	t := s.Table
+	opts.Decks = t.Decks
+	opts.BlackjackPayout = t.BlackjackPayout
+	opts.StandSoft17 = t.StandSoft17
+	opts.DoubleOn = t.DoubleOn
+	opts.NoDoubleAfterSplit = t.NoDoubleAfterSplit
+	opts.SplitHands = t.SplitHands
+	opts.Penetration = t.Penetration
+	if lo, hi := s.Penetration[0], s.Penetration[1]; lo > 0 {
+		opts.Penetration = lo + rnd.Float64()*(hi-lo)
+	}
+	opts.MinBet, opts.MaxBet = t.MinBet, t.MaxBet
+	rate := s.HandsPerHour
+	if rate == 0 {
+		rate = 80
+	}
+	opts.Hands, opts.Shoes = max(1, int(s.Hours*float64(rate))), 0
+	opts.Rebuys = 0
+	if trip.Seed != 0 {
+		opts.Seed = rnd.Int63() | 1
+	}
+	return opts
+}
+
+// TripConfig describes a batch of trips played to a schedule.
+type TripConfig struct {
+	Game   blackjack.Options // What stays the same all trip: the bettor, the count and the Bankroll, which is carried from shift to shift; Rebuys isn't used, and StopLoss and StopWin end a shift rather than the trip
+	Shifts []Shift           // The schedule, played in order every trip
+	Trips  int               // Number of trips to simulate, 1000 by default
+}
+
+// TripReport summarises the simulated trips.
+type TripReport struct {
+	SessionReport               // Every trip's result, a trip counting as one long session; a trip that went broke stopped early
+	Shifts        []ShiftReport // How every shift of the schedule went, in order
+}
+
+// ShiftReport is how one shift of the schedule went over all the trips.
+type ShiftReport struct {
+	Shift
+	Played int     // Trips that got as far as the shift
+	Hands  int     // Hands played in the shift, on average
+	Mean   float64 // Average result of the shift
+	AvgBet float64 // Average bet of the rounds played in the shift
+	Broke  int     // Trips that ran out of money during the shift
+}
+
+// PerHour returns the shift's average result per hour.
+func (s ShiftReport) PerHour() float64 {
+	return s.Mean / s.Hours
+}
+
+// RunTrips plays cfg.Trips trips through the schedule, each with a new AI from
+// newAI that plays every shift of it, and reports how the trips and each shift
+// went. With a Bankroll, what's left after a shift is what the next one starts
+// with, and the trip ends when it can't cover a bet.
+func RunTrips(cfg TripConfig, newAI func() blackjack.AI) TripReport {
+	if cfg.Trips == 0 {
+		cfg.Trips = 1000
+	}
+	seed := cfg.Game.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(seed))
+
+	r := TripReport{
+		SessionReport: SessionReport{
+			Results: make([]int, cfg.Trips),
+			Stops:   map[blackjack.StopReason]int{},
+			Rebuys:  make([]int, cfg.Trips),
+		},
+		Shifts: make([]ShiftReport, len(cfg.Shifts)),
+	}
+	var (
+		net     = make([]int, len(cfg.Shifts))
+		hands   = make([]int, len(cfg.Shifts))
+		wagered = make([]int, len(cfg.Shifts))
+		rounds  = make([]int, len(cfg.Shifts))
+		shift   int
+	)
+	onRound := cfg.Game.OnRound
+	cfg.Game.OnRound = func(res blackjack.RoundResult) {
+		if res.Seated {
+			wagered[shift] += res.Bet
+			rounds[shift]++
+		}
+		if onRound != nil {
+			onRound(res)
+		}
+	}
+	for i, s := range cfg.Shifts {
+		r.Shifts[i].Shift = s
+		r.Hours += s.Hours
+	}
+	total := 0
+	for i := range r.Results {
+		ai := newAI()
+		bankroll := cfg.Game.Bankroll
+		for j, s := range cfg.Shifts {
+			shift = j
+			r.Shifts[j].Played++
+			if cfg.Game.Bankroll > 0 && bankroll <= 0 {
+				r.Shifts[j].Broke++
+				r.Stops[blackjack.StoppedBroke]++
+				break
+			}
+			opts := s.options(cfg.Game, rnd)
+			if cfg.Game.Bankroll > 0 {
+				opts.Bankroll = bankroll
+			}
+			g := blackjack.New(opts)
+			res := g.Play(ai)
+			r.Results[i] += res
+			net[j] += res
+			hands[j] += g.Played()
+			total += g.Played()
+			if cfg.Game.Bankroll > 0 {
+				bankroll += res
+			}
+			if g.Session().Stopped == blackjack.StoppedBroke {
+				r.Shifts[j].Broke++
+				r.Stops[blackjack.StoppedBroke]++
+				break
+			}
+		}
+	}
+	for j := range r.Shifts {
+		s := &r.Shifts[j]
+		if s.Played > 0 {
+			s.Hands = hands[j] / s.Played
+			s.Mean = float64(net[j]) / float64(s.Played)
+		}
+		if rounds[j] > 0 {
+			s.AvgBet = float64(wagered[j]) / float64(rounds[j])
+		}
+	}
+	r.Hands = total / cfg.Trips
+	sort.Ints(r.Results)
+	r.Mean, r.StdDev = meanStdDev(r.Results)
+	return r
+}
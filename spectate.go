@@ -0,0 +1,37 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/Scrimzay/blackjacksimulator/spectate"
+)
+
+// startSpectators opens the game up to spectators: WebSocket clients at addr
+// and a local follower writing to the file at path, either of which may be
+// empty. The returned function sends the spectators home once the game is
+// over, letting the follower catch up first.
+func startSpectators(addr, path string) (*spectate.Hub, func(), error) {
+	hub := &spectate.Hub{}
+	if addr != "" {
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, nil, err
+		}
+		go http.Serve(l, hub)
+	}
+	if path == "" {
+		return hub, hub.Close, nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, nil, err
+	}
+	wait := hub.Follow(f)
+	return hub, func() {
+		hub.Close()
+		wait()
+		f.Close()
+	}, nil
+}
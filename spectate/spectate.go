@@ -0,0 +1,124 @@
+// Package spectate streams a live game's events to read-only observers, such
+// as a second terminal or browsers following a simulation on a projector.
+// A Hub is a blackjack.EventSink: set it (or a blackjack.Tee including it) as
+// Options.Events and every spectator gets each event as it happens. Spectators
+// can't act on the game, and one that falls behind misses events rather than
+// holding the game up. They don't get to see what the seat can't either: the
+// shoe's order is left out of shuffle events, and the dealer's hole card is held
+// back until the dealer turns it over.
+package spectate
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+)
+
+// Hub fans a game's events out to its spectators.
+type Hub struct {
+	// Buffer is how many events a spectator can fall behind by before it
+	// misses some, 256 if 0.
+	Buffer int
+
+	mu    sync.Mutex
+	subs  map[chan blackjack.Event]struct{}
+	round []blackjack.Event // Events of the round in progress, for latecomers
+	dealt int               // Cards dealt to the dealer this round
+	hole  *blackjack.Event  // The hole card's event, until it's turned over
+}
+
+// Record sends e to every spectator without waiting for any of them.
+func (h *Hub) Record(e blackjack.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	switch {
+	case e.Kind == blackjack.EventShuffle:
+		e.Cards = nil // Spectators see the cards as they're dealt, not the whole shoe
+	case e.Kind == blackjack.EventBet:
+		h.dealt = 0
+	case e.Kind == blackjack.EventCard && e.Hand == blackjack.DealerHand:
+		if h.dealt++; h.dealt == 2 {
+			h.hole = &e
+			return
+		}
+	}
+	if h.hole != nil && e.Phase != blackjack.PlayerTurn {
+		h.send(*h.hole) // Turned over once the seat is done
+		h.hole = nil
+	}
+	h.send(e)
+}
+
+// send passes e on to the spectators.
+func (h *Hub) send(e blackjack.Event) {
+	h.round = append(h.round, e)
+	if e.Kind == blackjack.EventEnd {
+		h.round = h.round[:0]
+	}
+	for c := range h.subs {
+		select {
+		case c <- e:
+		default: // Too far behind; the spectator misses this one
+		}
+	}
+}
+
+// Subscribe returns a channel of the game's events, starting with those of the
+// round in progress, and a function to stop watching, which closes it.
+func (h *Hub) Subscribe() (<-chan blackjack.Event, func()) {
+	size := h.Buffer
+	if size == 0 {
+		size = 256
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	c := make(chan blackjack.Event, max(size, len(h.round)))
+	for _, e := range h.round {
+		c <- e
+	}
+	if h.subs == nil {
+		h.subs = make(map[chan blackjack.Event]struct{})
+	}
+	h.subs[c] = struct{}{}
+	return c, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[c]; ok { // Not already closed by Close
+			delete(h.subs, c)
+			close(c)
+		}
+	}
+}
+
+// Close stops every spectator.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.subs {
+		delete(h.subs, c)
+		close(c)
+	}
+}
+
+// Follow starts writing the game's events to w as JSON lines, the same as
+// blackjack.JSONEvents, until the hub is closed or writing fails. It is the
+// local spectator, e.g. for another terminal or a named pipe. The returned
+// function waits for it to finish.
+func (h *Hub) Follow(w io.Writer) (wait func() error) {
+	events, stop := h.Subscribe()
+	done := make(chan error, 1)
+	go func() {
+		defer stop()
+		enc := json.NewEncoder(w)
+		for e := range events {
+			if err := enc.Encode(e); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	return func() error { return <-done }
+}
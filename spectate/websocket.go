@@ -0,0 +1,142 @@
+package spectate
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// websocketGUID is the key suffix RFC 6455 has servers hash to accept a handshake.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket frame opcodes.
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xA
+)
+
+// ServeHTTP upgrades the request to a WebSocket and sends the spectator every
+// event as a text message of JSON until either side goes away. Anything the
+// spectator sends but a close or a ping is ignored: spectators only watch.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Header.Get("Upgrade") != "websocket" || key == "" {
+		http.Error(w, "Spectators connect with a WebSocket", http.StatusUpgradeRequired)
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "Can't take over the connection", http.StatusInternalServerError)
+		return
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	rw.WriteString("Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+	if rw.Flush() != nil {
+		return
+	}
+
+	events, stop := h.Subscribe()
+	defer stop()
+	pongs := make(chan []byte, 1)
+	go func() {
+		defer stop() // The spectator left, or broke the protocol
+		for {
+			op, payload, err := readFrame(rw.Reader)
+			if err != nil || op == opClose {
+				return
+			}
+			if op == opPing {
+				select {
+				case pongs <- payload:
+				default:
+				}
+			}
+		}
+	}()
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				writeFrame(rw.Writer, opClose, nil)
+				rw.Flush()
+				return
+			}
+			b, _ := json.Marshal(e)
+			writeFrame(rw.Writer, opText, b)
+		case p := <-pongs:
+			writeFrame(rw.Writer, opPong, p)
+		}
+		if rw.Flush() != nil {
+			return
+		}
+	}
+}
+
+// writeFrame writes a whole, unmasked message, as servers send them.
+func writeFrame(w *bufio.Writer, op byte, payload []byte) {
+	w.WriteByte(0x80 | op) // FIN: the message is in a single frame
+	switch n := len(payload); {
+	case n < 126:
+		w.WriteByte(byte(n))
+	case n <= 0xFFFF:
+		w.WriteByte(126)
+		binary.Write(w, binary.BigEndian, uint16(n))
+	default:
+		w.WriteByte(127)
+		binary.Write(w, binary.BigEndian, uint64(n))
+	}
+	w.Write(payload)
+}
+
+// maxFrame bounds what a spectator may send, since nothing it says matters.
+const maxFrame = 1 << 16
+
+// readFrame reads a frame from a client, unmasking its payload.
+func readFrame(r *bufio.Reader) (op byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(r, head[:]); err != nil {
+		return 0, nil, err
+	}
+	op = head[0] & 0x0F
+	n := uint64(head[1] & 0x7F)
+	switch n {
+	case 126:
+		var l uint16
+		err = binary.Read(r, binary.BigEndian, &l)
+		n = uint64(l)
+	case 127:
+		err = binary.Read(r, binary.BigEndian, &n)
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	if n > maxFrame {
+		return 0, nil, io.ErrShortBuffer
+	}
+	var mask [4]byte
+	if head[1]&0x80 != 0 {
+		if _, err = io.ReadFull(r, mask[:]); err != nil {
+			return 0, nil, err
+		}
+	}
+	payload = make([]byte, n)
+	if _, err = io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return op, payload, nil
+}
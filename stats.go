@@ -0,0 +1,381 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/count"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/stats"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// report plays a game and prints its statistics.
+func report(args []string) {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	decks := fs.Int("decks", 6, "number of decks used")
+	hands := fs.Int("hands", 100000, "number of hands to simulate")
+	seed := fs.Int64("seed", 0, "seed the shuffle to deal the same shoes every run (0 for random)")
+	ramp := fs.Int("ramp", 0, "bet one unit per point of Hi-Lo true count, up to this many units")
+	top := fs.Int("situations", 5, "number of situations to list for each of double, split and surrender")
+	playing := fs.String("strategy", "basic", "strategy to play, one of those the strategies command lists")
+	chartFile := fs.String("chart", "", "play a strategy chart exported by the chart command instead of basic strategy")
+	cd := fs.Bool("cd", false, "play composition-dependent basic strategy, and list its exceptions to the chart and what they gain (-strategy cd)")
+	exact := fs.Bool("exact", false, "play the best move for the exact cards left instead of basic strategy, slow (-strategy exact)")
+	shoeFile := fs.String("shoes", "", "deal the shoes in this file, written by the shoes command, instead of shuffling")
+	examples := fs.Int("examples", 5, "number of rounds, picked at random, to show in hand history notation")
+	warmUp := fs.Int64("warmup", 0, "play this many rounds before counting any, e.g. to let an adaptive AI settle")
+	warmUpShoes := fs.Int64("warmup-shoes", 0, "play this many shoes before counting any rounds")
+	from := fs.String("from", "", "start every shoe from these cards, as if the rest had been dealt: a composition like \"A:10 T:24 J:24 Q:24 K:24 ...\" or a file written by -export")
+	bias := fs.String("bias", "", "deal deliberately biased shoes, any of ten-rich:N (N twos to nines taken out), ace-poor:N (N aces taken out) and clumped:N (like cards dealt in runs of N), separated by commas")
+	export := fs.String("export", "", "write the composition of the cards left to this file the first time the hi-lo true count reaches -export-tc")
+	exportTC := fs.Float64("export-tc", 3, "true count at which -export saves the cards left")
+	beginners := fs.Bool("beginners", false, "also play the beginner strategies, "+strings.Join(beginnerStrategies, " and ")+", at the same table and compare them")
+	track := fs.String("track", "", "record the run in this experiment store, e.g. experiments.jsonl, to compare it with others later")
+	rules := presetFlags(fs)
+	printer := outputFlags(fs)
+	profile := profileFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+	defer profile()()
+
+	s := stats.Stats{Examples: stats.NewReservoir(*examples, *seed), WarmUp: *warmUp, WarmUpShoes: *warmUpShoes}
+	opts := blackjack.Options{Decks: *decks, Hands: *hands, Seed: *seed, OnRound: s.Add, Count: count.HiLo, Reuse: true}
+	if *ramp > 0 {
+		opts.Bettor = strategy.Ramp{Spread: *ramp}
+	}
+	if err := rules(&opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	var exported *exporter
+	if *export != "" {
+		exported = &exporter{Bettor: opts.Bettor, tc: *exportTC, path: *export}
+		opts.Bettor, opts.Composition = exported, true
+	}
+	if *shoeFile != "" {
+		batch, err := deck.OpenShoeBatch(*shoeFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer batch.Close()
+		if batch.Size() != len(blackjack.Classic.Shoe(opts.Decks)) {
+			fmt.Fprintf(os.Stderr, "%s holds %d-card shoes, not %d decks\n", *shoeFile, batch.Size(), opts.Decks)
+			os.Exit(2)
+		}
+		opts.Shuffler = batch.Stream(0)
+	}
+	if *from != "" {
+		c, err := loadComposition(*from)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		opts.Remaining = &c
+	}
+	if *bias != "" {
+		if err := biasShoe(&opts, *bias); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+	strategyName := *playing
+	switch {
+	case *cd:
+		strategyName = "cd"
+	case *exact:
+		strategyName = "exact"
+	}
+	named, err := strategy.Lookup(strategyName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	named.Options(&opts)
+	if *beginners && opts.Seed == 0 {
+		opts.Seed = rand.Int63() // So the beginners are dealt the same shoes
+	}
+	g := blackjack.New(opts)
+	player := named.New(strategy.Setup{Decks: opts.Decks, Out: out})
+	if *chartFile != "" {
+		strategyName = *chartFile
+		c, err := loadChart(*chartFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		player = strategy.ChartAI(c)
+	}
+	g.Play(player)
+	var beginnerStats []stats.Stats
+	if *beginners {
+		beginnerStats = playBeginners(opts, *ramp)
+	}
+	if exported != nil {
+		switch {
+		case exported.err != nil:
+			fmt.Fprintln(os.Stderr, exported.err)
+		case !exported.done:
+			fmt.Fprintf(os.Stderr, "The true count never reached %g, nothing was written to %s\n", *exportTC, *export)
+		}
+	}
+	if *track != "" {
+		r, err := trackRun(*track, fs, strategyName, *seed, s)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer out.Printf("\nRecorded as run %d in %s (config %s).\n", r.ID, *track, r.Hash)
+	}
+
+	pct := func(n, of int64) string {
+		return fmt.Sprintf("%.2f%%", 100*float64(n)/float64(max(of, 1)))
+	}
+	if err := s.Check(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if s.Excluded > 0 {
+		out.Printf("Left out %d warm-up rounds.\n", s.Excluded)
+	}
+	out.Table([][]string{
+		{"Rounds played:", fmt.Sprint(s.Rounds)},
+		{"Hands played:", fmt.Sprint(s.Hands)},
+		{"Net result:", out.Money(int(s.Net))},
+		{"EV per round:", fmt.Sprintf("%s (%s of the bet)", out.Average(s.EV()), pct(s.Net, s.Wagered))},
+		{"Standard deviation:", fmt.Sprintf("%s per round (EV %s ± %s at 95%%)", out.Average(s.StdDev()), out.Average(s.EV()), out.Average(1.96*s.StdErr()))},
+		{"Hands won:", pct(s.Wins, s.Hands)},
+		{"Hands lost:", pct(s.Losses, s.Hands)},
+		{"Hands pushed:", pct(s.Pushes, s.Hands)},
+	})
+
+	if *beginners {
+		out.Println("\nBeginner strategies at the same table (± is 95%):")
+		rows := [][]string{{"Strategy", "EV per round", "Edge", "Against " + strategyName}}
+		for i, b := range beginnerStats {
+			diff, err := b.EV()-s.EV(), 1.96*math.Hypot(b.StdErr(), s.StdErr())
+			rows = append(rows, []string{
+				beginnerStrategies[i],
+				fmt.Sprintf("%s ± %s", out.Average(b.EV()), out.Average(1.96*b.StdErr())),
+				pct(-b.Net, b.Wagered),
+				fmt.Sprintf("%s ± %s", out.Average(diff), out.Average(err)),
+			})
+		}
+		out.Table(rows)
+	}
+
+	out.Println("\nMoves:")
+	var rows [][]string
+	for _, move := range []string{"hit", "stand", "double", "split", "surrender"} {
+		rows = append(rows, []string{move, fmt.Sprint(s.Moves[move]), fmt.Sprintf("%.2f per 100 rounds", 100*float64(s.Moves[move])/float64(max(s.Rounds, 1)))})
+	}
+	out.Table(rows)
+
+	out.Println("\nHands by kind (EV per hand, and its share of the EV per round):")
+	rows = rows[:0]
+	for _, k := range []struct {
+		name string
+		g    stats.Group
+	}{{"doubled", s.Doubled}, {"split", s.Split}, {"surrendered", s.Surrendered}} {
+		rows = append(rows, []string{k.name, fmt.Sprint(k.g.Hands), fmt.Sprintf("%+.2f", k.g.EV()), fmt.Sprintf("%+.2f", float64(k.g.Net)/float64(max(s.Rounds, 1)))})
+	}
+	out.Table(rows)
+
+	if s.Drawn.N > 0 {
+		out.Println("\nShoe depletion:")
+		rows = [][]string{{"Cards per round:", fmt.Sprintf("%.2f (standard deviation %.2f)", s.Drawn.Mean, s.Drawn.StdDev())}}
+		if s.Depth.N > 0 {
+			rows = append(rows, []string{"Dealt before each shuffle:", fmt.Sprintf("%.1f%% of the shoe (standard deviation %.1f%%, %d shoes)", 100*s.Depth.Mean, 100*s.Depth.StdDev(), s.Depth.N)})
+		}
+		out.Table(rows)
+	}
+
+	for _, move := range []string{"double", "split", "surrender"} {
+		situations := s.SituationsFor(move)
+		if len(situations) == 0 {
+			continue
+		}
+		out.Printf("\nMost frequent %ss:\n", move)
+		rows = rows[:0]
+		for _, c := range situations[:min(*top, len(situations))] {
+			rows = append(rows, []string{c.Name, fmt.Sprint(c.Times)})
+		}
+		out.Table(rows)
+	}
+
+	out.Printf("\nStreaks (pushes skipped; expected if rounds are independent, %.2f%% of them won):\n", 100*s.Streaks.WinRate())
+	rows = [][]string{{"length", "won", "expected", "lost", "expected"}}
+	for _, r := range s.Streaks.Table() {
+		rows = append(rows, []string{fmt.Sprint(r.Length), fmt.Sprint(r.Won), fmt.Sprintf("%.1f", r.ExpectedWon), fmt.Sprint(r.Lost), fmt.Sprintf("%.1f", r.ExpectedLost)})
+	}
+	out.Table(rows)
+
+	out.Printf("\nShoes by the highest Hi-Lo true count they reached (%d shoes):\n", s.Shoes)
+	rows = [][]string{{"max true", "shoes", "rounds", "net", "share of net", "EV per round"}}
+	groups := s.HotShoes(5)
+	for _, g := range groups {
+		label := fmt.Sprint(g.MaxTrue)
+		switch g.MaxTrue {
+		case 0:
+			label = "<= 0"
+		case len(groups) - 1:
+			label = fmt.Sprintf(">= %d", g.MaxTrue)
+		}
+		share := "-"
+		if s.Net != 0 {
+			share = fmt.Sprintf("%.1f%%", 100*float64(g.Net)/float64(s.Net))
+		}
+		rows = append(rows, []string{label, pct(g.Shoes, s.Shoes), fmt.Sprint(g.Rounds), out.Money(int(g.Net)), share, fmt.Sprintf("%+.2f", g.EV())})
+	}
+	out.Table(rows)
+
+	out.Println("\nRound results:")
+	rows = [][]string{{"net", "rounds", ""}}
+	for _, v := range s.Distribution.Values() {
+		rows = append(rows, []string{out.Money(v), fmt.Sprint(s.Distribution[v]), pct(s.Distribution[v], s.Rounds)})
+	}
+	out.Table(rows)
+
+	if *examples > 0 {
+		out.Println("\nSample rounds:")
+		for _, line := range s.Examples.Lines {
+			out.Println(line)
+		}
+	}
+
+	if strategyName == "cd" {
+		exceptions := strategy.CDExceptions(g.Rules())
+		out.Printf("\nComposition-dependent exceptions off the top of the shoe (%d; they gain %.4f%% of the bet per round over the chart):\n", len(exceptions), 100*strategy.CDGain(exceptions))
+		rows = [][]string{{"hand", "upcard", "chart", "play", "gain when dealt", "per round"}}
+		for _, e := range exceptions[:min(*top, len(exceptions))] {
+			rows = append(rows, []string{out.Hand(e.Hand[:]), out.Card(e.Up), e.Basic.String(), e.Move.String(), fmt.Sprintf("%+.4f", e.Gain), fmt.Sprintf("%+.5f%%", 100*e.Gain*e.Odds)})
+		}
+		if len(exceptions) > 0 {
+			out.Table(rows)
+		}
+	}
+
+	ins := s.Insurance
+	out.Println("\nInsurance (measured as if taken every time):")
+	rows = [][]string{
+		{"Dealer aces:", fmt.Sprintf("%d (%s of rounds)", ins.Offered, pct(ins.Offered, s.Rounds))},
+		{"Blackjack under the ace:", fmt.Sprintf("%d (%.2f%%, 33.33%% breaks even)", ins.Won, 100*ins.WinRate())},
+		{"EV per unit insured:", fmt.Sprintf("%+.4f", ins.EV())},
+	}
+	if ins.Taken > 0 {
+		rows = append(rows, []string{"Insured by the strategy:", fmt.Sprintf("%d of the aces, %s on the bets", ins.Taken, out.Money(int(ins.Net)))})
+	}
+	out.Table(rows)
+}
+
+// beginnerStrategies are the strategies stats -beginners compares with: the
+// plans most beginners come to the table with.
+var beginnerStrategies = []string{"mimic-dealer", "never-bust"}
+
+// playBeginners plays each of the beginner strategies in a game of its own
+// under opts, betting flat or on the ramp, and returns how they did.
+func playBeginners(opts blackjack.Options, ramp int) []stats.Stats {
+	results := make([]stats.Stats, len(beginnerStrategies))
+	var wg sync.WaitGroup
+	for i, name := range beginnerStrategies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			named, err := strategy.Lookup(name)
+			if err != nil {
+				panic(err)
+			}
+			o := opts
+			o.OnRound, o.Bettor = results[i].Add, nil
+			if ramp > 0 {
+				o.Bettor = strategy.Ramp{Spread: ramp}
+			}
+			named.Options(&o)
+			g := blackjack.New(o)
+			g.Play(named.New(strategy.Setup{Decks: o.Decks}))
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// loadComposition reads a composition of cards, given as it's written or as
+// the name of a file holding one.
+func loadComposition(arg string) (blackjack.Composition, error) {
+	if b, err := os.ReadFile(arg); err == nil {
+		c, err := blackjack.ParseComposition(string(b))
+		if err != nil {
+			return c, fmt.Errorf("%s: %v", arg, err)
+		}
+		return c, nil
+	}
+	return blackjack.ParseComposition(arg)
+}
+
+// biasShoe applies a -bias spec to the shoes opts deals.
+func biasShoe(opts *blackjack.Options, spec string) error {
+	for _, b := range strings.Split(spec, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(b), ":")
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 {
+			return fmt.Errorf("Invalid bias %q, expected e.g. ten-rich:40", b)
+		}
+		c := blackjack.Compose(opts.Variant.Shoe(opts.Decks))
+		if opts.Remaining != nil {
+			c = *opts.Remaining
+		}
+		switch name {
+		case "ten-rich":
+			c = blackjack.TenRich(c, n)
+			opts.Remaining = &c
+		case "ace-poor":
+			c = blackjack.AcePoor(c, n)
+			opts.Remaining = &c
+		case "clumped":
+			opts.Shuffler = blackjack.Clumped{Run: n}
+		default:
+			return fmt.Errorf("Unknown bias %q, try ten-rich, ace-poor or clumped", name)
+		}
+	}
+	return nil
+}
+
+// exporter bets like Bettor, or the table minimum if it's nil, and writes the
+// composition of the cards left to path the first time the true count reaches
+// tc, for stats -from to pick up.
+type exporter struct {
+	blackjack.Bettor
+	tc   float64
+	path string
+	done bool
+	err  error
+}
+
+func (e *exporter) Bet(ctx blackjack.BetContext) int {
+	if !e.done && ctx.Counted && ctx.TrueCount >= e.tc {
+		e.done = true
+		e.err = os.WriteFile(e.path, []byte(ctx.Composition.String()+"\n"), 0o644)
+	}
+	if e.Bettor == nil {
+		return ctx.MinBet
+	}
+	return e.Bettor.Bet(ctx)
+}
+
+// Clone returns an exporter that has already exported, so branches never
+// write the file, betting like a copy of Bettor.
+func (e *exporter) Clone() blackjack.Bettor {
+	c := *e
+	c.done = true
+	if b, ok := e.Bettor.(blackjack.Cloner); ok {
+		c.Bettor = b.Clone()
+	}
+	return &c
+}
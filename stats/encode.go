@@ -0,0 +1,132 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Stats and Streaks encode to JSON and gob with everything needed to carry on
+// adding rounds or merging, so checkpoints and the results of distributed
+// runs can be saved and combined later.
+
+// encodingVersion is the version of the encoded stats.
+const encodingVersion = 1
+
+// totals is Stats without its methods, so encoding it doesn't recurse.
+type totals Stats
+
+// encodedStats is the encoded form of Stats.
+type encodedStats struct {
+	Version  int               `json:"version"`
+	Totals   totals            `json:"totals"`
+	Shoe     Shoe              `json:"shoe"`    // The shoe being dealt, its MaxTrue 0 unless Counted
+	Counted  bool              `json:"counted"` // Whether the shoe being dealt has a count; JSON has no NaN
+	Hottest  map[int]ShoeGroup `json:"hottest,omitempty"`
+	Dealt    int64             `json:"dealt"`
+	Shuffles int64             `json:"shuffles"`
+}
+
+// encoded returns the encoded form of s.
+func (s Stats) encoded() encodedStats {
+	e := encodedStats{Version: encodingVersion, Totals: totals(s), Shoe: s.shoe, Hottest: s.hottest, Dealt: s.dealt, Shuffles: s.shuffles}
+	e.Counted = !math.IsNaN(s.shoe.MaxTrue)
+	if !e.Counted {
+		e.Shoe.MaxTrue = 0
+	}
+	return e
+}
+
+// decode sets s from its encoded form.
+func (s *Stats) decode(e encodedStats) error {
+	if e.Version != encodingVersion {
+		return fmt.Errorf("Stats version %d is not supported", e.Version)
+	}
+	*s = Stats(e.Totals)
+	s.shoe, s.hottest, s.dealt, s.shuffles = e.Shoe, e.Hottest, e.Dealt, e.Shuffles
+	if !e.Counted {
+		s.shoe.MaxTrue = math.NaN()
+	}
+	return nil
+}
+
+// MarshalJSON encodes the stats as JSON.
+func (s Stats) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.encoded())
+}
+
+// UnmarshalJSON decodes stats encoded by MarshalJSON.
+func (s *Stats) UnmarshalJSON(data []byte) error {
+	var e encodedStats
+	if err := json.Unmarshal(data, &e); err != nil {
+		return fmt.Errorf("Stats: %v", err)
+	}
+	return s.decode(e)
+}
+
+// GobEncode encodes the stats for encoding/gob.
+func (s Stats) GobEncode() ([]byte, error) {
+	var b bytes.Buffer
+	err := gob.NewEncoder(&b).Encode(s.encoded())
+	return b.Bytes(), err
+}
+
+// GobDecode decodes stats encoded by GobEncode.
+func (s *Stats) GobDecode(data []byte) error {
+	var e encodedStats
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&e); err != nil {
+		return fmt.Errorf("Stats: %v", err)
+	}
+	return s.decode(e)
+}
+
+// encodedStreaks is the encoded form of Streaks.
+type encodedStreaks struct {
+	Won    []int64 `json:"won"`    // Finished winning streaks by length
+	Lost   []int64 `json:"lost"`   // Finished losing streaks by length
+	Run    int     `json:"run"`    // Streak in progress: positive for wins, negative for losses
+	Wins   int64   `json:"wins"`   // Rounds won
+	Losses int64   `json:"losses"` // Rounds lost
+}
+
+func (s Streaks) encoded() encodedStreaks {
+	return encodedStreaks{s.won, s.lost, s.run, s.wins, s.loss}
+}
+
+func (s *Streaks) decode(e encodedStreaks) {
+	*s = Streaks{won: e.Won, lost: e.Lost, run: e.Run, wins: e.Wins, loss: e.Losses}
+}
+
+// MarshalJSON encodes the streaks as JSON.
+func (s Streaks) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.encoded())
+}
+
+// UnmarshalJSON decodes streaks encoded by MarshalJSON.
+func (s *Streaks) UnmarshalJSON(data []byte) error {
+	var e encodedStreaks
+	if err := json.Unmarshal(data, &e); err != nil {
+		return err
+	}
+	s.decode(e)
+	return nil
+}
+
+// GobEncode encodes the streaks for encoding/gob.
+func (s Streaks) GobEncode() ([]byte, error) {
+	var b bytes.Buffer
+	err := gob.NewEncoder(&b).Encode(s.encoded())
+	return b.Bytes(), err
+}
+
+// GobDecode decodes streaks encoded by GobEncode.
+func (s *Streaks) GobDecode(data []byte) error {
+	var e encodedStreaks
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&e); err != nil {
+		return err
+	}
+	s.decode(e)
+	return nil
+}
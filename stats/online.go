@@ -0,0 +1,188 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/history"
+)
+
+// Moments keeps the mean and variance of a stream of values without storing
+// them, with Welford's method, which stays accurate over billions of values.
+type Moments struct {
+	N    int64   // Values seen
+	Mean float64 // Their mean
+	M2   float64 // Sum of squared differences from the mean
+}
+
+// Add counts a value.
+func (m *Moments) Add(x float64) {
+	m.N++
+	d := x - m.Mean
+	m.Mean += d / float64(m.N)
+	m.M2 += d * (x - m.Mean)
+}
+
+// Variance returns the variance of the values.
+func (m Moments) Variance() float64 {
+	if m.N < 2 {
+		return 0
+	}
+	return m.M2 / float64(m.N-1)
+}
+
+// StdDev returns the standard deviation of the values.
+func (m Moments) StdDev() float64 {
+	return math.Sqrt(m.Variance())
+}
+
+// StdErr returns the standard error of the mean.
+func (m Moments) StdErr() float64 {
+	if m.N == 0 {
+		return 0
+	}
+	return m.StdDev() / math.Sqrt(float64(m.N))
+}
+
+// Merge adds the values o has seen, as if they had been added one by one.
+func (m *Moments) Merge(o Moments) {
+	if o.N == 0 {
+		return
+	}
+	n := m.N + o.N
+	d := o.Mean - m.Mean
+	m.M2 += o.M2 + d*d*float64(m.N)*float64(o.N)/float64(n)
+	m.Mean += d * float64(o.N) / float64(n)
+	m.N = n
+}
+
+// Tally counts how many times each value came up. It only grows with the
+// number of different values, which for round results is a few dozen.
+type Tally map[int]int64
+
+// Values returns the values seen, from smallest to largest.
+func (t Tally) Values() []int {
+	values := make([]int, 0, len(t))
+	for v := range t {
+		values = append(values, v)
+	}
+	sort.Ints(values)
+	return values
+}
+
+// Percentile returns the value below which p percent of the counts fall.
+func (t Tally) Percentile(p float64) int {
+	total := int64(0)
+	for _, n := range t {
+		total += n
+	}
+	values := t.Values()
+	seen := int64(0)
+	for _, v := range values {
+		seen += t[v]
+		if float64(seen) > p/100*float64(total) {
+			return v
+		}
+	}
+	if len(values) == 0 {
+		return 0
+	}
+	return values[len(values)-1]
+}
+
+// Reservoir keeps a uniform random sample of the rounds it's shown, in hand
+// history notation, however many there are.
+type Reservoir struct {
+	Size  int        // Rounds to keep, 10 if 0
+	Seen  int        // Rounds shown
+	Lines []string   // The sample, in no particular order
+	rand  *rand.Rand // Source of randomness, seeded on first use if nil
+}
+
+// NewReservoir returns a reservoir of size rounds whose choices are repeatable
+// for a given seed.
+func NewReservoir(size int, seed int64) Reservoir {
+	return Reservoir{Size: size, rand: rand.New(rand.NewSource(seed))}
+}
+
+// Add shows the reservoir a round. Each of the rounds seen so far has the same
+// chance of being in the sample.
+func (r *Reservoir) Add(round blackjack.RoundResult) {
+	if r.Size == 0 {
+		r.Size = 10
+	}
+	if r.rand == nil {
+		r.rand = rand.New(rand.NewSource(rand.Int63()))
+	}
+	r.Seen++
+	if len(r.Lines) < r.Size {
+		r.Lines = append(r.Lines, history.Format(round))
+		return
+	}
+	if i := r.rand.Intn(r.Seen); i < r.Size {
+		r.Lines[i] = history.Format(round)
+	}
+}
+
+// Merge combines the sample of o into r, so that it's a uniform sample of the
+// rounds both were shown. Each slot is drawn from one side or the other in
+// proportion to the rounds it saw that haven't been drawn yet, and any subset
+// of a uniform sample is itself uniform. r keeps its Size; a smaller o runs out
+// of lines early, and the rest come from r.
+func (r *Reservoir) Merge(o Reservoir) {
+	if r.Size == 0 {
+		r.Size = o.Size
+	}
+	if r.Size == 0 {
+		r.Size = 10
+	}
+	if r.rand == nil {
+		r.rand = rand.New(rand.NewSource(rand.Int63()))
+	}
+	mine, theirs := r.pick(r.Lines), r.pick(o.Lines)
+	lines := make([]string, 0, min(r.Size, len(mine)+len(theirs)))
+	na, nb := r.Seen, o.Seen
+	for len(lines) < cap(lines) {
+		if len(theirs) == 0 || len(mine) > 0 && r.rand.Intn(na+nb) < na {
+			lines, mine, na = append(lines, mine[0]), mine[1:], na-1
+		} else {
+			lines, theirs, nb = append(lines, theirs[0]), theirs[1:], nb-1
+		}
+	}
+	r.Lines = lines
+	r.Seen += o.Seen
+}
+
+// pick returns lines in random order.
+func (r *Reservoir) pick(lines []string) []string {
+	lines = slices.Clone(lines)
+	r.rand.Shuffle(len(lines), func(i, j int) { lines[i], lines[j] = lines[j], lines[i] })
+	return lines
+}
+
+// sum adds x to a total. Counters that go up by one can't overflow an int64
+// in any run that finishes, but sums of bets and results can with big enough
+// amounts, so they're checked: an overflowing total stops at the limit and
+// marks the stats as overflowed.
+func (s *Stats) sum(total *int64, x int64) {
+	t, ok := add64(*total, x)
+	if !ok {
+		s.Overflow = true
+	}
+	*total = t
+}
+
+// add64 returns a+b and whether it fitted, or the limit it overflowed.
+func add64(a, b int64) (int64, bool) {
+	t := a + b
+	switch {
+	case b > 0 && t < a:
+		return math.MaxInt64, false
+	case b < 0 && t > a:
+		return math.MinInt64, false
+	}
+	return t, true
+}
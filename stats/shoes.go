@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+)
+
+// Shoe totals the rounds dealt from one shoe.
+type Shoe struct {
+	Rounds  int64   // Rounds dealt, including ones the seat sat out
+	Wagered int64   // Total of the original bets
+	Net     int64   // Total won or lost
+	MaxTrue float64 // Highest true count before a deal, NaN unless the engine keeps a count
+	Drawn   int64   // Cards taken from it
+	Left    int64   // Cards left in it after its last round
+	Whole   bool    // Whether its first round was counted, so Drawn covers all it dealt
+}
+
+// addShoe counts the round in the shoe it was dealt from. Finished shoes are
+// folded into their group, so only the one being dealt is kept.
+func (s *Stats) addShoe(r blackjack.RoundResult) {
+	if r.Shuffled || s.Shoes == 0 {
+		if s.Shoes > 0 {
+			s.finishShoe()
+			if dealt := s.shoe.Drawn + s.shoe.Left; s.shoe.Whole && r.Shuffled && dealt > 0 {
+				s.Depth.Add(float64(s.shoe.Drawn) / float64(dealt))
+			}
+		}
+		s.Shoes++
+		s.shoe = Shoe{MaxTrue: math.NaN(), Whole: r.Shuffled}
+	}
+	s.shoe.Rounds++
+	s.shoe.Drawn += int64(r.Drawn)
+	s.shoe.Left = int64(r.Left)
+	s.Drawn.Add(float64(r.Drawn))
+	s.sum(&s.shoe.Wagered, int64(r.Bet))
+	s.sum(&s.shoe.Net, int64(r.Net))
+	if r.Count != nil && !(r.Count.True <= s.shoe.MaxTrue) {
+		s.shoe.MaxTrue = r.Count.True
+	}
+}
+
+// finishShoe adds the shoe being dealt to the group for its highest count.
+func (s *Stats) finishShoe() {
+	if math.IsNaN(s.shoe.MaxTrue) {
+		return
+	}
+	if s.hottest == nil {
+		s.hottest = map[int]ShoeGroup{}
+	}
+	i := int(math.Floor(s.shoe.MaxTrue))
+	g := s.hottest[i]
+	g.MaxTrue = i
+	g.Shoes++
+	g.Rounds += s.shoe.Rounds
+	s.sum(&g.Wagered, s.shoe.Wagered)
+	s.sum(&g.Net, s.shoe.Net)
+	s.hottest[i] = g
+}
+
+// mergeShoes adds the shoes of another run of rounds. The shoe being dealt in
+// s is finished, since o's rounds come from other shoes, and o's becomes the
+// one being dealt.
+func (s *Stats) mergeShoes(o Stats) {
+	if o.Shoes == 0 {
+		return
+	}
+	if s.Shoes > 0 {
+		s.finishShoe()
+	}
+	for i, og := range o.hottest {
+		if s.hottest == nil {
+			s.hottest = map[int]ShoeGroup{}
+		}
+		g := s.hottest[i]
+		g.MaxTrue = i
+		g.Shoes += og.Shoes
+		g.Rounds += og.Rounds
+		s.sum(&g.Wagered, og.Wagered)
+		s.sum(&g.Net, og.Net)
+		s.hottest[i] = g
+	}
+	s.Shoes += o.Shoes
+	s.shoe = o.shoe
+}
+
+// HotShoes groups the shoes by the highest true count they reached, rounded
+// down, with everything at or below 0 in one group and everything at or above
+// top in another. Shoes without a count are left out.
+func (s Stats) HotShoes(top int) []ShoeGroup {
+	groups := make([]ShoeGroup, top+1)
+	for i := range groups {
+		groups[i].MaxTrue = i
+	}
+	add := func(g ShoeGroup) {
+		to := &groups[min(max(g.MaxTrue, 0), top)]
+		to.Shoes += g.Shoes
+		to.Rounds += g.Rounds
+		to.Wagered, _ = add64(to.Wagered, g.Wagered)
+		to.Net, _ = add64(to.Net, g.Net)
+	}
+	for _, g := range s.hottest {
+		add(g)
+	}
+	if s.Shoes > 0 && !math.IsNaN(s.shoe.MaxTrue) {
+		add(ShoeGroup{MaxTrue: int(math.Floor(s.shoe.MaxTrue)), Shoes: 1, Rounds: s.shoe.Rounds, Wagered: s.shoe.Wagered, Net: s.shoe.Net})
+	}
+	return groups
+}
+
+// ShoeGroup totals the shoes whose true count peaked at the same value.
+type ShoeGroup struct {
+	MaxTrue int // Highest true count reached, rounded down
+	Shoes   int64
+	Rounds  int64
+	Wagered int64
+	Net     int64
+}
+
+// EV returns the average result per round of the group's shoes.
+func (g ShoeGroup) EV() float64 {
+	if g.Rounds == 0 {
+		return 0
+	}
+	return float64(g.Net) / float64(g.Rounds)
+}
@@ -0,0 +1,312 @@
+// Package stats aggregates settled rounds into the figures of a session
+// report. Feed it every round through Options.OnRound. Nothing is kept per
+// round, so it runs for billions of hands in constant memory, and the totals
+// are int64s, checked for overflow, so the figures hold up over trillions.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/history"
+	"github.com/Scrimzay/blackjacksimulator/review"
+)
+
+// Stats is the running total of a game's rounds.
+type Stats struct {
+	Rounds    int64 // Rounds the seat played
+	Hands     int64 // Hands played, splits included
+	Wagered   int64 // Total of the original bets
+	Net       int64 // Total won or lost
+	Wins      int64 // Hands won
+	Losses    int64 // Hands lost
+	Pushes    int64 // Hands pushed
+	Insurance Insurance
+	Overflow  bool // Whether a total overflowed, in which case it stopped at the limit and the figures can't be trusted
+
+	Moves       map[string]int64            // Times each move was made
+	Situations  map[string]map[string]int64 // Times each move was made per situation, as named by review.Decision.Situation
+	Doubled     Group                       // Hands that were doubled
+	Split       Group                       // Hands that came out of a split
+	Surrendered Group                       // Hands that were surrendered
+	Streaks     Streaks                     // Runs of won and lost rounds
+	Shoes       int64                       // Shoes dealt from
+
+	Results      Moments   // Net result of every round, for its spread
+	Distribution Tally     // Rounds by net result
+	Examples     Reservoir // A random sample of the rounds played
+	Drawn        Moments   // Cards taken from the shoe per round, rounds sat out included
+	Depth        Moments   // Fraction of each shoe dealt before it was reshuffled, for shoes seen from the start
+
+	WarmUp      int64 // Rounds played before any are counted, e.g. while an adaptive AI calibrates
+	WarmUpShoes int64 // Shoes played before any rounds are counted; with WarmUp, both must pass
+	Excluded    int64 // Rounds left out as warm-up
+
+	shoe     Shoe              // The shoe being dealt
+	hottest  map[int]ShoeGroup // Finished shoes by highest true count, rounded down
+	dealt    int64             // Rounds added, warm-up included
+	shuffles int64             // Shoes started, warm-up included
+}
+
+// Group totals a kind of hand.
+type Group struct {
+	Hands int64 // Number of hands
+	Net   int64 // Total won or lost on them
+}
+
+// EV returns the average result per hand of the group.
+func (g Group) EV() float64 {
+	if g.Hands == 0 {
+		return 0
+	}
+	return float64(g.Net) / float64(g.Hands)
+}
+
+// Count is how often something happened.
+type Count struct {
+	Name  string
+	Times int64
+}
+
+// Insurance tracks the insurance side bet: as the bet a player would have made
+// every time the dealer showed an ace, and as the bets the seat actually took.
+type Insurance struct {
+	Offered int64 // Rounds the dealer showed an ace
+	Won     int64 // Of those, rounds the dealer had blackjack
+	Taken   int64 // Rounds the seat took insurance
+	Net     int64 // Won or lost on the insurance the seat took
+}
+
+// WinRate returns how often the dealer had blackjack under the ace.
+func (i Insurance) WinRate() float64 {
+	if i.Offered == 0 {
+		return 0
+	}
+	return float64(i.Won) / float64(i.Offered)
+}
+
+// EV returns the result per unit insured of taking insurance every time: it
+// pays 2 to 1, so it breaks even when a third of the aces hide a ten.
+func (i Insurance) EV() float64 {
+	if i.Offered == 0 {
+		return 0
+	}
+	return 3*i.WinRate() - 1
+}
+
+// Add counts a settled round, unless it's part of the warm-up.
+func (s *Stats) Add(r blackjack.RoundResult) {
+	s.dealt++
+	if r.Shuffled || s.dealt == 1 {
+		s.shuffles++
+	}
+	if s.dealt <= s.WarmUp || s.shuffles <= s.WarmUpShoes {
+		s.Excluded++
+		return
+	}
+	s.addShoe(r)
+	if !r.Seated {
+		return
+	}
+	s.Rounds++
+	s.sum(&s.Wagered, int64(r.Bet))
+	s.sum(&s.Net, int64(r.Net))
+	s.Results.Add(float64(r.Net))
+	if s.Distribution == nil {
+		s.Distribution = Tally{}
+	}
+	s.Distribution[r.Net]++
+	s.Examples.Add(r)
+	s.Streaks.add(r.Net)
+	for _, h := range r.Hands {
+		s.Hands++
+		switch {
+		case h.Net > 0:
+			s.Wins++
+		case h.Net < 0:
+			s.Losses++
+		default:
+			s.Pushes++
+		}
+		if len(r.Hands) > 1 {
+			s.addTo(&s.Split, h)
+		}
+		for _, a := range h.Actions {
+			switch a.Move {
+			case "double":
+				s.addTo(&s.Doubled, h)
+			case "surrender":
+				s.addTo(&s.Surrendered, h)
+			}
+		}
+	}
+	s.addMoves(r)
+	if len(r.Dealer) >= 2 && r.Dealer[0].Rank == deck.Ace {
+		s.Insurance.Offered++
+		if blackjack.Blackjack(r.Dealer[:2]...) {
+			s.Insurance.Won++
+		}
+	}
+	if r.Insurance > 0 {
+		s.Insurance.Taken++
+		s.sum(&s.Insurance.Net, int64(r.InsuranceNet))
+	}
+}
+
+// Merge adds the rounds counted by o, as if they had been added to s one by
+// one, so parallel workers or separate runs can be combined. The runs are
+// taken to be separate sessions: a streak or a shoe doesn't carry over from
+// one to the other. The warm-up settings of s are kept.
+func (s *Stats) Merge(o Stats) {
+	s.Rounds += o.Rounds
+	s.Hands += o.Hands
+	s.sum(&s.Wagered, o.Wagered)
+	s.sum(&s.Net, o.Net)
+	s.Wins += o.Wins
+	s.Losses += o.Losses
+	s.Pushes += o.Pushes
+	s.Insurance.Offered += o.Insurance.Offered
+	s.Insurance.Won += o.Insurance.Won
+	s.Insurance.Taken += o.Insurance.Taken
+	s.sum(&s.Insurance.Net, o.Insurance.Net)
+	s.Overflow = s.Overflow || o.Overflow
+
+	if len(o.Moves) > 0 && s.Moves == nil {
+		s.Moves = map[string]int64{}
+		s.Situations = map[string]map[string]int64{}
+	}
+	for move, n := range o.Moves {
+		s.Moves[move] += n
+	}
+	for situation, moves := range o.Situations {
+		if s.Situations[situation] == nil {
+			s.Situations[situation] = map[string]int64{}
+		}
+		for move, n := range moves {
+			s.Situations[situation][move] += n
+		}
+	}
+	for _, g := range []struct{ to, from *Group }{{&s.Doubled, &o.Doubled}, {&s.Split, &o.Split}, {&s.Surrendered, &o.Surrendered}} {
+		g.to.Hands += g.from.Hands
+		s.sum(&g.to.Net, g.from.Net)
+	}
+	s.Streaks.merge(o.Streaks)
+	s.mergeShoes(o)
+
+	s.Results.Merge(o.Results)
+	if len(o.Distribution) > 0 && s.Distribution == nil {
+		s.Distribution = Tally{}
+	}
+	for net, n := range o.Distribution {
+		s.Distribution[net] += n
+	}
+	s.Examples.Merge(o.Examples)
+	s.Drawn.Merge(o.Drawn)
+	s.Depth.Merge(o.Depth)
+
+	s.Excluded += o.Excluded
+	s.dealt += o.dealt
+	s.shuffles += o.shuffles
+}
+
+// Check cross-checks the totals, which always agree unless something went
+// wrong on the way: an overflow, a round counted in one total but not another,
+// or a running mean that has drifted from the exact net. It returns the first
+// disagreement it finds.
+func (s Stats) Check() error {
+	switch {
+	case s.Overflow:
+		return errors.New("A total overflowed, so the figures can't be trusted")
+	case s.Wins+s.Losses+s.Pushes != s.Hands:
+		return fmt.Errorf("%d hands won, %d lost and %d pushed don't add up to %d hands", s.Wins, s.Losses, s.Pushes, s.Hands)
+	case s.Results.N != s.Rounds:
+		return fmt.Errorf("%d round results kept for %d rounds", s.Results.N, s.Rounds)
+	case s.Insurance.Won > s.Insurance.Offered:
+		return fmt.Errorf("%d insurance bets won of %d offered", s.Insurance.Won, s.Insurance.Offered)
+	case s.Insurance.Taken > s.Insurance.Offered:
+		return fmt.Errorf("%d insurance bets taken of %d offered", s.Insurance.Taken, s.Insurance.Offered)
+	}
+	if s.Distribution != nil {
+		n := int64(0)
+		for _, c := range s.Distribution {
+			n += c
+		}
+		if n != s.Rounds {
+			return fmt.Errorf("%d rounds in the distribution of %d", n, s.Rounds)
+		}
+	}
+	// Welford's mean is exact to within rounding, which over a trillion
+	// rounds comes to well under a millionth of the average bet.
+	if s.Rounds > 0 {
+		bet := max(float64(s.Wagered)/float64(s.Rounds), 1)
+		if d := math.Abs(s.Results.Mean - s.EV()); d > 1e-6*bet {
+			return fmt.Errorf("Running mean %g has drifted %g from the exact EV %g", s.Results.Mean, d, s.EV())
+		}
+	}
+	return nil
+}
+
+// EV returns the average result per round.
+func (s Stats) EV() float64 {
+	if s.Rounds == 0 {
+		return 0
+	}
+	return float64(s.Net) / float64(s.Rounds)
+}
+
+// StdDev returns the standard deviation of the result of a round.
+func (s Stats) StdDev() float64 {
+	return s.Results.StdDev()
+}
+
+// StdErr returns the standard error of EV.
+func (s Stats) StdErr() float64 {
+	return s.Results.StdErr()
+}
+
+// addTo counts a hand in a group.
+func (s *Stats) addTo(g *Group, h blackjack.HandResult) {
+	g.Hands++
+	s.sum(&g.Net, int64(h.Net))
+}
+
+// addMoves counts the round's decisions, by situation for those made on two
+// cards or more.
+func (s *Stats) addMoves(r blackjack.RoundResult) {
+	if s.Moves == nil {
+		s.Moves = map[string]int64{}
+		s.Situations = map[string]map[string]int64{}
+	}
+	for _, step := range history.Steps(r) {
+		s.Moves[step.Move]++
+		if len(step.Cards) < 2 {
+			continue
+		}
+		situation := review.Decision{Hand: step.Cards, Dealer: step.Dealer}.Situation()
+		if s.Situations[situation] == nil {
+			s.Situations[situation] = map[string]int64{}
+		}
+		s.Situations[situation][step.Move]++
+	}
+}
+
+// SituationsFor returns the situations move was made in, most frequent first.
+func (s Stats) SituationsFor(move string) []Count {
+	var counts []Count
+	for situation, moves := range s.Situations {
+		if n := moves[move]; n > 0 {
+			counts = append(counts, Count{situation, n})
+		}
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Times != counts[j].Times {
+			return counts[i].Times > counts[j].Times
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	return counts
+}
@@ -0,0 +1,148 @@
+package stats
+
+import "math"
+
+// Streaks tracks runs of won and lost rounds. Pushes neither extend nor break
+// a streak.
+type Streaks struct {
+	won  []int64 // won[k] is the number of finished winning streaks of k rounds
+	lost []int64 // lost[k] is the number of finished losing streaks of k rounds
+	run  int     // Streak in progress: positive for wins, negative for losses
+	wins int64   // Rounds won
+	loss int64   // Rounds lost
+}
+
+// StreakRow compares the streaks of one length with what independent rounds
+// would produce.
+type StreakRow struct {
+	Length       int
+	Won          int64   // Winning streaks of this length
+	ExpectedWon  float64 // Winning streaks expected
+	Lost         int64   // Losing streaks of this length
+	ExpectedLost float64 // Losing streaks expected
+}
+
+// add counts a round's result.
+func (s *Streaks) add(net int) {
+	switch {
+	case net > 0:
+		s.wins++
+	case net < 0:
+		s.loss++
+	}
+	switch {
+	case net > 0 && s.run >= 0:
+		s.run++
+	case net < 0 && s.run <= 0:
+		s.run--
+	case net != 0:
+		s.finish()
+		s.run = 1
+		if net < 0 {
+			s.run = -1
+		}
+	}
+}
+
+// finish records the streak in progress.
+func (s *Streaks) finish() {
+	switch {
+	case s.run > 0:
+		s.won = grow(s.won, s.run)
+		s.won[s.run]++
+	case s.run < 0:
+		s.lost = grow(s.lost, -s.run)
+		s.lost[-s.run]++
+	}
+}
+
+// merge adds the streaks of another run of rounds. The two runs are separate
+// sessions, so the streak in progress in s ends where o's rounds begin.
+func (s *Streaks) merge(o Streaks) {
+	s.finish()
+	s.won = grow(s.won, len(o.won)-1)
+	for k, n := range o.won {
+		s.won[k] += n
+	}
+	s.lost = grow(s.lost, len(o.lost)-1)
+	for k, n := range o.lost {
+		s.lost[k] += n
+	}
+	s.run = o.run
+	s.wins += o.wins
+	s.loss += o.loss
+}
+
+func grow(counts []int64, n int) []int64 {
+	for len(counts) <= n {
+		counts = append(counts, 0)
+	}
+	return counts
+}
+
+// Won returns the number of winning streaks of each length, counting the one
+// in progress: Won()[k] is the number of streaks of k rounds.
+func (s Streaks) Won() []int64 {
+	s.won = append([]int64(nil), s.won...)
+	if s.run > 0 {
+		s.finish()
+	}
+	return s.won
+}
+
+// Lost returns the number of losing streaks of each length, like Won.
+func (s Streaks) Lost() []int64 {
+	s.lost = append([]int64(nil), s.lost...)
+	if s.run < 0 {
+		s.finish()
+	}
+	return s.lost
+}
+
+// Expected returns how many of n streaks should be k rounds long if rounds
+// are independent and each one that isn't a push continues the streak with
+// probability p: the lengths are geometric, so a fraction (1-p)p^(k-1) of
+// them.
+func Expected(n int64, p float64, k int) float64 {
+	return float64(n) * (1 - p) * math.Pow(p, float64(k-1))
+}
+
+// WinRate returns the fraction of rounds that weren't pushes that were won.
+func (s Streaks) WinRate() float64 {
+	if s.wins+s.loss == 0 {
+		return 0
+	}
+	return float64(s.wins) / float64(s.wins+s.loss)
+}
+
+// Table lines up the observed streak lengths with the geometric expectation,
+// from 1 up to the longest streak seen.
+func (s Streaks) Table() []StreakRow {
+	won, lost := s.Won(), s.Lost()
+	if len(won) == 0 && len(lost) == 0 {
+		return nil
+	}
+	nWon, nLost := total(won), total(lost)
+	p := s.WinRate()
+	rows := make([]StreakRow, max(len(won), len(lost))-1)
+	for i := range rows {
+		k := i + 1
+		rows[i] = StreakRow{Length: k, ExpectedWon: Expected(nWon, p, k), ExpectedLost: Expected(nLost, 1-p, k)}
+		if k < len(won) {
+			rows[i].Won = won[k]
+		}
+		if k < len(lost) {
+			rows[i].Lost = lost[k]
+		}
+	}
+	return rows
+}
+
+// total returns the number of streaks in counts.
+func total(counts []int64) int64 {
+	n := int64(0)
+	for _, c := range counts {
+		n += c
+	}
+	return n
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// strategies lists the strategies the -strategy flags take, by name.
+func strategies(args []string) {
+	fs := flag.NewFlagSet("strategies", flag.ExitOnError)
+	printer := outputFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+
+	var rows [][]string
+	for _, n := range strategy.Registered() {
+		rows = append(rows, []string{n.Name, n.About})
+	}
+	out.Table(rows)
+}
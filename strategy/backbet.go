@@ -0,0 +1,9 @@
+package strategy
+
+// FlatBackBettor bets the same amount behind the seat every round.
+type FlatBackBettor int
+
+// BetBehind returns the flat amount regardless of the shoe.
+func (b FlatBackBettor) BetBehind(shuffled bool) int {
+	return int(b)
+}
@@ -0,0 +1,177 @@
+package strategy
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Baselines: strategies too simple to be any good, to sanity check the engine
+// and to give comparisons a floor. All but RandomAI only ever hit or stand, by
+// their own cards, so their house edge can be worked out directly (see the
+// validate package). They all bet the minimum.
+
+// standAI stands on every hand.
+type standAI struct{}
+
+// AlwaysStand returns an AI that stands on whatever it's dealt, winning only
+// when the dealer busts or has less.
+func AlwaysStand() blackjack.AI {
+	return standAI{}
+}
+
+func (standAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return blackjack.MoveStand
+}
+
+func (standAI) Results(hands [][]deck.Card, dealer []deck.Card) {}
+
+// hitAI hits every hand.
+type hitAI struct{}
+
+// AlwaysHit returns an AI that hits every hand until it busts, naturals
+// included, so it loses all but the rounds a dealer blackjack pushes.
+func AlwaysHit() blackjack.AI {
+	return hitAI{}
+}
+
+func (hitAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return blackjack.MoveHit
+}
+
+// PlayView stands split aces the table won't let it hit.
+func (ai hitAI) PlayView(v blackjack.GameView) blackjack.Move {
+	if !v.CanHit {
+		return blackjack.MoveStand
+	}
+	return blackjack.MoveHit
+}
+
+func (hitAI) Results(hands [][]deck.Card, dealer []deck.Card) {}
+
+// mimicAI plays the dealer's rules.
+type mimicAI struct {
+	standSoft17 bool // Stand on soft 17, as the dealer does
+}
+
+// MimicDealer returns an AI that plays its hand the way the dealer must: hit
+// below 17, and soft 17 too where the dealer hits it, never doubling or
+// splitting. It busts as often as the dealer, and loses when both do.
+func MimicDealer() blackjack.AI {
+	return &mimicAI{}
+}
+
+func (ai *mimicAI) SetRules(r blackjack.Rules) {
+	ai.standSoft17 = r.StandSoft17
+}
+
+func (ai *mimicAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	if dealerStands(hand, ai.standSoft17) {
+		return blackjack.MoveStand
+	}
+	return blackjack.MoveHit
+}
+
+// PlayView stands split aces the table won't let it hit.
+func (ai *mimicAI) PlayView(v blackjack.GameView) blackjack.Move {
+	if !v.CanHit {
+		return blackjack.MoveStand
+	}
+	return ai.Play(v.Hand, v.Dealer)
+}
+
+func (ai *mimicAI) Results(hands [][]deck.Card, dealer []deck.Card) {}
+
+// dealerStands reports whether a dealer would stand on hand: on 17 or more,
+// except a soft 17 unless standSoft17.
+func dealerStands(hand []deck.Card, standSoft17 bool) bool {
+	score := blackjack.Score(hand...)
+	return score > 17 || score == 17 && (standSoft17 || !blackjack.Soft(hand...))
+}
+
+// neverBustAI never takes a card that could bust it.
+type neverBustAI struct{}
+
+// NeverBust returns an AI that never risks busting: it stands on any hard 12
+// or more and hits below that, and hits soft hands, which can't bust, up to
+// soft 17. It never doubles or splits. Leaving the dealer to bust alone is a
+// common beginner's plan, and costs about as much as mimicking the dealer:
+// around six percent, near ten times basic strategy's edge.
+func NeverBust() blackjack.AI {
+	return neverBustAI{}
+}
+
+func (neverBustAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	if neverBustStands(blackjack.Score(hand...), blackjack.Soft(hand...)) {
+		return blackjack.MoveStand
+	}
+	return blackjack.MoveHit
+}
+
+// PlayView stands split aces the table won't let it hit.
+func (ai neverBustAI) PlayView(v blackjack.GameView) blackjack.Move {
+	if !v.CanHit {
+		return blackjack.MoveStand
+	}
+	return ai.Play(v.Hand, v.Dealer)
+}
+
+func (neverBustAI) Results(hands [][]deck.Card, dealer []deck.Card) {}
+
+// neverBustStands reports whether NeverBust stands on a total.
+func neverBustStands(total int, soft bool) bool {
+	if soft {
+		return total >= 18
+	}
+	return total >= 12
+}
+
+// randomAI makes a random move out of those the table allows.
+type randomAI struct {
+	rand *rand.Rand
+}
+
+// RandomAI returns an AI that picks each move at random, with equal chances,
+// from the moves the table allows. Its moves are random unless the game
+// seeds it through Options.AISeed.
+func RandomAI() blackjack.AI {
+	return &randomAI{rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// Seed makes the moves repeatable.
+func (ai *randomAI) Seed(seed int64) {
+	ai.rand.Seed(seed)
+}
+
+func (ai *randomAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return ai.PlayView(blackjack.GameView{Hand: hand, Dealer: dealer, CanHit: true})
+}
+
+func (ai *randomAI) PlayView(v blackjack.GameView) blackjack.Move {
+	moves := []blackjack.Move{blackjack.MoveStand}
+	if v.CanHit {
+		moves = append(moves, blackjack.MoveHit)
+	}
+	if v.CanDouble {
+		moves = append(moves, blackjack.MoveDouble)
+	}
+	if v.CanSplit {
+		moves = append(moves, blackjack.MoveSplit)
+	}
+	if v.CanSurrender {
+		moves = append(moves, blackjack.MoveSurrender)
+	}
+	return moves[ai.rand.Intn(len(moves))]
+}
+
+func (ai *randomAI) Results(hands [][]deck.Card, dealer []deck.Card) {}
+
+func init() {
+	Register(Named{Name: "always-stand", About: "stands on everything, a baseline", New: func(Setup) blackjack.AI { return AlwaysStand() }})
+	Register(Named{Name: "always-hit", About: "hits every hand until it busts, a baseline", New: func(Setup) blackjack.AI { return AlwaysHit() }})
+	Register(Named{Name: "mimic-dealer", About: "plays the dealer's rules: hits to 17, never doubles or splits", New: func(Setup) blackjack.AI { return MimicDealer() }})
+	Register(Named{Name: "never-bust", About: "stands on hard 12 or more so it can't bust, never doubles or splits", New: func(Setup) blackjack.AI { return NeverBust() }})
+	Register(Named{Name: "random", About: "a random move out of those allowed, a baseline", New: func(Setup) blackjack.AI { return RandomAI() }})
+}
@@ -0,0 +1,151 @@
+// Package strategy contains the AIs (playing strategies) that can be plugged
+// into the blackjack engine.
+package strategy
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// basicAI represents a simple card-counting AI that adjusts bets and decisions
+// based on the number of high/low cards seen in the game.
+type basicAI struct {
+	score int // Running count of the card values seen
+}
+
+// BasicAI returns a card-counting AI. The engine reports how much of the shoe
+// is left at bet time, so decks is no longer used; it is kept so existing
+// callers still compile.
+func BasicAI(decks int) blackjack.AI {
+	return &basicAI{}
+}
+
+func init() {
+	Register(Named{Name: "classic", About: "the simulator's first AI: rough basic strategy, betting up when its own Hi-Lo count runs high", New: func(s Setup) blackjack.AI { return BasicAI(s.Decks) }})
+}
+
+// Bet calculates the betting amount based on the true count (score adjusted for unseen cards).
+// If the deck is shuffled, it resets the running count.
+func (bi *basicAI) Bet(ctx blackjack.BetContext) int {
+	if ctx.Shuffled {
+		bi.score = 0
+	}
+	// Calculate the true count: running count divided by the number of remaining decks
+	trueScore := float64(bi.score) / ctx.DecksLeft()
+
+	// Adjust bet size based on the true count value
+	switch {
+	case trueScore >= 14:
+		return limit(100000, ctx) // Very high confidence in a favorable deck
+	case trueScore >= 8:
+		return limit(5000, ctx) // Medium confidence
+	default:
+		return ctx.MinBet // Default minimal bet
+	}
+}
+
+// limit keeps a bet within the table limits, rounded down to one the table's
+// increment and chips allow.
+func limit(bet int, ctx blackjack.BetContext) int {
+	return ctx.Round(bet)
+}
+
+// playView asks ai for its move, through PlayView if it takes a GameView.
+// Wrappers use it to pass the view on.
+func playView(ai blackjack.AI, v blackjack.GameView) blackjack.Move {
+	if vw, ok := ai.(blackjack.Viewer); ok {
+		return vw.PlayView(v)
+	}
+	return ai.Play(v.Hand, v.Dealer)
+}
+
+// Play determines the AI's move based on basic blackjack strategy and card counting.
+func (bi *basicAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return bi.play(hand, dealer, true)
+}
+
+// play is Play, splitting pairs only if canSplit.
+func (bi *basicAI) play(hand []deck.Card, dealer deck.Card, canSplit bool) blackjack.Move {
+	score := blackjack.Score(hand...)
+
+	// If the player has two cards
+	if len(hand) == 2 {
+		// Check for pair splitting strategy
+		if canSplit && blackjack.Hand(hand).IsPair() {
+			if v := hand[0].BlackjackValue(); v == 1 || v == 8 || v == 9 {
+				return blackjack.MoveSplit // Split pairs if the value is favorable
+			}
+		}
+
+		// Double down strategy for hands with a total of 10 or 11 (excluding soft hands)
+		if score == 10 || (score == 11 && !blackjack.Soft(hand...)) {
+			return blackjack.MoveDouble
+		}
+	}
+
+	// Dealer strategy influences the decision
+	dScore := blackjack.Score(dealer)
+	if dScore >= 5 && dScore <= 6 {
+		return blackjack.MoveStand // Favorable situation, stand
+	}
+
+	// If the player's score is low, hit
+	if score < 13 {
+		return blackjack.MoveHit
+	}
+
+	// Otherwise, stand
+	return blackjack.MoveStand
+}
+
+// PlayView plays as Play does, but only makes the moves the engine allows:
+// it doesn't split past the table's limit or double where it can't.
+func (bi *basicAI) PlayView(v blackjack.GameView) blackjack.Move {
+	move := bi.play(v.Hand, v.Dealer, v.CanSplit)
+	switch move.String() {
+	case "split":
+		return move
+	case "double":
+		if v.CanDouble {
+			return move
+		}
+		return blackjack.MoveHit
+	}
+	if !v.CanHit {
+		return blackjack.MoveStand
+	}
+	return move
+}
+
+// Results processes the final hands of the round and updates the card count.
+func (bi *basicAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	// Count the dealer's cards
+	for _, card := range dealer {
+		bi.count(card)
+	}
+	// Count all player hands
+	for _, hand := range hands {
+		for _, card := range hand {
+			bi.count(card)
+		}
+	}
+}
+
+// Burned counts the burn cards when the dealer shows them.
+func (bi *basicAI) Burned(cards []deck.Card) {
+	for _, card := range cards {
+		bi.count(card)
+	}
+}
+
+// count updates the running card count based on the value of a given card.
+// - High-value cards (10, J, Q, K, A) decrease the count
+// - Low-value cards (2-6) increase the count
+func (bi *basicAI) count(card deck.Card) {
+	switch v := card.BlackjackValue(); {
+	case v == 1 || v == 10:
+		bi.score-- // High-value cards are bad for the player
+	case v <= 6:
+		bi.score++ // Low-value cards are good for the player
+	}
+}
@@ -0,0 +1,89 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+)
+
+// FlatBettor bets the same amount every round, within the table limits.
+type FlatBettor int
+
+// Bet returns the flat amount regardless of the shoe.
+func (b FlatBettor) Bet(ctx blackjack.BetContext) int {
+	return limit(int(b), ctx)
+}
+
+// Clone returns b, which has nothing to copy.
+func (b FlatBettor) Clone() blackjack.Bettor {
+	return b
+}
+
+// Ramp bets one unit per point of true count, from one unit up to Spread units,
+// using the count the engine keeps (set Options.Count). Without a count it
+// flat bets one unit.
+type Ramp struct {
+	Unit   int     // Size of a unit, the table minimum if 0
+	Spread int     // Most units bet at once, 8 if 0
+	Scale  float64 // True count points per unit, 1 if 0; smaller for counts with smaller tags
+}
+
+// Bet sizes the bet by the engine's true count.
+func (r Ramp) Bet(ctx blackjack.BetContext) int {
+	unit, spread, scale := r.Unit, r.Spread, r.Scale
+	if unit == 0 {
+		unit = ctx.MinBet
+	}
+	if spread == 0 {
+		spread = 8
+	}
+	if scale == 0 {
+		scale = 1
+	}
+	units := 1
+	if ctx.Counted {
+		units = min(max(int(math.Floor(ctx.TrueCount/scale)), 1), spread)
+	}
+	return limit(units*unit, ctx)
+}
+
+// Clone returns r, which has nothing to copy.
+func (r Ramp) Clone() blackjack.Bettor {
+	return r
+}
+
+// DoubleForLess bets like b, or the table minimum if b is nil, and doubles for
+// as much as the bankroll has left when it can't cover the whole bet, instead
+// of doubling money it doesn't have.
+func DoubleForLess(b blackjack.Bettor) blackjack.Bettor {
+	return doubleForLess{b}
+}
+
+type doubleForLess struct {
+	blackjack.Bettor
+}
+
+func (d doubleForLess) Bet(ctx blackjack.BetContext) int {
+	if d.Bettor == nil {
+		return ctx.MinBet
+	}
+	return d.Bettor.Bet(ctx)
+}
+
+// Clone copies the bettor it bets like, which must be a Cloner if set.
+func (d doubleForLess) Clone() blackjack.Bettor {
+	if d.Bettor == nil {
+		return d
+	}
+	c, ok := d.Bettor.(blackjack.Cloner)
+	if !ok {
+		panic(fmt.Sprintf("Can't clone bettor %T, which isn't a Cloner", d.Bettor))
+	}
+	return doubleForLess{c.Clone()}
+}
+
+// DoubleFor doubles for the most the bankroll allows.
+func (d doubleForLess) DoubleFor(ctx blackjack.BetContext) int {
+	return ctx.MaxBet
+}
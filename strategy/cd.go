@@ -0,0 +1,132 @@
+package strategy
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/ev"
+)
+
+// cdAI plays composition-dependent basic strategy.
+type cdAI struct {
+	*chartAI
+}
+
+// CDBasicAI returns an AI that plays composition-dependent basic strategy:
+// every decision is made from the cards in the hand and the upcard, rather
+// than from the total alone, so it finds the exceptions to the chart such as
+// standing on a three-card 16 against a ten, or hitting 10-2 against a 4. It
+// doesn't look at any other cards, so it's still basic strategy. Splits follow
+// the chart, and it bets the minimum.
+func CDBasicAI() blackjack.AI {
+	return &cdAI{chartAI: BasicStrategyAI().(*chartAI)}
+}
+
+func init() {
+	Register(Named{Name: "cd", About: "composition-dependent basic strategy, from the cards in the hand and the upcard", New: func(Setup) blackjack.AI { return CDBasicAI() }})
+}
+
+// PlayView plays the allowed move with the best expected value off the top of a
+// full shoe, given the hand and the upcard.
+func (ai *cdAI) PlayView(v blackjack.GameView) blackjack.Move {
+	if (ai.chart.Decision(v.Hand, v.Dealer) == 'P' && v.CanSplit) || !v.CanHit {
+		return ai.chartAI.PlayView(v)
+	}
+	shoe := ev.NewShoe(ai.rules).Remove(v.Hand...).Remove(v.Dealer)
+	e := ev.Hand(ai.rules, shoe, v.Hand, v.Dealer, v.Hands > 1)
+	e.Split = math.NaN()
+	if !v.CanDouble {
+		e.Double = math.NaN()
+	}
+	move, _ := e.Best()
+	return move
+}
+
+// Exception is a two-card hand that composition-dependent basic strategy plays
+// differently from the chart.
+type Exception struct {
+	Hand  [2]deck.Card
+	Up    deck.Card
+	Basic blackjack.Move // What the chart plays
+	Move  blackjack.Move // What the cards call for
+	Gain  float64        // Expected value gained per unit bet when the hand is dealt
+	Odds  float64        // Chance of being dealt the hand against the upcard
+}
+
+// CDExceptions returns the two-card exceptions to the chart for the rules, the
+// biggest contributors to the gain first. They're worked out off the top of a
+// full shoe, where a round starts most often; a few more turn up deeper in the
+// shoe and for hands of more cards, which CDBasicAI plays as well.
+func CDExceptions(r blackjack.Rules) []Exception {
+	chart := ChartFor(r)
+	full := ev.NewShoe(r)
+	n := float64(len(r.Variant.Shoe(r.Decks)))
+	var exceptions []Exception
+	for u := 1; u <= 10; u++ {
+		up := card(u)
+		for a := 1; a <= 10; a++ {
+			for b := a; b <= 10; b++ {
+				hand := []deck.Card{card(a), card(b)}
+				d := chart.Decision(hand, up)
+				if d == 'P' || blackjack.Blackjack(hand...) {
+					continue
+				}
+				s := full.Remove(up)
+				odds := float64(full[u-1]) / n * float64(s[a-1]) / (n - 1)
+				s = s.Remove(hand[0])
+				odds *= float64(s[b-1]) / (n - 2)
+				if a != b {
+					odds *= 2
+				}
+				if odds == 0 {
+					continue
+				}
+				e := ev.Hand(r, s.Remove(hand[1]), hand, up, false)
+				e.Split = math.NaN()
+				basic := decisionMove(d, r.DoubleOn.Allows(blackjack.Score(hand...)), r.Surrender)
+				move, best := e.Best()
+				if gain := best - moveEV(e, basic); move.String() != basic.String() && gain > 1e-9 {
+					exceptions = append(exceptions, Exception{
+						Hand:  [2]deck.Card{hand[0], hand[1]},
+						Up:    up,
+						Basic: basic,
+						Move:  move,
+						Gain:  gain,
+						Odds:  odds,
+					})
+				}
+			}
+		}
+	}
+	sort.Slice(exceptions, func(i, j int) bool {
+		return exceptions[i].Gain*exceptions[i].Odds > exceptions[j].Gain*exceptions[j].Odds
+	})
+	return exceptions
+}
+
+// CDGain returns the expected value composition-dependent play of two-card
+// hands adds per round, per unit bet, over the chart.
+func CDGain(exceptions []Exception) float64 {
+	gain := 0.0
+	for _, e := range exceptions {
+		gain += e.Gain * e.Odds
+	}
+	return gain
+}
+
+// moveEV returns the expected value of a move.
+func moveEV(e ev.EVs, m blackjack.Move) float64 {
+	switch m.String() {
+	case "hit":
+		return e.Hit
+	case "double":
+		return e.Double
+	case "split":
+		return e.Split
+	case "surrender":
+		return e.Surrender
+	}
+	return e.Stand
+}
@@ -0,0 +1,148 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Chart is a basic strategy chart. Each row holds one decision per dealer
+// upcard, in the order 2, 3, 4, 5, 6, 7, 8, 9, T, A. The decisions are
+//
+//	H  hit
+//	S  stand
+//	D  double, or hit if doubling isn't possible
+//	d  double, or stand if doubling isn't possible
+//	P  split
+//	R  surrender, or hit if surrendering isn't possible
+//	r  surrender, or stand if surrendering isn't possible
+//	-  don't split (pairs only): play the hand by its total
+type Chart struct {
+	Hard  map[int]string // Hard totals, 4 to 21
+	Soft  map[int]string // Soft totals, 12 to 21
+	Pairs map[int]string // Pairs by the score of one card, 2 to 11
+}
+
+// H17 is basic strategy for four or more decks where the dealer hits soft 17,
+// doubling after splits is allowed and surrender isn't.
+var H17 = Chart{
+	Hard: map[int]string{
+		4:  "HHHHHHHHHH",
+		5:  "HHHHHHHHHH",
+		6:  "HHHHHHHHHH",
+		7:  "HHHHHHHHHH",
+		8:  "HHHHHHHHHH",
+		9:  "HDDDDHHHHH",
+		10: "DDDDDDDDHH",
+		11: "DDDDDDDDDD",
+		12: "HHSSSHHHHH",
+		13: "SSSSSHHHHH",
+		14: "SSSSSHHHHH",
+		15: "SSSSSHHHHH",
+		16: "SSSSSHHHHH",
+		17: "SSSSSSSSSS",
+		18: "SSSSSSSSSS",
+		19: "SSSSSSSSSS",
+		20: "SSSSSSSSSS",
+		21: "SSSSSSSSSS",
+	},
+	Soft: map[int]string{
+		12: "HHHHHHHHHH",
+		13: "HHHDDHHHHH",
+		14: "HHHDDHHHHH",
+		15: "HHDDDHHHHH",
+		16: "HHDDDHHHHH",
+		17: "HDDDDHHHHH",
+		18: "dddddSSHHH",
+		19: "SSSSdSSSSS",
+		20: "SSSSSSSSSS",
+		21: "SSSSSSSSSS",
+	},
+	Pairs: map[int]string{
+		2:  "PPPPPP----",
+		3:  "PPPPPP----",
+		4:  "---PP-----",
+		5:  "----------",
+		6:  "PPPPP-----",
+		7:  "PPPPPP----",
+		8:  "PPPPPPPPPP",
+		9:  "PPPPP-PP--",
+		10: "----------",
+		11: "PPPPPPPPPP",
+	},
+}
+
+// column returns the column of a chart row for the dealer's upcard.
+func column(dealer deck.Card) int {
+	return blackjack.Score(dealer) - 2
+}
+
+// Decision returns the chart's decision for a hand against the dealer's
+// upcard, as one of the characters listed on Chart. Splits are only offered
+// for two cards of the same rank, as the engine only allows those.
+func (c Chart) Decision(hand []deck.Card, dealer deck.Card) byte {
+	col := column(dealer)
+	if len(hand) == 2 && hand[0].Rank == hand[1].Rank {
+		if row, ok := c.Pairs[blackjack.Score(hand[0])]; ok && row[col] == 'P' {
+			return 'P'
+		}
+	}
+	return c.totalDecision(hand, dealer)
+}
+
+// totalDecision is Decision for a hand that isn't going to be split.
+func (c Chart) totalDecision(hand []deck.Card, dealer deck.Card) byte {
+	col := column(dealer)
+	score := blackjack.Score(hand...)
+	rows := c.Hard
+	if blackjack.Soft(hand...) {
+		rows = c.Soft
+	}
+	row, ok := rows[score]
+	if !ok {
+		if score < 12 {
+			return 'H'
+		}
+		return 'S'
+	}
+	return row[col]
+}
+
+// Move returns the move the chart makes for a hand against the dealer's
+// upcard. Doubling and surrendering are only offered for two-card hands.
+func (c Chart) Move(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	first := len(hand) == 2
+	return decisionMove(c.Decision(hand, dealer), first, first)
+}
+
+// decisionMove turns a chart decision into a move, given whether the hand may
+// be doubled and surrendered.
+func decisionMove(d byte, canDouble, canSurrender bool) blackjack.Move {
+	switch d {
+	case 'H':
+		return blackjack.MoveHit
+	case 'S':
+		return blackjack.MoveStand
+	case 'P':
+		return blackjack.MoveSplit
+	case 'D', 'd':
+		if canDouble {
+			return blackjack.MoveDouble
+		}
+		if d == 'd' {
+			return blackjack.MoveStand
+		}
+		return blackjack.MoveHit
+	case 'R', 'r':
+		if canSurrender {
+			return blackjack.MoveSurrender
+		}
+		if d == 'r' {
+			return blackjack.MoveStand
+		}
+		return blackjack.MoveHit
+	default:
+		panic(fmt.Sprintf("invalid chart decision %q", d))
+	}
+}
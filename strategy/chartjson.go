@@ -0,0 +1,161 @@
+package strategy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// ChartVersion is the version of the JSON chart format written by WriteJSON.
+//
+// A chart file is a JSON object with these fields:
+//
+//	version  always 1
+//	columns  the dealer upcards the rows are indexed by, always "23456789TA"
+//	hard     rows for hard totals, keyed by total ("4" to "21")
+//	soft     rows for soft totals, keyed by total ("12" to "21")
+//	pairs    rows for pairs, keyed by the score of one card ("2" to "11")
+//
+// Each row is a string of ten decisions, one per column, using the letters
+// documented on Chart. Pair rows may only use P and -. Totals missing from
+// the file are hit below 12 and stood on from 12 up.
+const ChartVersion = 1
+
+const chartColumns = "23456789TA"
+
+type chartFile struct {
+	Version int            `json:"version"`
+	Columns string         `json:"columns"`
+	Hard    map[int]string `json:"hard"`
+	Soft    map[int]string `json:"soft"`
+	Pairs   map[int]string `json:"pairs"`
+}
+
+// WriteJSON writes the chart to w in the format described on ChartVersion.
+func (c Chart) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(chartFile{
+		Version: ChartVersion,
+		Columns: chartColumns,
+		Hard:    c.Hard,
+		Soft:    c.Soft,
+		Pairs:   c.Pairs,
+	})
+}
+
+// ReadChart reads a chart written by WriteJSON, or edited by hand, and checks
+// that it is valid.
+func ReadChart(r io.Reader) (Chart, error) {
+	var f chartFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return Chart{}, err
+	}
+	if f.Version != ChartVersion {
+		return Chart{}, fmt.Errorf("Unsupported chart version %d", f.Version)
+	}
+	if f.Columns != chartColumns {
+		return Chart{}, fmt.Errorf("Chart columns must be %q", chartColumns)
+	}
+	c := Chart{Hard: f.Hard, Soft: f.Soft, Pairs: f.Pairs}
+	return c, c.Validate()
+}
+
+// Validate checks that every row of the chart is for a possible hand and
+// holds a valid decision for each dealer upcard.
+func (c Chart) Validate() error {
+	tables := []struct {
+		name     string
+		rows     map[int]string
+		min, max int
+		allowed  string
+	}{
+		{"Hard", c.Hard, 4, 21, "HSDdRr"},
+		{"Soft", c.Soft, 12, 21, "HSDdRr"},
+		{"Pair", c.Pairs, 2, 11, "P-"},
+	}
+	for _, t := range tables {
+		for total, row := range t.rows {
+			if total < t.min || total > t.max {
+				return fmt.Errorf("%s total %d is not possible", t.name, total)
+			}
+			if len(row) != len(chartColumns) {
+				return fmt.Errorf("%s %d: row must have %d decisions", t.name, total, len(chartColumns))
+			}
+			for i := 0; i < len(row); i++ {
+				if !strings.ContainsRune(t.allowed, rune(row[i])) {
+					return fmt.Errorf("%s %d v %c: invalid decision %q", t.name, total, chartColumns[i], row[i])
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// chartAI plays a chart with a flat minimum bet.
+type chartAI struct {
+	chart    Chart
+	rules    blackjack.Rules
+	generate bool // Whether to replace the chart with one for the game's rules
+	split    bool // Whether the hands being played came from a split
+}
+
+// ChartAI returns an AI that plays the chart, betting the minimum every
+// round. Where the chart calls for a double or surrender the game's rules
+// don't allow, it plays the chart's fallback instead.
+func ChartAI(c Chart) blackjack.AI {
+	return &chartAI{chart: c, rules: blackjack.Rules{DoubleAfterSplit: true}}
+}
+
+func init() {
+	Register(Named{Name: "h17", About: "the H17 chart whatever the rules, as most printed cards are", New: func(Setup) blackjack.AI { return ChartAI(H17) }})
+}
+
+func (ai *chartAI) SetRules(r blackjack.Rules) {
+	ai.rules = r
+	if ai.generate {
+		ai.chart = ChartFor(r)
+	}
+}
+
+func (ai *chartAI) Bet(ctx blackjack.BetContext) int {
+	return ctx.MinBet
+}
+
+func (ai *chartAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	first := len(hand) == 2
+	canDouble := first && ai.rules.DoubleOn.Allows(blackjack.Score(hand...)) && (!ai.split || ai.rules.DoubleAfterSplit)
+	canSurrender := ai.rules.Surrender && !ai.split && (first || ai.rules.Variant == blackjack.SuperFun21)
+	d := ai.chart.Decision(hand, dealer)
+	if ai.split {
+		d = ai.chart.totalDecision(hand, dealer) // Split hands aren't split again
+	}
+	if d == 'P' {
+		ai.split = true
+	}
+	return decisionMove(d, canDouble, canSurrender)
+}
+
+// PlayView plays the chart knowing which moves the engine allows, so it
+// resplits where the table lets it and stands split aces that can't be hit.
+func (ai *chartAI) PlayView(v blackjack.GameView) blackjack.Move {
+	d := ai.chart.Decision(v.Hand, v.Dealer)
+	switch {
+	case d == 'P' && v.CanSplit:
+		return blackjack.MoveSplit
+	case !v.CanHit:
+		return blackjack.MoveStand
+	case d == 'P':
+		d = ai.chart.totalDecision(v.Hand, v.Dealer)
+	}
+	canSurrender := ai.rules.Surrender && v.Hands == 1 && (len(v.Hand) == 2 || ai.rules.Variant == blackjack.SuperFun21)
+	return decisionMove(d, v.CanDouble, canSurrender)
+}
+
+func (ai *chartAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	ai.split = false
+}
@@ -0,0 +1,99 @@
+package strategy
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/display"
+)
+
+// coachAI shows a player what their mistakes cost, by branching the round
+// from where they left basic strategy and playing it out the right way.
+type coachAI struct {
+	blackjack.AI
+	out     *display.Printer   // Where the what-ifs are told
+	advisor blackjack.AI       // Strategy the player's moves are held against
+	frozen  blackjack.Snapshot // The game at the decision being made
+	miss    *Moment            // The round's first move off the advisor's, nil if none
+	advised blackjack.Move     // What the advisor would have done instead
+}
+
+// Coach wraps a human-controlled ai so that after every round in which the
+// player strayed from basic strategy, they're told what would have happened
+// had they followed it from their first mistake on: the same shoe, played
+// the right way.
+func Coach(ai blackjack.AI, p *display.Printer) blackjack.AI {
+	return &coachAI{AI: ai, out: p, advisor: BasicStrategyAI()}
+}
+
+// Bet leaves betting to the wrapped AI.
+func (ai *coachAI) Bet(ctx blackjack.BetContext) int {
+	return blackjack.PlaceBet(ai.AI, ctx)
+}
+
+// Freeze keeps the game as it is before the decision, and passes it on to
+// the wrapped AI if it wants it too.
+func (ai *coachAI) Freeze(s blackjack.Snapshot) {
+	ai.frozen = s
+	if f, ok := ai.AI.(blackjack.Freezer); ok {
+		f.Freeze(s)
+	}
+}
+
+// Play is PlayView for the bare cards.
+func (ai *coachAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return ai.PlayView(blackjack.GameView{Hand: hand, Dealer: dealer, CanHit: true})
+}
+
+// PlayView lets the wrapped AI decide and notes the round's first move the
+// advisor wouldn't have made.
+func (ai *coachAI) PlayView(v blackjack.GameView) blackjack.Move {
+	move := playView(ai.AI, v)
+	if ai.miss == nil && ai.frozen.Mid() {
+		if advised := playView(ai.advisor, v); advised.String() != move.String() {
+			ai.miss, ai.advised = &Moment{At: ai.frozen, View: v, Played: move}, advised
+		}
+	}
+	return move
+}
+
+// Settled tells the wrapped AI how the round went, then the player how it
+// would have gone.
+func (ai *coachAI) Settled(r blackjack.RoundResult) {
+	if rw, ok := ai.AI.(blackjack.RoundWatcher); ok {
+		rw.Settled(r)
+	}
+	if m := ai.miss; m != nil {
+		alt := m.At.Branch(ai.advisor, ai.advised)
+		ai.out.Println(ai.out.T(display.MsgWhatIf, ai.out.Move(ai.advised), ai.out.Move(m.Played),
+			ai.out.Hand(m.View.Hand), ai.out.Card(m.View.Dealer), ai.out.Money(alt.Net), ai.out.Money(r.Net)))
+	}
+	ai.miss, ai.frozen = nil, blackjack.Snapshot{}
+}
+
+// SetRules passes the table rules on to the wrapped AI and the advisor.
+func (ai *coachAI) SetRules(r blackjack.Rules) {
+	for _, a := range []blackjack.AI{ai.AI, ai.advisor} {
+		if rt, ok := a.(blackjack.RulesTaker); ok {
+			rt.SetRules(r)
+		}
+	}
+}
+
+// Count passes the engine's count on to the wrapped AI, if it wants it.
+func (ai *coachAI) Count(c blackjack.Count) {
+	if cw, ok := ai.AI.(blackjack.CountWatcher); ok {
+		cw.Count(c)
+	}
+}
+
+// Burned passes shown burn cards on to the wrapped AI.
+func (ai *coachAI) Burned(cards []deck.Card) {
+	if bw, ok := ai.AI.(blackjack.BurnWatcher); ok {
+		bw.Burned(cards)
+	}
+}
+
+// Insure leaves insurance to the wrapped AI.
+func (ai *coachAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return blackjack.TakeInsurance(ai.AI, hand, dealer)
+}
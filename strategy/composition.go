@@ -0,0 +1,56 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/ev"
+)
+
+// compositionAI plays the move with the best expected value for the cards left.
+type compositionAI struct {
+	*chartAI
+	shoe ev.Shoe // The unseen cards before the decision, empty until the engine shows them
+}
+
+// CompositionAI returns an AI that works out the expected value of every move
+// from the exact composition of the unseen cards, as the engine shows it with
+// Options.Composition, and plays the best one allowed. It's composition-dependent
+// strategy taken as far as it goes, and slow for it. Without the composition it
+// plays basic strategy, and it always bets the minimum.
+func CompositionAI() blackjack.AI {
+	return &compositionAI{chartAI: BasicStrategyAI().(*chartAI)}
+}
+
+func init() {
+	Register(Named{Name: "exact", About: "the best move for the exact cards left (slow)", Composition: true, New: func(Setup) blackjack.AI { return CompositionAI() }})
+}
+
+// Composition takes the unseen cards before a decision.
+func (ai *compositionAI) Composition(c blackjack.Composition) {
+	ai.shoe = ev.FromComposition(c)
+}
+
+// PlayView plays the allowed move with the best expected value.
+func (ai *compositionAI) PlayView(v blackjack.GameView) blackjack.Move {
+	if ai.shoe == (ev.Shoe{}) {
+		return ai.chartAI.PlayView(v)
+	}
+	e := ev.Hand(ai.rules, ai.shoe, v.Hand, v.Dealer, v.Hands > 1)
+	if !v.CanHit {
+		e.Hit = math.NaN()
+	}
+	if !v.CanDouble {
+		e.Double = math.NaN()
+	}
+	if !v.CanSplit {
+		e.Split = math.NaN()
+	}
+	move, _ := e.Best()
+	return move
+}
+
+func (ai *compositionAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	ai.shoe = ev.Shoe{}
+}
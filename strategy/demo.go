@@ -0,0 +1,98 @@
+package strategy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/display"
+)
+
+// demoAI narrates another AI's game step by step for presentations.
+type demoAI struct {
+	blackjack.AI
+	out   *display.Printer // Where the narration is written
+	delay time.Duration    // Pause after each step
+	round int              // Number of the current round
+}
+
+// Demo wraps ai so that every bet, decision and result is printed through p,
+// pausing for delay after each step so an audience can follow along.
+func Demo(ai blackjack.AI, p *display.Printer, delay time.Duration) blackjack.AI {
+	return &demoAI{AI: ai, out: p, delay: delay}
+}
+
+// step prints a line of narration and waits.
+func (ai *demoAI) step(line string) {
+	ai.out.Println(line)
+	time.Sleep(ai.delay)
+}
+
+// Bet announces the round and the wrapped AI's bet.
+func (ai *demoAI) Bet(ctx blackjack.BetContext) int {
+	ai.round++
+	ai.out.Println()
+	ai.out.Println(ai.out.T(display.MsgRound, ai.round))
+	if ctx.Shuffled {
+		ai.step(ai.out.T(display.MsgDealerShuffles))
+	}
+	bet := blackjack.PlaceBet(ai.AI, ctx)
+	ai.step(ai.out.T(display.MsgBet, ai.out.Amount(bet)))
+	return bet
+}
+
+// Count passes the engine's count on to the wrapped AI, if it wants it.
+func (ai *demoAI) Count(c blackjack.Count) {
+	if cw, ok := ai.AI.(blackjack.CountWatcher); ok {
+		cw.Count(c)
+	}
+}
+
+// Burned passes shown burn cards on to the wrapped AI.
+func (ai *demoAI) Burned(cards []deck.Card) {
+	if bw, ok := ai.AI.(blackjack.BurnWatcher); ok {
+		bw.Burned(cards)
+	}
+}
+
+// Play shows the situation and the wrapped AI's decision.
+func (ai *demoAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	ai.step(ai.out.T(display.MsgVersus, ai.out.Hand(hand), ai.out.Card(dealer)))
+	move := ai.AI.Play(hand, dealer)
+	ai.step("  -> " + ai.out.Move(move))
+	return move
+}
+
+// PlayView is Play for a wrapped AI that takes a GameView.
+func (ai *demoAI) PlayView(v blackjack.GameView) blackjack.Move {
+	ai.step(ai.out.T(display.MsgVersus, ai.out.Hand(v.Hand), ai.out.Card(v.Dealer)))
+	move := playView(ai.AI, v)
+	ai.step("  -> " + ai.out.Move(move))
+	return move
+}
+
+// Results shows the final hands.
+func (ai *demoAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	for _, h := range hands {
+		ai.step(ai.out.T(display.MsgFinalHand, ai.out.Hand(h)))
+	}
+	ai.step(ai.out.T(display.MsgDealer) + " " + ai.out.Hand(dealer))
+	ai.AI.Results(hands, dealer)
+}
+
+// Settled shows how each hand was settled, and tells the wrapped AI if it
+// wants to know.
+func (ai *demoAI) Settled(r blackjack.RoundResult) {
+	for _, h := range r.Hands {
+		ai.step(fmt.Sprintf("  %s: %s", ai.out.Outcome(h), ai.out.Money(h.Net)))
+	}
+	if rw, ok := ai.AI.(blackjack.RoundWatcher); ok {
+		rw.Settled(r)
+	}
+}
+
+// Insure leaves insurance to the wrapped AI.
+func (ai *demoAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return blackjack.TakeInsurance(ai.AI, hand, dealer)
+}
@@ -0,0 +1,68 @@
+package strategy
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// drillAI plays another AI but is dealt the same starting cards every round.
+type drillAI struct {
+	blackjack.AI
+	player []deck.Card // Player's forced starting cards
+	dealer []deck.Card // Dealer's forced upcard and hole card
+}
+
+// Drill wraps ai so that every round starts with the given player cards and
+// dealer upcard (and hole card, if dealer has two), e.g. 16 against a ten
+// over and over. Cards not given are drawn from the shoe as usual.
+func Drill(ai blackjack.AI, player, dealer []deck.Card) blackjack.AI {
+	return &drillAI{AI: ai, player: player, dealer: dealer}
+}
+
+// Force returns the drilled cards.
+func (ai *drillAI) Force() (player, dealer []deck.Card) {
+	return ai.player, ai.dealer
+}
+
+// PlayView passes the view on to the wrapped AI.
+func (ai *drillAI) PlayView(v blackjack.GameView) blackjack.Move {
+	return playView(ai.AI, v)
+}
+
+// Bet leaves betting to the wrapped AI.
+func (ai *drillAI) Bet(ctx blackjack.BetContext) int {
+	return blackjack.PlaceBet(ai.AI, ctx)
+}
+
+// SetRules passes the table rules on to the wrapped AI, if it wants them.
+func (ai *drillAI) SetRules(r blackjack.Rules) {
+	if rt, ok := ai.AI.(blackjack.RulesTaker); ok {
+		rt.SetRules(r)
+	}
+}
+
+// Count passes the engine's count on to the wrapped AI, if it wants it.
+func (ai *drillAI) Count(c blackjack.Count) {
+	if cw, ok := ai.AI.(blackjack.CountWatcher); ok {
+		cw.Count(c)
+	}
+}
+
+// Burned passes shown burn cards on to the wrapped AI.
+func (ai *drillAI) Burned(cards []deck.Card) {
+	if bw, ok := ai.AI.(blackjack.BurnWatcher); ok {
+		bw.Burned(cards)
+	}
+}
+
+// Composition passes the unseen cards on to the wrapped AI, if it wants them.
+func (ai *drillAI) Composition(c blackjack.Composition) {
+	if cw, ok := ai.AI.(blackjack.CompositionWatcher); ok {
+		cw.Composition(c)
+	}
+}
+
+// Insure leaves insurance to the wrapped AI.
+func (ai *drillAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return blackjack.TakeInsurance(ai.AI, hand, dealer)
+}
@@ -0,0 +1,160 @@
+package strategy
+
+import (
+	"math"
+	"sync"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/ev"
+)
+
+var charts sync.Map // Generated charts by blackjack.Rules
+
+// ChartFor returns the basic strategy chart for the rules, generated from the
+// expected value of every decision off the top of a full shoe. Each total is
+// decided by averaging over the two-card hands that make it, weighted by how
+// likely they are. Charts are cached, so asking again for the same rules is
+// cheap.
+func ChartFor(r blackjack.Rules) Chart {
+	if c, ok := charts.Load(r); ok {
+		return c.(Chart)
+	}
+	c := generate(r)
+	charts.Store(r, c)
+	return c
+}
+
+func generate(r blackjack.Rules) Chart {
+	shoe := ev.NewShoe(r)
+	c := Chart{Hard: map[int]string{}, Soft: map[int]string{}, Pairs: map[int]string{}}
+	for total := 4; total <= 21; total++ {
+		c.Hard[total] = row(func(up deck.Card) byte {
+			return decide(r, shoe, up, hardHands(total))
+		})
+	}
+	for total := 12; total <= 21; total++ {
+		c.Soft[total] = row(func(up deck.Card) byte {
+			return decide(r, shoe, up, [][2]int{{1, total - 11}})
+		})
+	}
+	for v := 2; v <= 11; v++ {
+		pair := card(v % 10)
+		if v == 11 {
+			pair = card(1)
+		}
+		c.Pairs[v] = row(func(up deck.Card) byte {
+			e := ev.Hand(r, shoe.Remove(pair, pair, up), []deck.Card{pair, pair}, up, false)
+			if e.Split > others(e) {
+				return 'P'
+			}
+			return '-'
+		})
+	}
+	return c
+}
+
+// row builds a chart row from the decision for each upcard.
+func row(decide func(up deck.Card) byte) string {
+	b := make([]byte, 0, len(chartColumns))
+	for _, v := range []int{2, 3, 4, 5, 6, 7, 8, 9, 10, 1} {
+		b = append(b, decide(card(v)))
+	}
+	return string(b)
+}
+
+// card returns a card of the given value, 1 for an ace.
+func card(v int) deck.Card {
+	if v == 0 {
+		v = 10
+	}
+	return deck.Card{Rank: deck.Rank(v)}
+}
+
+// hardHands returns the two-card hands without an ace that make total, or a
+// ten and the rest for totals no two cards make.
+func hardHands(total int) [][2]int {
+	var hands [][2]int
+	for a := 2; a <= 10; a++ {
+		if b := total - a; b >= a && b <= 10 {
+			hands = append(hands, [2]int{a, b})
+		}
+	}
+	if len(hands) == 0 {
+		hands = append(hands, [2]int{10, total - 10})
+	}
+	return hands
+}
+
+// decide picks the chart decision for hands of the same total against up,
+// averaging each action's expected value over the hands.
+func decide(r blackjack.Rules, shoe ev.Shoe, up deck.Card, hands [][2]int) byte {
+	var sum ev.EVs
+	weights := 0.0
+	for _, h := range hands {
+		if h[1] > 10 {
+			return 'S' // Two cards can't make hard 21, which is always stood on
+		}
+		a, b := card(h[0]), card(h[1])
+		s := shoe.Remove(up)
+		w := float64(s[h[0]-1])
+		s = s.Remove(a)
+		w *= float64(s[h[1]-1])
+		if w == 0 {
+			continue
+		}
+		e := ev.Hand(r, s.Remove(b), []deck.Card{a, b}, up, false)
+		sum.Stand += w * e.Stand
+		sum.Hit += w * e.Hit
+		sum.Double += w * e.Double
+		sum.Surrender += w * e.Surrender
+		weights += w
+	}
+
+	stand, hit := sum.Stand/weights, sum.Hit/weights
+	double, surrender := sum.Double/weights, sum.Surrender/weights
+	fallback := byte('S')
+	if hit > stand {
+		fallback = 'H'
+	}
+	best := math.Max(stand, hit)
+	switch {
+	case surrender > best && !(double > surrender):
+		if fallback == 'H' {
+			return 'R'
+		}
+		return 'r'
+	case double > best:
+		if fallback == 'H' {
+			return 'D'
+		}
+		return 'd'
+	}
+	return fallback
+}
+
+// others returns the best expected value of not splitting a pair.
+func others(e ev.EVs) float64 {
+	best := math.Max(e.Stand, e.Hit)
+	for _, v := range []float64{e.Double, e.Surrender} {
+		if !math.IsNaN(v) {
+			best = math.Max(best, v)
+		}
+	}
+	return best
+}
+
+// BasicStrategyAI returns an AI that plays perfect basic strategy for whatever
+// rules the game is played under, generating the chart with ChartFor when the
+// game starts, and betting the minimum. Until then it plays H17.
+func BasicStrategyAI() blackjack.AI {
+	return &chartAI{
+		chart:    H17,
+		rules:    blackjack.Rules{DoubleAfterSplit: true},
+		generate: true,
+	}
+}
+
+func init() {
+	Register(Named{Name: "basic", About: "perfect basic strategy for the table's rules, flat bets", New: func(Setup) blackjack.AI { return BasicStrategyAI() }})
+}
@@ -0,0 +1,150 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/display"
+	"github.com/Scrimzay/blackjacksimulator/ev"
+)
+
+// guideAI shows a player the expected value of each move they can make,
+// worked out from the exact cards left in the shoe.
+type guideAI struct {
+	blackjack.AI
+	out   *display.Printer // Where the values are shown
+	after bool             // Hold the values back until the move is made
+	rules blackjack.Rules  // Table rules the values are worked out for
+	shoe  ev.Shoe          // Cards unseen before the decision, empty until the engine says
+}
+
+// EVGuide wraps a human-controlled ai so that before every decision the
+// player is shown what each allowed move is worth, per unit bet, as the exact
+// engine works it out from the cards left. With after set the values are held
+// back until the move is made, then shown with what the choice cost against
+// the best move, to guess first and check after. The game needs
+// Options.Composition set.
+func EVGuide(ai blackjack.AI, p *display.Printer, after bool) blackjack.AI {
+	return &guideAI{AI: ai, out: p, after: after}
+}
+
+// Bet leaves betting to the wrapped AI.
+func (ai *guideAI) Bet(ctx blackjack.BetContext) int {
+	return blackjack.PlaceBet(ai.AI, ctx)
+}
+
+// Composition takes the unseen cards before a decision, and passes them on
+// to the wrapped AI if it wants them too.
+func (ai *guideAI) Composition(c blackjack.Composition) {
+	ai.shoe = ev.FromComposition(c)
+	if cw, ok := ai.AI.(blackjack.CompositionWatcher); ok {
+		cw.Composition(c)
+	}
+}
+
+// Play is PlayView for the bare cards.
+func (ai *guideAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return ai.PlayView(blackjack.GameView{Hand: hand, Dealer: dealer, CanHit: true})
+}
+
+// PlayView shows the values of the allowed moves, before or after the wrapped
+// AI picks one.
+func (ai *guideAI) PlayView(v blackjack.GameView) blackjack.Move {
+	if ai.shoe == (ev.Shoe{}) {
+		return playView(ai.AI, v)
+	}
+	e := ai.values(v)
+	if !ai.after {
+		ai.out.Println(ai.out.T(display.MsgEVs, ai.list(e)))
+		return playView(ai.AI, v)
+	}
+	move := playView(ai.AI, v)
+	ai.out.Println(ai.out.T(display.MsgEVs, ai.list(e)))
+	best, worth := e.Best()
+	if chosen := moveEV(e, move); move.String() != best.String() && !math.IsNaN(chosen) {
+		ai.out.Println(ai.out.T(display.MsgEVCost, ai.out.Move(move), chosen, ai.out.Move(best), worth, worth-chosen))
+	} else {
+		ai.out.Println(ai.out.T(display.MsgEVBest, ai.out.Move(best), worth))
+	}
+	return move
+}
+
+// values works out the expected value of each move v allows, NaN for the rest.
+func (ai *guideAI) values(v blackjack.GameView) ev.EVs {
+	e := ev.Hand(ai.rules, ai.shoe, v.Hand, v.Dealer, v.Hands > 1)
+	if !v.CanHit {
+		e.Hit = math.NaN()
+	}
+	if !v.CanDouble {
+		e.Double = math.NaN()
+	}
+	if !v.CanSplit {
+		e.Split = math.NaN()
+	}
+	if !v.CanSurrender {
+		e.Surrender = math.NaN()
+	}
+	return e
+}
+
+// list writes the values of the available moves, e.g. "stand -0.540, hit -0.475".
+func (ai *guideAI) list(e ev.EVs) string {
+	var moves []string
+	for _, m := range []blackjack.Move{blackjack.MoveStand, blackjack.MoveHit, blackjack.MoveDouble, blackjack.MoveSplit, blackjack.MoveSurrender} {
+		if v := moveEV(e, m); !math.IsNaN(v) {
+			moves = append(moves, fmt.Sprintf("%s %+.3f", ai.out.Move(m), v))
+		}
+	}
+	return strings.Join(moves, ", ")
+}
+
+// Results forgets the round's cards and passes the final hands on.
+func (ai *guideAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	ai.shoe = ev.Shoe{}
+	ai.AI.Results(hands, dealer)
+}
+
+// Settled passes the round's result on to the wrapped AI.
+func (ai *guideAI) Settled(r blackjack.RoundResult) {
+	if rw, ok := ai.AI.(blackjack.RoundWatcher); ok {
+		rw.Settled(r)
+	}
+}
+
+// Freeze passes the game before the decision on to the wrapped AI.
+func (ai *guideAI) Freeze(s blackjack.Snapshot) {
+	if f, ok := ai.AI.(blackjack.Freezer); ok {
+		f.Freeze(s)
+	}
+}
+
+// SetRules keeps the table rules for the values and passes them on to the
+// wrapped AI.
+func (ai *guideAI) SetRules(r blackjack.Rules) {
+	ai.rules = r
+	if rt, ok := ai.AI.(blackjack.RulesTaker); ok {
+		rt.SetRules(r)
+	}
+}
+
+// Count passes the engine's count on to the wrapped AI, if it wants it.
+func (ai *guideAI) Count(c blackjack.Count) {
+	if cw, ok := ai.AI.(blackjack.CountWatcher); ok {
+		cw.Count(c)
+	}
+}
+
+// Burned passes shown burn cards on to the wrapped AI.
+func (ai *guideAI) Burned(cards []deck.Card) {
+	if bw, ok := ai.AI.(blackjack.BurnWatcher); ok {
+		bw.Burned(cards)
+	}
+}
+
+// Insure leaves insurance to the wrapped AI.
+func (ai *guideAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return blackjack.TakeInsurance(ai.AI, hand, dealer)
+}
@@ -0,0 +1,90 @@
+package strategy
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// holeCardAI plays with knowledge of the dealer's hole card and falls back to
+// another AI for everything else.
+type holeCardAI struct {
+	blackjack.AI
+	hole  deck.Card // The hole card as it was read
+	known bool      // Whether the hole card was read this round
+	s17   bool      // Whether the dealer stands on soft 17
+}
+
+// HoleCardAI wraps base with hole-card strategy: once the dealer's total is known,
+// chase a pat dealer hand, stand on stiffs against a stiff dealer, and leave the
+// rest to base. Run it with Options.HoleCardReliability set.
+func HoleCardAI(base blackjack.AI) blackjack.AI {
+	return &holeCardAI{AI: base}
+}
+
+// HoleCard remembers the read for the round.
+func (ai *holeCardAI) HoleCard(card deck.Card) {
+	ai.hole = card
+	ai.known = true
+}
+
+// SetRules notes whether the dealer stands on soft 17 and passes the rules on
+// to base.
+func (ai *holeCardAI) SetRules(r blackjack.Rules) {
+	ai.s17 = r.StandSoft17
+	if rt, ok := ai.AI.(blackjack.RulesTaker); ok {
+		rt.SetRules(r)
+	}
+}
+
+// Bet leaves betting to base.
+func (ai *holeCardAI) Bet(ctx blackjack.BetContext) int {
+	return blackjack.PlaceBet(ai.AI, ctx)
+}
+
+// Play is PlayView for the bare cards, on a hand that may still be hit and,
+// with two cards, doubled.
+func (ai *holeCardAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return ai.PlayView(blackjack.GameView{Hand: hand, Dealer: dealer, CanHit: true, CanDouble: len(hand) == 2})
+}
+
+// PlayView decides against the dealer's two-card total, hitting and doubling
+// only where the table allows it and standing instead.
+func (ai *holeCardAI) PlayView(v blackjack.GameView) blackjack.Move {
+	if !ai.known {
+		return playView(ai.AI, v)
+	}
+	score := blackjack.Score(v.Hand...)
+	dScore := blackjack.Score(v.Dealer, ai.hole)
+	dealerPat := dScore >= 18 || (dScore == 17 && (ai.s17 || !blackjack.Soft(v.Dealer, ai.hole)))
+
+	switch {
+	case dealerPat:
+		// The dealer won't draw: anything short of beating them loses anyway
+		if score < dScore && v.CanHit {
+			return blackjack.MoveHit
+		}
+		return blackjack.MoveStand
+	case dScore >= 12 && dScore <= 16:
+		// The dealer is stiff and has to draw to it
+		if v.CanDouble && (score == 10 || score == 11) {
+			return blackjack.MoveDouble
+		}
+		if score >= 12 || !v.CanHit {
+			return blackjack.MoveStand
+		}
+		return blackjack.MoveHit
+	default:
+		return playView(ai.AI, v)
+	}
+}
+
+// Insure leaves insurance to base.
+func (ai *holeCardAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return blackjack.TakeInsurance(ai.AI, hand, dealer)
+}
+
+// Results forgets the read before passing the round on.
+func (ai *holeCardAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	ai.known = false
+	ai.AI.Results(hands, dealer)
+}
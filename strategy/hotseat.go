@@ -0,0 +1,74 @@
+package strategy
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/display"
+)
+
+// Seat is one of the players taking turns at a hot-seat table.
+type Seat struct {
+	Name     string // Shown when it's the player's turn
+	Bankroll int    // Money brought to the table, 0 for no limit
+	Balance  int    // Won or lost so far
+}
+
+// broke reports whether the seat can't cover a bet of min.
+func (s *Seat) broke(min int) bool {
+	return s.Bankroll > 0 && s.Bankroll+s.Balance < max(min, 1)
+}
+
+// hotSeatAI passes the seat round a group of people sharing the terminal.
+type hotSeatAI struct {
+	humanAI
+	seats  []*Seat // Players in turn order
+	up     int     // Index of the player whose round it is
+	minBet int     // Table minimum, as of the last bet
+}
+
+// HotSeat returns a human-controlled AI for several people at the same
+// terminal. Each round is played by the next of the seats in turn who can
+// still cover the table minimum, betting from their own bankroll; the seats'
+// balances are kept up to date as rounds are settled. Play stops once every
+// seat is broke.
+func HotSeat(p *display.Printer, seats []*Seat) blackjack.AI {
+	return &hotSeatAI{humanAI: humanAI{out: p, eof: new(bool)}, seats: seats, up: -1}
+}
+
+// Leave gets the table up once nobody can cover the minimum, or the input
+// has run out.
+func (ai *hotSeatAI) Leave() bool {
+	if ai.humanAI.Leave() {
+		return true
+	}
+	for _, s := range ai.seats {
+		if !s.broke(ai.minBet) {
+			return false
+		}
+	}
+	return true
+}
+
+// Bet passes the seat to the next player with money left and asks them for a
+// bet no bigger than their bankroll.
+func (ai *hotSeatAI) Bet(ctx blackjack.BetContext) int {
+	ai.minBet = ctx.MinBet
+	for range ai.seats {
+		ai.up = (ai.up + 1) % len(ai.seats)
+		if !ai.seats[ai.up].broke(ctx.MinBet) {
+			break
+		}
+	}
+	s := ai.seats[ai.up]
+	ai.out.Println(ai.out.T(display.MsgSeatUp, s.Name, ai.out.Money(s.Balance)))
+	ctx.Bankroll = s.Bankroll + s.Balance
+	if s.Bankroll > 0 && (ctx.MaxBet == 0 || ctx.Bankroll < ctx.MaxBet) {
+		ctx.MaxBet = ctx.Bankroll
+	}
+	return ai.humanAI.Bet(ctx)
+}
+
+// Settled shows how the round went and books it to the player who played it.
+func (ai *hotSeatAI) Settled(r blackjack.RoundResult) {
+	ai.seats[ai.up].Balance += r.Net
+	ai.humanAI.Settled(r)
+}
@@ -0,0 +1,185 @@
+package strategy
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/display"
+)
+
+// humanAI represents a human player, requiring user input for actions.
+type humanAI struct {
+	out *display.Printer // Where prompts and hands are shown
+	eof *bool            // Set once the input runs out, to get up from the table
+}
+
+// HumanAI initializes and returns a human-controlled AI.
+func HumanAI() blackjack.AI {
+	return HumanAIWith(display.Stdout())
+}
+
+// HumanAIWith returns a human-controlled AI that shows the game through p.
+func HumanAIWith(p *display.Printer) blackjack.AI {
+	return humanAI{out: p, eof: new(bool)}
+}
+
+func init() {
+	Register(Named{
+		Name:  "human",
+		About: "you, at the keyboard",
+		New: func(s Setup) blackjack.AI {
+			if s.Out == nil {
+				return HumanAI()
+			}
+			return HumanAIWith(s.Out)
+		},
+	})
+}
+
+// Bet prompts the player to enter their bet amount, asking again until the
+// table takes it. If the deck was shuffled, it notifies the player. If the
+// input runs out it bets the table minimum, and the player leaves before the
+// next round.
+func (ai humanAI) Bet(ctx blackjack.BetContext) int {
+	if ctx.Shuffled {
+		ai.out.Println(ai.out.T(display.MsgShuffled))
+	}
+	for {
+		if ai.out.Verbose {
+			ai.out.Println(ai.out.T(display.MsgBetPromptVerbose))
+		} else {
+			ai.out.Println(ai.out.T(display.MsgBetPrompt))
+		}
+		var bet int
+		if _, err := fmt.Fscanf(ai.out.Input(), "%d\n", &bet); err == io.EOF {
+			*ai.eof = true
+			return ctx.MinBet // Too late to leave before this round
+		}
+		err := ctx.Check(bet)
+		if err == nil {
+			return bet
+		}
+		ai.out.Println(ai.out.T(display.MsgInvalidBet, err))
+	}
+}
+
+// Count shows the engine's count before each decision, for practice. It is
+// only called when the game keeps a count.
+func (ai humanAI) Count(c blackjack.Count) {
+	if ai.out.Verbose {
+		ai.out.Println(ai.out.T(display.MsgCountVerbose, c.Running, c.True))
+	} else {
+		ai.out.Println(ai.out.T(display.MsgCount, c.Running, c.True))
+	}
+}
+
+// Burned shows the burn cards when the dealer turns them over.
+func (ai humanAI) Burned(cards []deck.Card) {
+	ai.out.Println(ai.out.T(display.MsgBurned, ai.out.Cards(cards)))
+}
+
+// Leave gets up from the table once the input has run out.
+func (ai humanAI) Leave() bool {
+	return *ai.eof
+}
+
+// Insure shows the hand against the dealer's ace and asks whether to take
+// insurance, until the player answers y or n.
+func (ai humanAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	for {
+		if ai.out.Verbose {
+			ai.out.Println(ai.out.T(display.MsgYourHand, ai.out.Hand(hand)))
+			ai.out.Println(ai.out.T(display.MsgInsureVerbose))
+		} else {
+			ai.out.Println(ai.out.T(display.MsgPlayer), ai.out.Hand(hand))
+			ai.out.Println(ai.out.T(display.MsgDealer), ai.out.Card(dealer))
+			ai.out.Println(ai.out.T(display.MsgInsure))
+		}
+		var input string
+		if _, err := fmt.Fscanf(ai.out.Input(), "%s\n", &input); err == io.EOF {
+			return false
+		}
+		switch input {
+		case "y":
+			return true
+		case "n":
+			return false
+		default:
+			ai.out.Println(ai.out.T(display.MsgInvalidOption))
+		}
+	}
+}
+
+// Play prompts the player to choose an action: hit, stand, double, or split.
+func (ai humanAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return ai.PlayView(blackjack.GameView{Hand: hand, Dealer: dealer, CanHit: true, CanDouble: true, CanSplit: true})
+}
+
+// PlayView prompts for an action until the player types one the table allows
+// on the hand, offering surrender too where it does. The hand stands if the
+// input runs out.
+func (ai humanAI) PlayView(v blackjack.GameView) blackjack.Move {
+	prompt, verbose := display.MsgActionPrompt, display.MsgActionPromptVerbose
+	if v.CanSurrender {
+		prompt, verbose = display.MsgSurrenderPrompt, display.MsgSurrenderVerbose
+	}
+	for {
+		if ai.out.Verbose {
+			ai.out.Println(ai.out.T(display.MsgYourHand, ai.out.Hand(v.Hand)))
+			ai.out.Println(ai.out.T(display.MsgDealerShows, ai.out.Card(v.Dealer)))
+			ai.out.Println(ai.out.T(verbose))
+		} else {
+			ai.out.Println(ai.out.T(display.MsgPlayer), ai.out.Hand(v.Hand))
+			ai.out.Println(ai.out.T(display.MsgDealer), ai.out.Card(v.Dealer))
+			ai.out.Println(ai.out.T(prompt))
+		}
+		var input string
+		if _, err := fmt.Fscanf(ai.out.Input(), "%s\n", &input); err == io.EOF {
+			return blackjack.MoveStand
+		}
+		switch {
+		case input == "h" && v.CanHit:
+			return blackjack.MoveHit
+		case input == "s":
+			return blackjack.MoveStand
+		case input == "d" && v.CanDouble:
+			return blackjack.MoveDouble
+		case input == "p" && v.CanSplit:
+			return blackjack.MoveSplit
+		case input == "r" && v.CanSurrender:
+			return blackjack.MoveSurrender
+		default:
+			ai.out.Println(ai.out.T(display.MsgInvalidOption))
+		}
+	}
+}
+
+// Results displays the final hands of both the player and dealer at the end of the round.
+func (ai humanAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	if ai.out.Verbose {
+		ai.out.Println(ai.out.T(display.MsgFinalHandsVerbose))
+	} else {
+		ai.out.Println(ai.out.T(display.MsgFinalHands))
+	}
+	rows := make([][]string, 0, len(hands)+1)
+	for i, h := range hands {
+		rows = append(rows, []string{ai.out.T(display.MsgPlayerHand, i+1), ai.out.Cards(h), ai.out.Total(h)})
+	}
+	rows = append(rows, []string{ai.out.T(display.MsgDealer), ai.out.Cards(dealer), ai.out.Total(dealer)})
+	ai.out.Table(rows)
+}
+
+// Settled shows how each hand was settled and what it won or lost, and the
+// insurance if it was taken.
+func (ai humanAI) Settled(r blackjack.RoundResult) {
+	rows := make([][]string, 0, len(r.Hands))
+	for i, h := range r.Hands {
+		rows = append(rows, []string{ai.out.T(display.MsgPlayerHand, i+1), ai.out.Outcome(h), ai.out.Money(h.Net)})
+	}
+	if r.Insurance > 0 {
+		rows = append(rows, []string{ai.out.T(display.MsgInsurance), "", ai.out.Money(r.InsuranceNet)})
+	}
+	ai.out.Table(rows)
+}
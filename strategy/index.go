@@ -0,0 +1,137 @@
+package strategy
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/count"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// IndexPlay is a departure from the basic strategy chart that is made once the
+// true count reaches an index.
+type IndexPlay struct {
+	Total  int     // Hard total of the hand, or the score of one card for a pair
+	Pair   bool    // Whether the play applies to a pair rather than a hard total
+	Dealer int     // Score of the dealer's upcard, 11 for an ace
+	Index  float64 // True count at which the play starts
+	Below  bool    // Play at or below the index rather than at or above it
+	Play   byte    // Decision made instead of the chart's, as in Chart
+}
+
+// Illustrious18 holds the Hi-Lo index plays for hard totals and pairs from the
+// well known Illustrious 18, less the plays H17 already makes. Its first play,
+// insurance at +3, is made by IndexAI's Insure.
+var Illustrious18 = []IndexPlay{
+	{Total: 16, Dealer: 10, Index: 0, Play: 'S'},
+	{Total: 15, Dealer: 10, Index: 4, Play: 'S'},
+	{Total: 10, Pair: true, Dealer: 5, Index: 5, Play: 'P'},
+	{Total: 10, Pair: true, Dealer: 6, Index: 4, Play: 'P'},
+	{Total: 10, Dealer: 10, Index: 4, Play: 'D'},
+	{Total: 12, Dealer: 3, Index: 2, Play: 'S'},
+	{Total: 12, Dealer: 2, Index: 3, Play: 'S'},
+	{Total: 9, Dealer: 2, Index: 1, Play: 'D'},
+	{Total: 10, Dealer: 11, Index: 3, Play: 'D'},
+	{Total: 9, Dealer: 7, Index: 3, Play: 'D'},
+	{Total: 16, Dealer: 9, Index: 5, Play: 'S'},
+	{Total: 13, Dealer: 2, Index: -1, Below: true, Play: 'H'},
+	{Total: 12, Dealer: 4, Index: -1, Below: true, Play: 'H'},
+	{Total: 12, Dealer: 5, Index: -2, Below: true, Play: 'H'},
+	{Total: 12, Dealer: 6, Index: -1, Below: true, Play: 'H'},
+	{Total: 13, Dealer: 3, Index: -2, Below: true, Play: 'H'},
+}
+
+// Applies reports whether the play is made for a hand against the dealer's
+// upcard at the given true count.
+func (ip IndexPlay) Applies(hand []deck.Card, dealer deck.Card, trueCount float64) bool {
+	if blackjack.Score(dealer) != ip.Dealer {
+		return false
+	}
+	if ip.Pair {
+		if len(hand) != 2 || hand[0].Rank != hand[1].Rank || blackjack.Score(hand[0]) != ip.Total {
+			return false
+		}
+	} else if blackjack.Soft(hand...) || blackjack.Score(hand...) != ip.Total {
+		return false
+	}
+	if ip.Below {
+		return trueCount <= ip.Index
+	}
+	return trueCount >= ip.Index
+}
+
+// DecisionAt returns the decision for a hand at the given true count: the
+// first index play that applies, or else the chart's. The second result
+// reports whether an index play was used.
+func (c Chart) DecisionAt(hand []deck.Card, dealer deck.Card, trueCount float64, plays []IndexPlay) (byte, bool) {
+	for _, ip := range plays {
+		if ip.Applies(hand, dealer, trueCount) {
+			return ip.Play, true
+		}
+	}
+	return c.Decision(hand, dealer), false
+}
+
+// MoveAt is like DecisionAt but returns the move to make, as Chart.Move does.
+func (c Chart) MoveAt(hand []deck.Card, dealer deck.Card, trueCount float64, plays []IndexPlay) (blackjack.Move, bool) {
+	d, indexed := c.DecisionAt(hand, dealer, trueCount, plays)
+	first := len(hand) == 2
+	return decisionMove(d, first, first), indexed
+}
+
+// indexAI plays basic strategy, departing from it where an index play says to
+// at the engine's true count.
+type indexAI struct {
+	*chartAI
+	plays     []IndexPlay
+	trueCount float64 // The engine's true count before the decision
+	counted   bool    // Whether the engine has shown a count
+}
+
+// IndexAI returns an AI that plays basic strategy for the game's rules and
+// makes the index plays once the true count calls for them, betting the
+// minimum. It reads the count the engine keeps (Options.Count), which has to
+// be the one the indexes are for, Hi-Lo for Illustrious18; without a count it
+// plays plain basic strategy. Index plays on hard totals aren't made on pairs
+// it splits. It takes insurance at a true count of +3 or more.
+func IndexAI(plays []IndexPlay) blackjack.AI {
+	return &indexAI{chartAI: BasicStrategyAI().(*chartAI), plays: plays}
+}
+
+func init() {
+	Register(Named{Name: "hilo-i18", About: "basic strategy plus the Illustrious 18 index plays at the Hi-Lo true count", Count: count.HiLo, New: func(Setup) blackjack.AI { return IndexAI(Illustrious18) }})
+}
+
+// Count takes the engine's count before a decision.
+func (ai *indexAI) Count(c blackjack.Count) {
+	ai.trueCount, ai.counted = c.True, true
+}
+
+// insureAt is the Hi-Lo true count from which insurance pays, the first of
+// the Illustrious 18.
+const insureAt = 3
+
+// Insure takes insurance once the true count reaches insureAt.
+func (ai *indexAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return ai.counted && ai.trueCount >= insureAt
+}
+
+// PlayView makes the first index play that applies and the table allows, or
+// else the chart's move.
+func (ai *indexAI) PlayView(v blackjack.GameView) blackjack.Move {
+	if !ai.counted || !v.CanHit {
+		return ai.chartAI.PlayView(v)
+	}
+	split := v.CanSplit && ai.chart.Decision(v.Hand, v.Dealer) == 'P'
+	for _, ip := range ai.plays {
+		if (split && !ip.Pair) || !ip.Applies(v.Hand, v.Dealer, ai.trueCount) {
+			continue
+		}
+		if ip.Play == 'P' {
+			if v.CanSplit {
+				return blackjack.MoveSplit
+			}
+			continue
+		}
+		return decisionMove(ip.Play, v.CanDouble, false)
+	}
+	return ai.chartAI.PlayView(v)
+}
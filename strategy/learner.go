@@ -0,0 +1,170 @@
+package strategy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Actions the learner chooses between, in the order its values are kept.
+var learnerMoves = [...]blackjack.Move{blackjack.MoveStand, blackjack.MoveHit, blackjack.MoveDouble}
+
+// Value is what a learner knows of one action in one situation.
+type Value struct {
+	N   int     `json:"n"`   // Times the action was taken
+	Sum float64 `json:"sum"` // Total result of the hands it was taken in, per unit bet
+}
+
+// Mean returns the average result of the action, 0 if it was never taken.
+func (v Value) Mean() float64 {
+	if v.N == 0 {
+		return 0
+	}
+	return v.Sum / float64(v.N)
+}
+
+// learnerAI learns to play by Monte Carlo control: it keeps the average result
+// of standing, hitting and doubling in every situation and plays the best of
+// them, exploring now and then.
+type learnerAI struct {
+	epsilon float64              // Chance of trying a random action
+	values  map[string]*[3]Value // By situation, as named by situation
+	rand    *rand.Rand
+}
+
+// LearnerAI returns an AI that learns a playing strategy from scratch as it
+// plays, trying a random action with probability epsilon and otherwise the one
+// that has done best so far. It never splits and bets the minimum. What it's
+// learned can be saved and loaded (blackjack.Saver and Loader), so it can be
+// trained over several runs: play -state or sessions -state.
+func LearnerAI(epsilon float64) blackjack.AI {
+	return &learnerAI{
+		epsilon: epsilon,
+		values:  map[string]*[3]Value{},
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// situation names a decision by the hand's total and the upcard, e.g. "h16v10"
+// or "s18vA".
+func situation(hand []deck.Card, up deck.Card) string {
+	kind := 'h'
+	if blackjack.Soft(hand...) {
+		kind = 's'
+	}
+	upcard := fmt.Sprint(up.BlackjackValue())
+	if up.Rank == deck.Ace {
+		upcard = "A"
+	}
+	return fmt.Sprintf("%c%dv%s", kind, blackjack.Score(hand...), upcard)
+}
+
+// Seed makes the exploration repeatable.
+func (ai *learnerAI) Seed(seed int64) {
+	ai.rand.Seed(seed)
+}
+
+func (ai *learnerAI) Bet(ctx blackjack.BetContext) int {
+	return ctx.MinBet
+}
+
+// PlayView plays the best action known for the situation, or explores.
+func (ai *learnerAI) PlayView(v blackjack.GameView) blackjack.Move {
+	if !v.CanHit {
+		return blackjack.MoveStand
+	}
+	n := 2 // Stand and hit
+	if v.CanDouble {
+		n = 3
+	}
+	if ai.rand.Float64() < ai.epsilon {
+		return learnerMoves[ai.rand.Intn(n)]
+	}
+	values := ai.values[situation(v.Hand, v.Dealer)]
+	if values == nil {
+		return ai.Play(v.Hand, v.Dealer)
+	}
+	best := 0
+	for i := 1; i < n; i++ {
+		if values[i].N > 0 && (values[best].N == 0 || values[i].Mean() > values[best].Mean()) {
+			best = i
+		}
+	}
+	return learnerMoves[best]
+}
+
+// Play is used for situations the learner hasn't met yet: it hits below 12
+// and stands otherwise, until it knows better.
+func (ai *learnerAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	if blackjack.Score(hand...) < 12 {
+		return blackjack.MoveHit
+	}
+	return blackjack.MoveStand
+}
+
+func (ai *learnerAI) Results(hands [][]deck.Card, dealer []deck.Card) {}
+
+// Settled credits every action taken in the round with the result of its hand.
+func (ai *learnerAI) Settled(r blackjack.RoundResult) {
+	if len(r.Dealer) == 0 || r.Bet == 0 {
+		return
+	}
+	for _, h := range r.Hands {
+		result := float64(h.Net) / float64(r.Bet)
+		cards := append([]deck.Card(nil), h.Start...)
+		for _, a := range h.Actions {
+			for i, m := range learnerMoves {
+				if m.String() != a.Move {
+					continue
+				}
+				key := situation(cards, r.Dealer[0])
+				if ai.values[key] == nil {
+					ai.values[key] = &[3]Value{}
+				}
+				ai.values[key][i].N++
+				ai.values[key][i].Sum += result
+			}
+			if a.Drew() {
+				cards = append(cards, a.Card)
+			}
+		}
+	}
+}
+
+// learnerState is the learner's saved state.
+type learnerState struct {
+	Version int                 `json:"version"`
+	Values  map[string][3]Value `json:"values"` // Stand, hit and double by situation
+}
+
+// Save writes what the learner has learned as JSON.
+func (ai *learnerAI) Save(w io.Writer) error {
+	s := learnerState{Version: 1, Values: make(map[string][3]Value, len(ai.values))}
+	for k, v := range ai.values {
+		s.Values[k] = *v
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// Load picks up where a saved learner left off.
+func (ai *learnerAI) Load(r io.Reader) error {
+	var s learnerState
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return fmt.Errorf("Learner state: %v", err)
+	}
+	if s.Version != 1 {
+		return fmt.Errorf("Learner state version %d is not supported", s.Version)
+	}
+	ai.values = make(map[string]*[3]Value, len(s.Values))
+	for k, v := range s.Values {
+		ai.values[k] = &v
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package strategy
+
+import (
+	"fmt"
+	"runtime/metrics"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Limits caps the resources an AI may use over a whole game.
+type Limits struct {
+	Time  time.Duration // Total time spent deciding, 0 for no limit
+	Alloc uint64        // Total bytes allocated while deciding, 0 for no limit
+}
+
+// Usage is what an AI has used so far.
+type Usage struct {
+	Decisions int           // Calls made into the AI
+	Time      time.Duration // Time spent in them
+	Alloc     uint64        // Bytes allocated in them
+}
+
+// LimitedAI accounts for the time and memory another AI uses and disqualifies
+// it once it goes over its limits, after which every decision is the safe
+// default: stand, or the table minimum for a bet. Allocations are measured
+// across the whole program while the AI decides, so they're only accurate
+// when nothing else runs at the same time, as in a single game.
+type LimitedAI struct {
+	blackjack.AI
+	limits       Limits
+	usage        Usage
+	disqualified error // Why the AI was disqualified, nil if it wasn't
+}
+
+// Limit wraps ai so that it's disqualified once it exceeds limits. Wrap it in
+// Timeout too to cut off single decisions that never return.
+func Limit(ai blackjack.AI, limits Limits) *LimitedAI {
+	return &LimitedAI{AI: ai, limits: limits}
+}
+
+// Usage returns the resources used so far.
+func (ai *LimitedAI) Usage() Usage {
+	return ai.usage
+}
+
+// Disqualified returns why the AI was disqualified, or nil if it's still in.
+func (ai *LimitedAI) Disqualified() error {
+	return ai.disqualified
+}
+
+// allocSample reads the bytes allocated by the program so far.
+var allocSample = []metrics.Sample{{Name: "/gc/heap/allocs:bytes"}}
+
+func allocated() uint64 {
+	metrics.Read(allocSample)
+	return allocSample[0].Value.Uint64()
+}
+
+// call runs f and accounts for it, reporting whether the AI is still in.
+func (ai *LimitedAI) call(f func()) bool {
+	if ai.disqualified != nil {
+		return false
+	}
+	alloc, start := allocated(), time.Now()
+	f()
+	ai.usage.Time += time.Since(start)
+	ai.usage.Alloc += allocated() - alloc
+	ai.usage.Decisions++
+	switch {
+	case ai.limits.Time > 0 && ai.usage.Time > ai.limits.Time:
+		ai.disqualified = fmt.Errorf("Used %v deciding, over the limit of %v", ai.usage.Time, ai.limits.Time)
+	case ai.limits.Alloc > 0 && ai.usage.Alloc > ai.limits.Alloc:
+		ai.disqualified = fmt.Errorf("Allocated %d bytes, over the limit of %d", ai.usage.Alloc, ai.limits.Alloc)
+	}
+	return true
+}
+
+// Bet leaves betting to the wrapped AI until it's disqualified.
+func (ai *LimitedAI) Bet(ctx blackjack.BetContext) int {
+	bet := ctx.MinBet
+	ai.call(func() { bet = blackjack.PlaceBet(ai.AI, ctx) })
+	return bet
+}
+
+// Play makes the wrapped AI's move until it's disqualified, then stands.
+func (ai *LimitedAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	move := blackjack.MoveStand
+	ai.call(func() { move = ai.AI.Play(hand, dealer) })
+	return move
+}
+
+// PlayView is Play for a wrapped AI that takes a GameView.
+func (ai *LimitedAI) PlayView(v blackjack.GameView) blackjack.Move {
+	move := blackjack.MoveStand
+	ai.call(func() { move = playView(ai.AI, v) })
+	return move
+}
+
+// Insure leaves insurance to the wrapped AI until it's disqualified, then
+// declines it.
+func (ai *LimitedAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	insure := false
+	ai.call(func() { insure = blackjack.TakeInsurance(ai.AI, hand, dealer) })
+	return insure
+}
+
+// Results shows the wrapped AI the round until it's disqualified.
+func (ai *LimitedAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	ai.call(func() { ai.AI.Results(hands, dealer) })
+}
@@ -0,0 +1,100 @@
+package strategy
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/display"
+	"github.com/Scrimzay/blackjacksimulator/ev"
+)
+
+// oddsAI shows a player how their hand stands up against the dealer's.
+type oddsAI struct {
+	blackjack.AI
+	out   *display.Printer // Where the odds are shown
+	rules blackjack.Rules  // Table rules the odds are worked out for
+	shoe  ev.Shoe          // Cards unseen before the decision, empty until the engine says
+}
+
+// StandOdds wraps a human-controlled ai so that before every decision the
+// player is told how often their hand wins, pushes and loses if they stand
+// on it, from the dealer's possible final hands under the upcard and the
+// cards left. The game needs Options.Composition set.
+func StandOdds(ai blackjack.AI, p *display.Printer) blackjack.AI {
+	return &oddsAI{AI: ai, out: p}
+}
+
+// Bet leaves betting to the wrapped AI.
+func (ai *oddsAI) Bet(ctx blackjack.BetContext) int {
+	return blackjack.PlaceBet(ai.AI, ctx)
+}
+
+// Composition takes the unseen cards before a decision, and passes them on
+// to the wrapped AI if it wants them too.
+func (ai *oddsAI) Composition(c blackjack.Composition) {
+	ai.shoe = ev.FromComposition(c)
+	if cw, ok := ai.AI.(blackjack.CompositionWatcher); ok {
+		cw.Composition(c)
+	}
+}
+
+// Play is PlayView for the bare cards.
+func (ai *oddsAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return ai.PlayView(blackjack.GameView{Hand: hand, Dealer: dealer, CanHit: true})
+}
+
+// PlayView shows the odds of standing, then lets the wrapped AI decide.
+func (ai *oddsAI) PlayView(v blackjack.GameView) blackjack.Move {
+	if ai.shoe != (ev.Shoe{}) {
+		o := ev.Dealer(ai.rules, ai.shoe, v.Dealer).Standing(ai.rules, v.Hand, v.Hands > 1)
+		ai.out.Println(ai.out.T(display.MsgStandOdds, ai.out.Total(v.Hand), ai.out.Card(v.Dealer), 100*o.Win, 100*o.Push, 100*o.Lose))
+	}
+	return playView(ai.AI, v)
+}
+
+// Results forgets the round's cards and passes the final hands on.
+func (ai *oddsAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	ai.shoe = ev.Shoe{}
+	ai.AI.Results(hands, dealer)
+}
+
+// Settled passes the round's result on to the wrapped AI.
+func (ai *oddsAI) Settled(r blackjack.RoundResult) {
+	if rw, ok := ai.AI.(blackjack.RoundWatcher); ok {
+		rw.Settled(r)
+	}
+}
+
+// Freeze passes the game before the decision on to the wrapped AI.
+func (ai *oddsAI) Freeze(s blackjack.Snapshot) {
+	if f, ok := ai.AI.(blackjack.Freezer); ok {
+		f.Freeze(s)
+	}
+}
+
+// SetRules keeps the table rules for the odds and passes them on to the
+// wrapped AI.
+func (ai *oddsAI) SetRules(r blackjack.Rules) {
+	ai.rules = r
+	if rt, ok := ai.AI.(blackjack.RulesTaker); ok {
+		rt.SetRules(r)
+	}
+}
+
+// Count passes the engine's count on to the wrapped AI, if it wants it.
+func (ai *oddsAI) Count(c blackjack.Count) {
+	if cw, ok := ai.AI.(blackjack.CountWatcher); ok {
+		cw.Count(c)
+	}
+}
+
+// Burned passes shown burn cards on to the wrapped AI.
+func (ai *oddsAI) Burned(cards []deck.Card) {
+	if bw, ok := ai.AI.(blackjack.BurnWatcher); ok {
+		bw.Burned(cards)
+	}
+}
+
+// Insure leaves insurance to the wrapped AI.
+func (ai *oddsAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return blackjack.TakeInsurance(ai.AI, hand, dealer)
+}
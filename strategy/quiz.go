@@ -0,0 +1,147 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/display"
+)
+
+// CountCheck is a player's answer to a count quiz, next to the engine's count.
+type CountCheck struct {
+	Round        int     // Round the player was asked before, from 1
+	Running      int     // The engine's running count
+	GuessRunning int     // The player's running count
+	True         float64 // The engine's true count
+	GuessTrue    float64 // The player's true count
+}
+
+// RunningRight reports whether the player had the running count exactly.
+func (c CountCheck) RunningRight() bool {
+	return c.Running == c.GuessRunning
+}
+
+// TrueRight reports whether the player's true count was within half a point,
+// as close as anyone estimates the decks left.
+func (c CountCheck) TrueRight() bool {
+	return math.Abs(c.True-c.GuessTrue) <= 0.5
+}
+
+// CountScore is the answers to a session's count quizzes.
+type CountScore []CountCheck
+
+// Accuracy returns the fraction of running counts the player had exactly.
+func (s CountScore) Accuracy() float64 {
+	return s.fraction(CountCheck.RunningRight)
+}
+
+// TrueAccuracy returns the fraction of true counts within half a point.
+func (s CountScore) TrueAccuracy() float64 {
+	return s.fraction(CountCheck.TrueRight)
+}
+
+// MeanError returns how far off the player's running count was, on average.
+func (s CountScore) MeanError() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+	total := 0
+	for _, c := range s {
+		total += max(c.Running-c.GuessRunning, c.GuessRunning-c.Running)
+	}
+	return float64(total) / float64(len(s))
+}
+
+func (s CountScore) fraction(right func(CountCheck) bool) float64 {
+	if len(s) == 0 {
+		return 1
+	}
+	n := 0
+	for _, c := range s {
+		if right(c) {
+			n++
+		}
+	}
+	return float64(n) / float64(len(s))
+}
+
+// quizAI asks the player it wraps for the count now and then.
+type quizAI struct {
+	blackjack.AI
+	out    *display.Printer // Where the questions are asked and answered
+	every  int              // Rounds between questions
+	rounds int              // Rounds bet so far
+	score  *CountScore      // Where the answers are kept
+}
+
+// CountQuiz wraps a human-controlled ai so that every few rounds, before the
+// bet, the player is asked for the running and true count and told the
+// engine's. The answers are appended to score. The game must keep a count
+// (Options.Count) for there to be anything to ask; the wrapper doesn't pass the
+// count on to ai, so the player can't just read it off.
+func CountQuiz(ai blackjack.AI, p *display.Printer, every int, score *CountScore) blackjack.AI {
+	return &quizAI{AI: ai, out: p, every: every, score: score}
+}
+
+// Bet asks for the count if it's time, then leaves betting to the wrapped AI.
+// A freshly shuffled shoe is never asked about, since its count is zero.
+func (ai *quizAI) Bet(ctx blackjack.BetContext) int {
+	ai.rounds++
+	if ctx.Counted && !ctx.Shuffled && ai.every > 0 && ai.rounds%ai.every == 0 {
+		ai.quiz(ctx)
+	}
+	return blackjack.PlaceBet(ai.AI, ctx)
+}
+
+// quiz asks for the count and scores the answer. A player who has stopped
+// typing isn't scored.
+func (ai *quizAI) quiz(ctx blackjack.BetContext) {
+	c := CountCheck{Round: ai.rounds, Running: ctx.Running, True: ctx.TrueCount}
+	ai.out.Println(ai.out.T(display.MsgQuizRunning))
+	if _, err := fmt.Fscanf(ai.out.Input(), "%d\n", &c.GuessRunning); err != nil {
+		return
+	}
+	ai.out.Println(ai.out.T(display.MsgQuizTrue))
+	if _, err := fmt.Fscanf(ai.out.Input(), "%g\n", &c.GuessTrue); err != nil {
+		return
+	}
+	*ai.score = append(*ai.score, c)
+	if c.RunningRight() && c.TrueRight() {
+		ai.out.Println(ai.out.T(display.MsgQuizRight, c.Running, c.True))
+	} else {
+		ai.out.Println(ai.out.T(display.MsgQuizWrong, c.Running, c.True))
+	}
+}
+
+// SetRules passes the table rules on to the wrapped AI, if it wants them.
+func (ai *quizAI) SetRules(r blackjack.Rules) {
+	if rt, ok := ai.AI.(blackjack.RulesTaker); ok {
+		rt.SetRules(r)
+	}
+}
+
+// Burned passes shown burn cards on to the wrapped AI.
+func (ai *quizAI) Burned(cards []deck.Card) {
+	if bw, ok := ai.AI.(blackjack.BurnWatcher); ok {
+		bw.Burned(cards)
+	}
+}
+
+// PlayView passes the view on to the wrapped AI.
+func (ai *quizAI) PlayView(v blackjack.GameView) blackjack.Move {
+	return playView(ai.AI, v)
+}
+
+// Settled tells the wrapped AI how the round went, if it wants to know.
+func (ai *quizAI) Settled(r blackjack.RoundResult) {
+	if rw, ok := ai.AI.(blackjack.RoundWatcher); ok {
+		rw.Settled(r)
+	}
+}
+
+// Insure leaves insurance to the wrapped AI.
+func (ai *quizAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return blackjack.TakeInsurance(ai.AI, hand, dealer)
+}
@@ -0,0 +1,77 @@
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/count"
+	"github.com/Scrimzay/blackjacksimulator/display"
+)
+
+// Named is a strategy registered under a name, so commands can offer every AI
+// by name without a change for each new one.
+type Named struct {
+	Name  string                     // Name it's chosen by, e.g. "basic"
+	About string                     // What it plays, in a line
+	Count count.System               // Count the engine has to keep for it (Options.Count), none if nil
+	New   func(s Setup) blackjack.AI // Returns a fresh player
+
+	Composition bool // Whether it needs the unseen cards (Options.Composition)
+}
+
+// Setup is what a registered strategy is built with.
+type Setup struct {
+	Decks int              // Number of decks in the shoe
+	Out   *display.Printer // Where an interactive strategy plays, the terminal if nil
+}
+
+var registry = map[string]Named{}
+
+// Register adds a strategy to those known by name. The built-in strategies
+// register themselves from init, and so can any other package's; registering
+// a name twice panics.
+func Register(n Named) {
+	if _, ok := registry[n.Name]; ok {
+		panic(fmt.Sprintf("strategy %q registered twice", n.Name))
+	}
+	registry[n.Name] = n
+}
+
+// Lookup returns the strategy registered under name.
+func Lookup(name string) (Named, error) {
+	n, ok := registry[name]
+	if !ok {
+		return n, fmt.Errorf("Unknown strategy %q, try one of: %s", name, strings.Join(Names(), ", "))
+	}
+	return n, nil
+}
+
+// Registered returns every registered strategy, in order of name.
+func Registered() []Named {
+	all := make([]Named, 0, len(registry))
+	for _, n := range registry {
+		all = append(all, n)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+// Names returns the names of the registered strategies, in order.
+func Names() []string {
+	var names []string
+	for _, n := range Registered() {
+		names = append(names, n.Name)
+	}
+	return names
+}
+
+// Options sets up opts for the strategy: the count it needs, unless the game
+// already keeps one, and the unseen cards if it looks at them.
+func (n Named) Options(opts *blackjack.Options) {
+	if opts.Count == nil {
+		opts.Count = n.Count
+	}
+	opts.Composition = opts.Composition || n.Composition
+}
@@ -0,0 +1,97 @@
+package strategy
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// Moment is a decision the player made, frozen so it can be played again
+// differently.
+type Moment struct {
+	Round  int                // Number of the round within Play, from 1
+	At     blackjack.Snapshot // The game just before the decision
+	View   blackjack.GameView // The decision
+	Played blackjack.Move     // What the player did
+	Net    int                // What the round came to
+}
+
+// rewindAI keeps a Moment for each decision of the AI it wraps.
+type rewindAI struct {
+	blackjack.AI
+	frozen  blackjack.Snapshot // The game at the decision being made
+	round   []Moment           // The round's decisions so far
+	moments *[]Moment          // Where finished rounds' decisions go
+}
+
+// Rewind wraps ai so that every decision it makes is appended to moments once
+// its round is over, ready to be branched from for a what-if review.
+func Rewind(ai blackjack.AI, moments *[]Moment) blackjack.AI {
+	return &rewindAI{AI: ai, moments: moments}
+}
+
+// Bet leaves betting to the wrapped AI.
+func (ai *rewindAI) Bet(ctx blackjack.BetContext) int {
+	return blackjack.PlaceBet(ai.AI, ctx)
+}
+
+// Freeze keeps the game as it is before the decision, and passes it on to
+// the wrapped AI if it wants it too.
+func (ai *rewindAI) Freeze(s blackjack.Snapshot) {
+	ai.frozen = s
+	if f, ok := ai.AI.(blackjack.Freezer); ok {
+		f.Freeze(s)
+	}
+}
+
+// Play is PlayView for the bare cards.
+func (ai *rewindAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return ai.PlayView(blackjack.GameView{Hand: hand, Dealer: dealer, CanHit: true})
+}
+
+// PlayView lets the wrapped AI decide and keeps the decision.
+func (ai *rewindAI) PlayView(v blackjack.GameView) blackjack.Move {
+	move := playView(ai.AI, v)
+	if ai.frozen.Mid() {
+		ai.round = append(ai.round, Moment{At: ai.frozen, View: v, Played: move})
+	}
+	ai.frozen = blackjack.Snapshot{}
+	return move
+}
+
+// Settled files the round's decisions and tells the wrapped AI how it went.
+func (ai *rewindAI) Settled(r blackjack.RoundResult) {
+	for _, m := range ai.round {
+		m.Round, m.Net = r.Round, r.Net
+		*ai.moments = append(*ai.moments, m)
+	}
+	ai.round = nil
+	if rw, ok := ai.AI.(blackjack.RoundWatcher); ok {
+		rw.Settled(r)
+	}
+}
+
+// SetRules passes the table rules on to the wrapped AI, if it wants them.
+func (ai *rewindAI) SetRules(r blackjack.Rules) {
+	if rt, ok := ai.AI.(blackjack.RulesTaker); ok {
+		rt.SetRules(r)
+	}
+}
+
+// Count passes the engine's count on to the wrapped AI, if it wants it.
+func (ai *rewindAI) Count(c blackjack.Count) {
+	if cw, ok := ai.AI.(blackjack.CountWatcher); ok {
+		cw.Count(c)
+	}
+}
+
+// Burned passes shown burn cards on to the wrapped AI.
+func (ai *rewindAI) Burned(cards []deck.Card) {
+	if bw, ok := ai.AI.(blackjack.BurnWatcher); ok {
+		bw.Burned(cards)
+	}
+}
+
+// Insure leaves insurance to the wrapped AI.
+func (ai *rewindAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return blackjack.TakeInsurance(ai.AI, hand, dealer)
+}
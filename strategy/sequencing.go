@@ -0,0 +1,171 @@
+package strategy
+
+import (
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// AceSequencer tracks key cards for ace sequencing: it remembers the cards that
+// went into the discard tray just before each ace, and once one of them shows up
+// in the new shoe the ace is expected within the next few cards. This only works
+// with an imperfect shuffle model such as deck.Riffle, since a perfect shuffle
+// leaves no trace of the discard order.
+type AceSequencer struct {
+	Keys   int // Cards before each ace remembered as its keys, 1 by default
+	Window int // Cards after a key within which the ace is expected, 10 by default
+
+	keys  map[deck.Card]int // Remaining key cards from the last shuffle
+	since int               // Cards observed since the last key card, -1 if none is active
+}
+
+// Learn records the key cards of every ace in the discard tray.
+func (s *AceSequencer) Learn(slugs []blackjack.Slug) {
+	n := s.Keys
+	if n == 0 {
+		n = 1
+	}
+	var discards []deck.Card
+	for _, slug := range slugs {
+		discards = append(discards, slug.Cards...)
+	}
+
+	s.keys = make(map[deck.Card]int)
+	s.since = -1
+	for i, card := range discards {
+		if card.Rank != deck.Ace {
+			continue
+		}
+		for k := max(0, i-n); k < i; k++ {
+			s.keys[discards[k]]++
+		}
+	}
+}
+
+// Observe feeds the next card seen out of the shoe to the sequencer.
+func (s *AceSequencer) Observe(card deck.Card) {
+	window := s.Window
+	if window == 0 {
+		window = 10
+	}
+	if s.since >= 0 {
+		s.since++
+		if s.since > window {
+			s.since = -1
+		}
+	}
+	if s.keys[card] > 0 {
+		s.keys[card]--
+		s.since = 0
+	}
+	if card.Rank == deck.Ace {
+		s.since = -1 // The ace we were waiting for (or another one) showed up
+	}
+}
+
+// AceExpected reports whether a key card was seen recently enough that an ace
+// should be among the next cards.
+func (s *AceSequencer) AceExpected() bool {
+	return s.keys != nil && s.since >= 0
+}
+
+// aceSequencingAI wraps another AI and raises its bet when an ace is expected.
+type aceSequencingAI struct {
+	blackjack.AI
+	seq AceSequencer
+	bet int // Bet placed when an ace is expected
+}
+
+// AceSequencing wraps base so that it bets at least bet whenever the key cards
+// say an ace is about to be dealt. Playing decisions are left to base.
+func AceSequencing(base blackjack.AI, bet int) blackjack.AI {
+	return &aceSequencingAI{AI: base, bet: bet}
+}
+
+// Tracked learns the key cards of the new shoe.
+func (ai *aceSequencingAI) Tracked(slugs []blackjack.Slug) {
+	ai.seq.Learn(slugs)
+	if t, ok := ai.AI.(blackjack.ShuffleTracker); ok {
+		t.Tracked(slugs)
+	}
+}
+
+// Bet raises the wrapped AI's bet when an ace is expected.
+func (ai *aceSequencingAI) Bet(ctx blackjack.BetContext) int {
+	bet := blackjack.PlaceBet(ai.AI, ctx)
+	if ai.seq.AceExpected() && ai.bet > bet {
+		return limit(ai.bet, ctx)
+	}
+	return bet
+}
+
+// PlayView leaves the move to base, with the table's view of the hand.
+func (ai *aceSequencingAI) PlayView(v blackjack.GameView) blackjack.Move {
+	return playView(ai.AI, v)
+}
+
+// SetRules passes the table rules on to base.
+func (ai *aceSequencingAI) SetRules(r blackjack.Rules) {
+	if rt, ok := ai.AI.(blackjack.RulesTaker); ok {
+		rt.SetRules(r)
+	}
+}
+
+// Seed passes the seed on to base.
+func (ai *aceSequencingAI) Seed(seed int64) {
+	if s, ok := ai.AI.(blackjack.Seeder); ok {
+		s.Seed(seed)
+	}
+}
+
+// Count passes the engine's count on to base, if it wants it.
+func (ai *aceSequencingAI) Count(c blackjack.Count) {
+	if cw, ok := ai.AI.(blackjack.CountWatcher); ok {
+		cw.Count(c)
+	}
+}
+
+// Composition passes the unseen cards on to base, if it wants them.
+func (ai *aceSequencingAI) Composition(c blackjack.Composition) {
+	if cw, ok := ai.AI.(blackjack.CompositionWatcher); ok {
+		cw.Composition(c)
+	}
+}
+
+// Burned passes shown burn cards on to base.
+func (ai *aceSequencingAI) Burned(cards []deck.Card) {
+	if bw, ok := ai.AI.(blackjack.BurnWatcher); ok {
+		bw.Burned(cards)
+	}
+}
+
+// Freeze passes the game before the decision on to base.
+func (ai *aceSequencingAI) Freeze(s blackjack.Snapshot) {
+	if f, ok := ai.AI.(blackjack.Freezer); ok {
+		f.Freeze(s)
+	}
+}
+
+// Settled passes the round's result on to base.
+func (ai *aceSequencingAI) Settled(r blackjack.RoundResult) {
+	if rw, ok := ai.AI.(blackjack.RoundWatcher); ok {
+		rw.Settled(r)
+	}
+}
+
+// Insure leaves insurance to base.
+func (ai *aceSequencingAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return blackjack.TakeInsurance(ai.AI, hand, dealer)
+}
+
+// Results observes the round's cards before passing them on.
+func (ai *aceSequencingAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	for _, hand := range hands {
+		for _, card := range hand {
+			ai.seq.Observe(card)
+		}
+	}
+	for _, card := range dealer {
+		ai.seq.Observe(card)
+	}
+	ai.AI.Results(hands, dealer)
+}
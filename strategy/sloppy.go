@@ -0,0 +1,79 @@
+package strategy
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// sloppyAI plays another AI's strategy but sometimes gets a hit or stand
+// wrong, like a tired player.
+type sloppyAI struct {
+	blackjack.AI
+	rate float64    // Chance of getting a decision wrong
+	rand *rand.Rand // Source of the mistakes
+}
+
+// Sloppy wraps base so that each hit or stand decision is swapped for the
+// other with probability rate. Its mistakes are random unless the game seeds
+// it through Options.AISeed.
+func Sloppy(base blackjack.AI, rate float64) blackjack.AI {
+	return &sloppyAI{
+		AI:   base,
+		rate: rate,
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func init() {
+	Register(Named{Name: "sloppy", About: "basic strategy with one hit or stand in twenty the wrong way round", New: func(Setup) blackjack.AI { return Sloppy(BasicStrategyAI(), 0.05) }})
+}
+
+// Seed makes the mistakes repeatable, and seeds base too if it takes a seed.
+func (ai *sloppyAI) Seed(seed int64) {
+	ai.rand.Seed(seed)
+	if s, ok := ai.AI.(blackjack.Seeder); ok {
+		s.Seed(seed + 1)
+	}
+}
+
+// Bet leaves betting to base.
+func (ai *sloppyAI) Bet(ctx blackjack.BetContext) int {
+	return blackjack.PlaceBet(ai.AI, ctx)
+}
+
+// Play makes base's move, now and then swapping a hit for a stand or back.
+func (ai *sloppyAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return ai.slip(ai.AI.Play(hand, dealer))
+}
+
+// PlayView is Play for a base that takes a GameView. Split aces that can't be
+// hit are left alone.
+func (ai *sloppyAI) PlayView(v blackjack.GameView) blackjack.Move {
+	move := playView(ai.AI, v)
+	if !v.CanHit {
+		return move
+	}
+	return ai.slip(move)
+}
+
+// Insure leaves insurance to base.
+func (ai *sloppyAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	return blackjack.TakeInsurance(ai.AI, hand, dealer)
+}
+
+// slip swaps a hit for a stand or back, at the AI's rate.
+func (ai *sloppyAI) slip(move blackjack.Move) blackjack.Move {
+	if ai.rand.Float64() >= ai.rate {
+		return move
+	}
+	switch move.String() {
+	case "hit":
+		return blackjack.MoveStand
+	case "stand":
+		return blackjack.MoveHit
+	}
+	return move
+}
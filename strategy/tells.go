@@ -0,0 +1,73 @@
+package strategy
+
+import (
+	"math"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/ev"
+)
+
+// tellAI plays composition-dependent basic strategy, weighing the dealer's
+// possible hands by the dealer's tell.
+type tellAI struct {
+	*chartAI
+	tell *blackjack.Tell // This round's tell, nil if there wasn't one
+}
+
+// TellAI returns an AI that reads the dealer for tells (Options.Tells) and plays
+// the move with the best expected value given what the tell says about the
+// hole card and how far it can be trusted. Without a tell it plays like
+// CDBasicAI, which makes the two a fair comparison of what tells are worth.
+func TellAI() blackjack.AI {
+	return &tellAI{chartAI: BasicStrategyAI().(*chartAI)}
+}
+
+func init() {
+	Register(Named{Name: "tells", About: "composition-dependent strategy that reads the dealer's tells (Options.Tells)", New: func(Setup) blackjack.AI { return TellAI() }})
+}
+
+// Tell takes the round's tell.
+func (ai *tellAI) Tell(t blackjack.Tell) {
+	ai.tell = &t
+}
+
+// PlayView plays the allowed move with the best expected value off the top of a
+// full shoe, given the hand, the upcard and the tell.
+func (ai *tellAI) PlayView(v blackjack.GameView) blackjack.Move {
+	if (ai.chart.Decision(v.Hand, v.Dealer) == 'P' && v.CanSplit) || !v.CanHit {
+		return ai.chartAI.PlayView(v)
+	}
+	shoe := ev.NewShoe(ai.rules).Remove(v.Hand...).Remove(v.Dealer)
+	e := ev.HandGiven(ai.rules, shoe, v.Hand, v.Dealer, v.Hands > 1, ai.likelihood(v.Dealer))
+	e.Split = math.NaN()
+	if !v.CanDouble {
+		e.Double = math.NaN()
+	}
+	if !v.CanSurrender {
+		e.Surrender = math.NaN()
+	}
+	move, _ := e.Best()
+	return move
+}
+
+// likelihood turns the tell into how likely it was under each hole card, nil
+// if there was no tell.
+func (ai *tellAI) likelihood(up deck.Card) *ev.Likelihood {
+	if ai.tell == nil {
+		return nil
+	}
+	var like ev.Likelihood
+	for v := 1; v <= 10; v++ {
+		like[v-1] = 1 - ai.tell.Accuracy
+		if blackjack.Stiff(up, deck.Card{Rank: deck.Rank(v)}) == ai.tell.Stiff {
+			like[v-1] = ai.tell.Accuracy
+		}
+	}
+	return &like
+}
+
+// Results forgets the round's tell.
+func (ai *tellAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	ai.tell = nil
+}
@@ -0,0 +1,100 @@
+package strategy
+
+import (
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+)
+
+// TimedAI plays another AI under a time limit per decision, for bots that
+// can't be trusted to answer, like remote ones. A decision that takes too long
+// is replaced with a safe one: stand, or the table minimum for a bet. While a
+// timed-out call is still running the bot is considered hung and every
+// decision gets the safe default, so the wrapped AI is never called
+// concurrently.
+type TimedAI struct {
+	blackjack.AI
+	limit    time.Duration // Time allowed per decision
+	pending  chan struct{} // Closed when the last call that timed out returns, nil if none
+	timeouts int           // Decisions that timed out
+}
+
+// Timeout wraps ai so that each of its decisions must be made within limit.
+func Timeout(ai blackjack.AI, limit time.Duration) *TimedAI {
+	return &TimedAI{AI: ai, limit: limit}
+}
+
+// Timeouts returns the number of decisions that were replaced by the safe default.
+func (ai *TimedAI) Timeouts() int {
+	return ai.timeouts
+}
+
+// call runs f within the time limit, reporting whether it finished.
+func (ai *TimedAI) call(f func()) bool {
+	if ai.pending != nil {
+		select {
+		case <-ai.pending:
+			ai.pending = nil
+		default:
+			ai.timeouts++
+			return false
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	timer := time.NewTimer(ai.limit)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		ai.pending = done
+		ai.timeouts++
+		return false
+	}
+}
+
+// Bet leaves betting to the wrapped AI, betting the minimum if it's too slow.
+func (ai *TimedAI) Bet(ctx blackjack.BetContext) int {
+	var bet int
+	if !ai.call(func() { bet = blackjack.PlaceBet(ai.AI, ctx) }) {
+		return ctx.MinBet
+	}
+	return bet
+}
+
+// Play makes the wrapped AI's move, standing if it's too slow.
+func (ai *TimedAI) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	var move blackjack.Move
+	if !ai.call(func() { move = ai.AI.Play(hand, dealer) }) {
+		return blackjack.MoveStand
+	}
+	return move
+}
+
+// PlayView is Play for a wrapped AI that takes a GameView.
+func (ai *TimedAI) PlayView(v blackjack.GameView) blackjack.Move {
+	var move blackjack.Move
+	if !ai.call(func() { move = playView(ai.AI, v) }) {
+		return blackjack.MoveStand
+	}
+	return move
+}
+
+// Insure leaves insurance to the wrapped AI, declining it if it's too slow.
+func (ai *TimedAI) Insure(hand []deck.Card, dealer deck.Card) bool {
+	var insure bool
+	if !ai.call(func() { insure = blackjack.TakeInsurance(ai.AI, hand, dealer) }) {
+		return false
+	}
+	return insure
+}
+
+// Results shows the wrapped AI the round, giving up on it if it's too slow.
+func (ai *TimedAI) Results(hands [][]deck.Card, dealer []deck.Card) {
+	ai.call(func() { ai.AI.Results(hands, dealer) })
+}
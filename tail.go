@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/sim"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// tail estimates the chance of a large single-session loss by importance
+// sampling extreme shoes.
+func tail(args []string) {
+	fs := flag.NewFlagSet("tail", flag.ExitOnError)
+	decks := fs.Int("decks", 4, "number of decks used")
+	n := fs.Int("n", 2000, "number of sessions to simulate")
+	hours := fs.Float64("hours", 4, "length of a session in hours")
+	rate := fs.Int("rate", 80, "hands played per hour")
+	loss := fs.Int("loss", 50000, "estimate the chance of losing at least this much in a session")
+	oversample := fs.Float64("oversample", 3, "how much more often extreme shoes are dealt")
+	printer := outputFlags(fs)
+	profile := profileFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+	defer profile()()
+
+	r := sim.RunTail(sim.TailConfig{
+		SessionConfig: sim.SessionConfig{
+			Game:         blackjack.Options{Decks: *decks},
+			Sessions:     *n,
+			Hours:        *hours,
+			HandsPerHour: *rate,
+		},
+		Loss:       *loss,
+		Oversample: *oversample,
+	}, func() blackjack.AI {
+		return strategy.BasicAI(*decks)
+	})
+
+	out.Table([][]string{
+		{fmt.Sprintf("P(losing %d or more):", *loss), fmt.Sprintf("%.5f ± %.5f", r.Probability, r.StdErr)},
+		{"Sessions that hit it:", fmt.Sprintf("%d of %d", r.Hits, *n)},
+		{"Effective sessions:", fmt.Sprintf("%.0f", r.Effective)},
+	})
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Scrimzay/blackjacksimulator/telegram"
+)
+
+// telegramBot runs the Telegram bot until it's stopped.
+func telegramBot(args []string) {
+	fs := flag.NewFlagSet("telegram", flag.ExitOnError)
+	token := fs.String("token", os.Getenv("TELEGRAM_TOKEN"), "bot token from @BotFather, $TELEGRAM_TOKEN by default")
+	decks := fs.Int("decks", 6, "number of decks in each table's shoe")
+	bankroll := fs.Int("bankroll", 1000, "money each chat sits down with, 0 for no limit")
+	minBet := fs.Int("min", 10, "table minimum")
+	maxBet := fs.Int("max", 500, "table maximum, 0 for no limit")
+	maxSim := fs.Int("max-sim", 200000, "most rounds a /sim may ask for")
+	parseFlags(fs, args)
+	if *token == "" {
+		fmt.Fprintln(os.Stderr, "telegram needs a bot token: -token or $TELEGRAM_TOKEN")
+		os.Exit(2)
+	}
+
+	bot := &telegram.Bot{
+		Client:   &telegram.Client{Token: *token},
+		Decks:    *decks,
+		Bankroll: *bankroll,
+		MinBet:   *minBet,
+		MaxBet:   *maxBet,
+		MaxSim:   *maxSim,
+		Log:      os.Stderr,
+	}
+	if err := bot.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
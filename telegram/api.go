@@ -0,0 +1,110 @@
+// Package telegram is a Telegram bot for playing blackjack and running small
+// simulations from a chat, a mobile-friendly demo of the engine. It talks to
+// the Bot API directly with long polling, so it needs nothing but a token from
+// @BotFather.
+package telegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Client calls the Telegram Bot API.
+type Client struct {
+	Token   string       // The bot's token
+	BaseURL string       // API address, https://api.telegram.org if empty
+	HTTP    *http.Client // Client making the calls, one with a suitable timeout if nil
+}
+
+// Update is an incoming update. Only messages are asked for.
+type Update struct {
+	ID      int      `json:"update_id"`
+	Message *Message `json:"message"`
+}
+
+// Message is a chat message.
+type Message struct {
+	Chat struct {
+		ID int64 `json:"id"`
+	} `json:"chat"`
+	Text string `json:"text"`
+}
+
+// APIError is an error reported by the Bot API, such as a bad token.
+type APIError struct {
+	Code        int
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Telegram: %d %s", e.Code, e.Description)
+}
+
+// call posts a method's parameters as JSON and decodes its result into v.
+func (c *Client) call(method string, params, v interface{}) error {
+	base, hc := c.BaseURL, c.HTTP
+	if base == "" {
+		base = "https://api.telegram.org"
+	}
+	if hc == nil {
+		hc = &http.Client{Timeout: 60 * time.Second}
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	resp, err := hc.Post(base+"/bot"+c.Token+"/"+method, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var r struct {
+		OK          bool            `json:"ok"`
+		Code        int             `json:"error_code"`
+		Description string          `json:"description"`
+		Result      json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return fmt.Errorf("Telegram %s: %v", method, err)
+	}
+	if !r.OK {
+		return &APIError{Code: r.Code, Description: r.Description}
+	}
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(r.Result, v)
+}
+
+// Updates waits up to timeout for updates after offset, the last update's ID
+// plus one.
+func (c *Client) Updates(offset int, timeout time.Duration) ([]Update, error) {
+	var updates []Update
+	err := c.call("getUpdates", map[string]interface{}{
+		"offset":          offset,
+		"timeout":         int(timeout.Seconds()),
+		"allowed_updates": []string{"message"},
+	}, &updates)
+	return updates, err
+}
+
+// Send sends text to a chat. With keys, the chat's keyboard is replaced with
+// buttons sending those texts, one row per slice; without, it's removed.
+func (c *Client) Send(chat int64, text string, keys [][]string) error {
+	params := map[string]interface{}{"chat_id": chat, "text": text}
+	if len(keys) > 0 {
+		rows := make([][]map[string]string, len(keys))
+		for i, row := range keys {
+			for _, k := range row {
+				rows[i] = append(rows[i], map[string]string{"text": k})
+			}
+		}
+		params["reply_markup"] = map[string]interface{}{"keyboard": rows, "resize_keyboard": true}
+	} else {
+		params["reply_markup"] = map[string]interface{}{"remove_keyboard": true}
+	}
+	return c.call("sendMessage", params, nil)
+}
@@ -0,0 +1,175 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/stats"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// help is the reply to /start and /help.
+const help = `Let's play blackjack.
+
+/deal 50 - deal a round, betting 50 (the last bet if left out)
+/stop - get up from the table
+/balance - how you're doing
+/sim 10000 - simulate basic strategy for that many rounds
+
+While a hand is being played, answer with the buttons: hit, stand, double, split or surrender.`
+
+// Bot plays blackjack with every chat that talks to it. Each chat has its own
+// table, with its own shoe and bankroll, and simulations run alongside.
+type Bot struct {
+	Client   *Client
+	Decks    int       // Decks in each table's shoe
+	Bankroll int       // Money each chat sits down with, 0 for no limit
+	MinBet   int       // Table minimum
+	MaxBet   int       // Table maximum, 0 for no limit
+	MaxSim   int       // Most rounds a /sim may ask for
+	Log      io.Writer // Where network errors are reported while the bot retries, nowhere if nil
+
+	mu     sync.Mutex
+	tables map[int64]*table
+}
+
+// Run answers chats until the Bot API rejects the bot, e.g. for a bad token.
+// Network errors are logged and retried.
+func (b *Bot) Run() error {
+	offset := 0
+	for {
+		updates, err := b.Client.Updates(offset, 50*time.Second)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			return err
+		}
+		if err != nil {
+			if b.Log != nil {
+				fmt.Fprintln(b.Log, err)
+			}
+			time.Sleep(5 * time.Second)
+			continue
+		}
+		for _, u := range updates {
+			offset = u.ID + 1
+			if u.Message != nil && u.Message.Text != "" {
+				b.handle(u.Message.Chat.ID, u.Message.Text)
+			}
+		}
+	}
+}
+
+// send replies to a chat, logging failures.
+func (b *Bot) send(chat int64, text string, keys [][]string) {
+	if err := b.Client.Send(chat, text, keys); err != nil && b.Log != nil {
+		fmt.Fprintln(b.Log, err)
+	}
+}
+
+// handle acts on a message: commands that don't need a table are answered
+// right away, everything else goes to the chat's table.
+func (b *Bot) handle(chat int64, text string) {
+	cmd, arg, _ := strings.Cut(strings.TrimSpace(text), " ")
+	cmd = strings.ToLower(strings.TrimPrefix(cmd, "/"))
+	if i := strings.IndexByte(cmd, '@'); i >= 0 {
+		cmd = cmd[:i] // /deal@SomeBot in groups
+	}
+	arg = strings.TrimSpace(arg)
+
+	b.mu.Lock()
+	t := b.tables[chat]
+	b.mu.Unlock()
+	switch {
+	case cmd == "start" || cmd == "help":
+		b.send(chat, help, nil)
+	case cmd == "sim":
+		go b.simulate(chat, arg)
+	case cmd == "balance" && t == nil:
+		b.send(chat, "You're not at a table. /deal to sit down.", nil)
+	case cmd == "deal" && t == nil:
+		t = b.sit(chat)
+		fallthrough
+	case t != nil:
+		t.tell(answer{cmd: cmd, arg: arg})
+	default:
+		b.send(chat, "Not sure what you mean. /help lists what I can do.", nil)
+	}
+}
+
+// sit starts a table for a chat, which lasts until the player gets up or goes
+// broke.
+func (b *Bot) sit(chat int64) *table {
+	t := newTable(func(text string, keys [][]string) { b.send(chat, text, keys) })
+	b.mu.Lock()
+	if b.tables == nil {
+		b.tables = make(map[int64]*table)
+	}
+	b.tables[chat] = t
+	b.mu.Unlock()
+
+	game := blackjack.New(blackjack.Options{
+		Decks:           b.Decks,
+		BlackjackPayout: 1.5,
+		Bankroll:        b.Bankroll,
+		MinBet:          b.MinBet,
+		MaxBet:          b.MaxBet,
+	})
+	go func() {
+		won := game.Play(t)
+		b.mu.Lock()
+		delete(b.tables, chat)
+		b.mu.Unlock()
+		msg := fmt.Sprintf("Thanks for playing. You leave %s.", signed(won))
+		if game.Session().Stopped == blackjack.StoppedBroke {
+			msg = "You're out of money. " + msg
+		}
+		b.send(chat, msg, nil)
+	}()
+	return t
+}
+
+// simulate plays basic strategy for the number of rounds in arg and replies
+// with the statistics.
+func (b *Bot) simulate(chat int64, arg string) {
+	rounds := 10000
+	if arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n <= 0 {
+			b.send(chat, "Give /sim a number of rounds, e.g. /sim 10000.", nil)
+			return
+		}
+		rounds = n
+	}
+	if b.MaxSim > 0 && rounds > b.MaxSim {
+		rounds = b.MaxSim
+	}
+	var s stats.Stats
+	g := blackjack.New(blackjack.Options{Decks: b.Decks, Hands: rounds, BlackjackPayout: 1.5, MinBet: b.MinBet, OnRound: s.Add, Reuse: true})
+	g.Play(strategy.BasicStrategyAI())
+	b.send(chat, fmt.Sprintf(`%d rounds of basic strategy, %d decks
+Net: %s on %d wagered
+EV: %.2f%% of the bet
+Per round: %.2f ± %.2f at 95%%, betting the minimum
+Hands won %.1f%%, lost %.1f%%, pushed %.1f%%`,
+		s.Rounds, b.Decks,
+		signed(int(s.Net)), s.Wagered,
+		100*float64(s.Net)/float64(max(s.Wagered, 1)),
+		s.EV(), 1.96*s.StdErr(),
+		pct(s.Wins, s.Hands), pct(s.Losses, s.Hands), pct(s.Pushes, s.Hands)), nil)
+}
+
+// signed writes an amount with its sign.
+func signed(n int) string {
+	return fmt.Sprintf("%+d", n)
+}
+
+// pct returns n as a percentage of of.
+func pct(n, of int64) float64 {
+	return 100 * float64(n) / float64(max(of, 1))
+}
@@ -0,0 +1,160 @@
+package telegram
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/deck"
+	"github.com/Scrimzay/blackjacksimulator/display"
+)
+
+// answer is a message from the player, split into command and argument.
+type answer struct {
+	cmd string
+	arg string
+}
+
+// table is a chat's seat: the AI that asks the player for every decision over
+// the chat and waits for the answer.
+type table struct {
+	out     *display.Printer                   // Renders cards and outcomes, without colors
+	send    func(text string, keys [][]string) // Replies to the chat
+	answers chan answer                        // The player's messages
+	bet     int                                // The bet the next /deal places, 0 for the minimum
+	balance int                                // Won or lost so far
+}
+
+func newTable(send func(string, [][]string)) *table {
+	return &table{
+		out:     &display.Printer{Lang: display.English},
+		send:    send,
+		answers: make(chan answer, 8),
+	}
+}
+
+// tell passes a message on to the table, unless it's swamped.
+func (t *table) tell(a answer) {
+	select {
+	case t.answers <- a:
+	default:
+		t.send("One thing at a time, please.", nil)
+	}
+}
+
+// wait returns the player's next message, answering /balance along the way.
+func (t *table) wait() answer {
+	for {
+		a := <-t.answers
+		if a.cmd != "balance" {
+			return a
+		}
+		t.send(fmt.Sprintf("You're %s so far.", signed(t.balance)), nil)
+	}
+}
+
+// Leave waits for the player to deal the next round or get up.
+func (t *table) Leave() bool {
+	for {
+		a := t.wait()
+		switch a.cmd {
+		case "stop":
+			return true
+		case "deal":
+			if a.arg != "" {
+				bet, err := strconv.Atoi(a.arg)
+				if err != nil {
+					t.send(fmt.Sprintf("%q isn't a bet.", a.arg), nil)
+					continue
+				}
+				t.bet = bet
+			}
+			return false
+		default:
+			t.send("/deal for another round, /stop to get up.", [][]string{{"/deal", "/stop"}})
+		}
+	}
+}
+
+// Bet places the bet the player asked for with /deal, asking again if the
+// table won't take it.
+func (t *table) Bet(ctx blackjack.BetContext) int {
+	if t.bet == 0 {
+		t.bet = ctx.MinBet
+	}
+	for {
+		err := ctx.Check(t.bet)
+		if err == nil {
+			break
+		}
+		t.send(fmt.Sprintf("Can't bet %d: %v. How much, then?", t.bet, err), nil)
+		a := t.wait()
+		amount := a.cmd // Just the number
+		if a.cmd == "deal" {
+			amount = a.arg
+		}
+		if bet, err := strconv.Atoi(amount); err == nil {
+			t.bet = bet
+		}
+	}
+	if ctx.Shuffled {
+		t.send("The dealer shuffles the shoe.", nil)
+	}
+	return t.bet
+}
+
+// PlayView shows the hand and waits for one of the moves the view allows.
+func (t *table) PlayView(v blackjack.GameView) blackjack.Move {
+	moves := map[string]blackjack.Move{"stand": blackjack.MoveStand}
+	keys := []string{"stand"}
+	for _, m := range []struct {
+		ok   bool
+		move blackjack.Move
+	}{
+		{v.CanHit, blackjack.MoveHit},
+		{v.CanDouble, blackjack.MoveDouble},
+		{v.CanSplit, blackjack.MoveSplit},
+		{v.CanSurrender, blackjack.MoveSurrender},
+	} {
+		if m.ok {
+			moves[m.move.String()] = m.move
+			keys = append(keys, m.move.String())
+		}
+	}
+	hand := "You"
+	if v.Hands > 1 {
+		hand = fmt.Sprintf("Hand %d of %d", v.HandIndex+1, v.Hands)
+	}
+	t.send(fmt.Sprintf("%s: %s\nDealer shows %s", hand, t.out.Hand(v.Hand), t.out.Card(v.Dealer)), [][]string{keys})
+	for {
+		a := t.wait()
+		if m, ok := moves[a.cmd]; ok {
+			return m
+		}
+		t.send("Pick one of: "+strings.Join(keys, ", "), [][]string{keys})
+	}
+}
+
+// Play is never called, since the table is a Viewer.
+func (t *table) Play(hand []deck.Card, dealer deck.Card) blackjack.Move {
+	return blackjack.MoveStand
+}
+
+// Results is a no-op: Settled reports the round.
+func (t *table) Results(hands [][]deck.Card, dealer []deck.Card) {}
+
+// Settled tells the player how the round went.
+func (t *table) Settled(r blackjack.RoundResult) {
+	t.balance += r.Net
+	var b strings.Builder
+	fmt.Fprintf(&b, "Dealer: %s\n", t.out.Hand(r.Dealer))
+	for i, h := range r.Hands {
+		if len(r.Hands) > 1 {
+			fmt.Fprintf(&b, "Hand %d: ", i+1)
+		}
+		fmt.Fprintf(&b, "%s. %s (%s)\n", t.out.Hand(h.Cards), t.out.Outcome(h), signed(h.Net))
+	}
+	fmt.Fprintf(&b, "You're %s so far. /deal again?", signed(t.balance))
+	t.send(b.String(), [][]string{{"/deal", "/stop"}})
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/stats"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// tells plays the same shoes without tells and then with dealer tells of
+// each accuracy, to show what imperfect information about the hole card is
+// worth. Every run plays the same tell-reading strategy, which without a tell
+// is composition-dependent basic strategy.
+func tells(args []string) {
+	fs := flag.NewFlagSet("tells", flag.ExitOnError)
+	decks := fs.Int("decks", 6, "number of decks used")
+	hands := fs.Int("hands", 200000, "rounds to play at each accuracy")
+	seed := fs.Int64("seed", 1, "seed the shuffle; every accuracy is dealt the same shoes")
+	accuracies := fs.String("accuracy", "0.6,0.7,0.8,0.9,1", "chances of reading the tell right to try, separated by commas")
+	rules := presetFlags(fs)
+	printer := outputFlags(fs)
+	profile := profileFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+	defer profile()()
+
+	var levels []float64
+	for _, f := range strings.Split(*accuracies, ",") {
+		a, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil || a <= 0 || a > 1 {
+			fmt.Fprintf(os.Stderr, "-accuracy %q: %q isn't a chance\n", *accuracies, f)
+			os.Exit(2)
+		}
+		levels = append(levels, a)
+	}
+	opts := blackjack.Options{Decks: *decks, Hands: *hands, Seed: *seed, BlackjackPayout: 1.5, Reuse: true}
+	if err := rules(&opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	play := func(accuracy float64) stats.Stats {
+		var s stats.Stats
+		o := opts
+		o.OnRound, o.Tells = s.Add, accuracy
+		g := blackjack.New(o)
+		g.Play(strategy.TellAI())
+		return s
+	}
+	none := play(0)
+	rows := [][]string{
+		{"Tells", "EV/100 rounds", "Edge", "Worth"},
+		{"None", fmt.Sprintf("%+.1f ± %.1f", 100*none.EV(), 196*none.StdErr()), fmt.Sprintf("%+.2f%%", edge(none)), "-"},
+	}
+	for _, a := range levels {
+		s := play(a)
+		rows = append(rows, []string{
+			fmt.Sprintf("%.0f%% right", 100*a),
+			fmt.Sprintf("%+.1f ± %.1f", 100*s.EV(), 196*s.StdErr()),
+			fmt.Sprintf("%+.2f%%", edge(s)),
+			fmt.Sprintf("%+.2f%%", edge(s)-edge(none)),
+		})
+	}
+	out.Printf("%d rounds per accuracy, %d decks; the tell says whether the dealer has a stiff\n\n", *hands, opts.Decks)
+	out.Table(rows)
+}
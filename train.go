@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/display"
+	"github.com/Scrimzay/blackjacksimulator/review"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// trainingReport prints how a training session went: the player's decisions
+// against basic strategy and, if they were quizzed, their counting.
+func trainingReport(out *display.Printer, rounds []blackjack.RoundResult, counts strategy.CountScore, decks int) {
+	r := review.Review(rounds, review.Config{Decks: decks})
+	out.Println("\nSession report:")
+	rows := [][]string{
+		{"Rounds:", fmt.Sprint(r.Rounds)},
+		{"Decisions:", fmt.Sprint(len(r.Decisions))},
+		{"Strategy accuracy:", fmt.Sprintf("%.1f%%", 100*r.Accuracy())},
+	}
+	if len(counts) > 0 {
+		rows = append(rows,
+			[]string{"Count checks:", fmt.Sprint(len(counts))},
+			[]string{"Running count exact:", fmt.Sprintf("%.1f%%", 100*counts.Accuracy())},
+			[]string{"Running count off by:", fmt.Sprintf("%.1f on average", counts.MeanError())},
+			[]string{"True count within 0.5:", fmt.Sprintf("%.1f%%", 100*counts.TrueAccuracy())},
+		)
+	}
+	out.Table(rows)
+	for i, l := range r.Leaks {
+		if i == 0 {
+			out.Println("\nWork on:")
+		}
+		if i == 3 {
+			break
+		}
+		out.Printf("  %s: you played %s, basic strategy says %s (%d times)\n", l.Situation, l.Played, l.Correct, l.Times)
+	}
+}
+
+// whatIfReview lists the session's decisions and replays the rounds the
+// player picks, showing what every move open to them was worth and how the
+// round would have ended with it.
+func whatIfReview(out *display.Printer, moments []strategy.Moment) {
+	if len(moments) == 0 {
+		return
+	}
+	alts := make([][]review.Alternative, len(moments))
+	rows := [][]string{{"round", "hand", "dealer", "played", "best", "net"}}
+	for i, m := range moments {
+		alts[i] = review.WhatIf(m)
+		best, mark := "-", ""
+		if len(alts[i]) > 0 {
+			best = alts[i][0].Move.String()
+			if !alts[i][0].Played {
+				mark = " *"
+			}
+		}
+		rows = append(rows, []string{fmt.Sprint(m.Round), out.Hand(m.View.Hand), out.Card(m.View.Dealer), m.Played.String() + mark, best, out.Money(m.Net)})
+	}
+	out.Println("\nYour decisions (* where another move was worth more):")
+	out.Table(rows)
+
+	for {
+		out.Println("\nRound to replay (0 to finish):")
+		var round int
+		if _, err := fmt.Fscanf(out.Input(), "%d\n", &round); err != nil || round <= 0 {
+			return
+		}
+		found := false
+		for i, m := range moments {
+			if m.Round != round {
+				continue
+			}
+			found = true
+			out.Printf("\n%s against %s, you played %s:\n", out.Hand(m.View.Hand), out.Card(m.View.Dealer), m.Played)
+			rows := [][]string{{"move", "EV", "round would have come to", ""}}
+			for _, a := range alts[i] {
+				note := ""
+				if a.Played {
+					note = "what you did"
+				}
+				rows = append(rows, []string{a.Move.String(), fmt.Sprintf("%+.3f", a.EV), out.Money(a.Result.Net), note})
+			}
+			out.Table(rows)
+		}
+		if !found {
+			out.Printf("You made no decisions in round %d.\n", round)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/count"
+	"github.com/Scrimzay/blackjacksimulator/sim"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// trip plays many trips through a schedule of shifts whose table conditions
+// drift, and prints how the trips went and how each shift did.
+func trip(args []string) {
+	fs := flag.NewFlagSet("trip", flag.ExitOnError)
+	n := fs.Int("n", 1000, "number of trips to simulate")
+	bankroll := fs.Int("bankroll", 0, "money brought on the trip, carried from shift to shift; the trip ends when it can't cover a bet (0 to play on regardless)")
+	spread := fs.Int("spread", 0, "bet the table minimum times the hi-lo true count, up to this many times (0 to flat bet the minimum)")
+	seed := fs.Int64("seed", 0, "seed the shuffles and the dealers for repeatable trips, random if 0")
+	printer := outputFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: trip [flags] schedule")
+		os.Exit(2)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	shifts, err := sim.LoadSchedule(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", fs.Arg(0), err)
+		os.Exit(2)
+	}
+
+	game := blackjack.Options{Bankroll: *bankroll, Seed: *seed, Reuse: true}
+	if *spread > 0 {
+		game.Count = count.HiLo
+		game.Bettor = strategy.Ramp{Spread: *spread}
+	}
+	r := sim.RunTrips(sim.TripConfig{Game: game, Shifts: shifts, Trips: *n}, strategy.BasicStrategyAI)
+
+	out.Printf("%d trips of %g hours in %d shifts, %d hands on average\n\n", len(r.Results), r.Hours, len(shifts), r.Hands)
+	rows := [][]string{
+		{"Average result:", out.Money(int(r.Mean))},
+		{"Per hour:", out.Average(r.PerHour())},
+		{"Standard deviation:", out.Amount(int(r.StdDev))},
+		{"Winning trips:", fmt.Sprintf("%.1f%%", 100*r.WinProbability())},
+		{"Losing trips:", fmt.Sprintf("%.1f%%", 100*r.LossProbability())},
+	}
+	if *bankroll > 0 {
+		rows = append(rows, []string{"Went broke:", fmt.Sprintf("%.1f%%", 100*r.StopRate(blackjack.StoppedBroke))})
+	}
+	out.Table(rows)
+
+	out.Println("\nShifts:")
+	rows = [][]string{{"Shift", "Hours", "Limits", "Played", "Hands", "Avg bet", "Result", "Per hour", "Broke"}}
+	for _, s := range r.Shifts {
+		minBet := s.Table.MinBet
+		if minBet == 0 {
+			minBet = 100
+		}
+		limits := out.Amount(minBet) + "-"
+		if s.Table.MaxBet > 0 {
+			limits += out.Amount(s.Table.MaxBet)
+		}
+		rows = append(rows, []string{
+			s.Name,
+			fmt.Sprintf("%g", s.Hours),
+			limits,
+			fmt.Sprint(s.Played),
+			fmt.Sprint(s.Hands),
+			out.Average(s.AvgBet),
+			out.Average(s.Mean),
+			out.Average(s.PerHour()),
+			fmt.Sprint(s.Broke),
+		})
+	}
+	out.Table(rows)
+
+	out.Println("\nPercentiles:")
+	rows = rows[:0]
+	for _, p := range []float64{1, 5, 25, 50, 75, 95, 99} {
+		rows = append(rows, []string{fmt.Sprintf("%g%%", p), out.Money(r.Percentile(p))})
+	}
+	out.Table(rows)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/Scrimzay/blackjacksimulator/validate"
+)
+
+// validateRules plays the canonical rule sets and checks the house edge of
+// each against the published figure, and the baselines' against the edges
+// worked out for them, exiting with an error if any is off.
+func validateRules(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	hands := fs.Int("hands", 5000000, "rounds to play for each rule set")
+	seed := fs.Int64("seed", 1, "seed the shuffle (0 for random)")
+	baselines := fs.Bool("baselines", true, "also check the baseline strategies against the edges worked out for them")
+	printer := outputFlags(fs)
+	profile := profileFlags(fs)
+	parseFlags(fs, args)
+	out := printer()
+	defer profile()()
+
+	cases := validate.Cases
+	if *baselines {
+		cases = append(cases[:len(cases):len(cases)], validate.BaselineCases(validate.Cases[0])...)
+	}
+	results := make([]validate.Result, len(cases))
+	var wg sync.WaitGroup
+	for i, c := range cases {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = validate.Run(c, *hands, *seed)
+		}()
+	}
+	wg.Wait()
+
+	rows := [][]string{{"Rules", "Expected", "Measured", "Allowed", ""}}
+	failed := 0
+	for _, r := range results {
+		verdict := "ok"
+		if !r.Pass() {
+			verdict, failed = "FAIL", failed+1
+		}
+		if r.Err != nil {
+			verdict += ": " + r.Err.Error()
+		}
+		rows = append(rows, []string{r.Name, fmt.Sprintf("%.2f%%", r.Edge), fmt.Sprintf("%.2f%% ± %.2f", r.Measured, r.StdErr), fmt.Sprintf("± %.2f", r.Tolerance), verdict})
+	}
+	out.Printf("House edge of basic strategy, or the baseline named, %d rounds per rule set:\n\n", *hands)
+	out.Table(rows)
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d rule sets are off their published edge\n", failed, len(results))
+		os.Exit(1)
+	}
+}
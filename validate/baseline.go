@@ -0,0 +1,164 @@
+package validate
+
+import "github.com/Scrimzay/blackjacksimulator/blackjack"
+
+// Baseline is a strategy that only ever hits or stands, by the total of its
+// own hand, so its house edge follows from the odds of the cards alone rather
+// than from a published figure.
+type Baseline struct {
+	Strategy string                                             // Name the strategy is registered under
+	Stands   func(r blackjack.Rules, total int, soft bool) bool // Whether it stands on a total
+	Slack    float64                                            // How far a six deck shoe takes the edge from Edge's, in percent
+}
+
+// Baselines are the baseline strategies checked by default.
+var Baselines = []Baseline{
+	{Strategy: "always-stand", Stands: func(blackjack.Rules, int, bool) bool { return true }, Slack: 0.1},
+	{Strategy: "always-hit", Stands: func(blackjack.Rules, int, bool) bool { return false }, Slack: 0.1},
+	{Strategy: "mimic-dealer", Stands: func(r blackjack.Rules, total int, soft bool) bool {
+		return standsOn(total, soft, r.StandSoft17)
+	}, Slack: 0.1},
+	{Strategy: "never-bust", Stands: func(r blackjack.Rules, total int, soft bool) bool {
+		return total >= 18 || total >= 12 && !soft
+	}, Slack: 0.1},
+}
+
+// BaselineCases returns a case for each baseline under the rules of c.
+func BaselineCases(c Case) []Case {
+	g := blackjack.New(c.Options)
+	rules := g.Rules()
+	var cases []Case
+	for _, b := range Baselines {
+		cases = append(cases, Case{
+			Name:     c.Name + ", " + b.Strategy,
+			Options:  c.Options,
+			Strategy: b.Strategy,
+			Edge:     b.Edge(rules),
+			Slack:    b.Slack,
+		})
+	}
+	return cases
+}
+
+// Edge works out the baseline's house edge under the rules, in percent of the
+// amount wagered, for a shoe so big that the cards dealt don't change the odds
+// of the next: the figure the edge tends to as decks are added. The dealer
+// peeks for blackjack and Push22 isn't taken into account. A natural is only
+// paid if the baseline stands on it; the engine lets it be hit like any 21.
+func (b Baseline) Edge(r blackjack.Rules) float64 {
+	odds := cardOdds(r)
+	ev := 0.0
+	for up := 1; up <= 10; up++ {
+		dealer, dealerBJ := dealerTotals(r, odds, up)
+		for first := 1; first <= 10; first++ {
+			for second := 1; second <= 10; second++ {
+				p := odds[up] * odds[first] * odds[second]
+				natural := first+second == 11 && (first == 1 || second == 1)
+				if natural && b.Stands(r, 21, true) {
+					ev += p * (1 - dealerBJ) * r.BlackjackPayout
+					continue
+				}
+				var player [23]float64
+				b.play(r, odds, &player, first+second, first == 1 || second == 1, 1)
+				lost := dealerBJ
+				if natural {
+					lost = 0 // The dealer's blackjack pushes it before it's played
+				}
+				ev += p * (-lost + (1-dealerBJ)*settle(r, &player, &dealer))
+			}
+		}
+	}
+	return -100 * ev
+}
+
+// cardOdds returns the chance of each card value, 1 for an ace to 10, in a
+// shoe of the rules' variant.
+func cardOdds(r blackjack.Rules) [11]float64 {
+	var odds [11]float64
+	shoe := r.Variant.Shoe(1)
+	for _, c := range shoe {
+		odds[c.BlackjackValue()] += 1 / float64(len(shoe))
+	}
+	return odds
+}
+
+// score returns the best total of a hand whose cards add up to sum counting
+// aces as one, and whether it's soft.
+func score(sum int, ace bool) (int, bool) {
+	if ace && sum+10 <= 21 {
+		return sum + 10, true
+	}
+	return sum, false
+}
+
+// standsOn reports whether the dealer stands on a total.
+func standsOn(total int, soft, standSoft17 bool) bool {
+	return total > 17 || total == 17 && (standSoft17 || !soft)
+}
+
+// play adds the chances of the baseline's final totals to totals, busts at
+// 22, from a hand adding up to sum with aces as one reached with chance p.
+func (b Baseline) play(r blackjack.Rules, odds [11]float64, totals *[23]float64, sum int, ace bool, p float64) {
+	total, soft := score(sum, ace)
+	switch {
+	case total > 21:
+		totals[22] += p
+	case b.Stands(r, total, soft):
+		totals[total] += p
+	default:
+		for v := 1; v <= 10; v++ {
+			b.play(r, odds, totals, sum+v, ace || v == 1, p*odds[v])
+		}
+	}
+}
+
+// dealerTotals returns the chances of the dealer's final totals with the
+// upcard, busts at 22, given the dealer doesn't have blackjack, and the chance
+// the dealer does.
+func dealerTotals(r blackjack.Rules, odds [11]float64, up int) (totals [23]float64, natural float64) {
+	for hole := 1; hole <= 10; hole++ {
+		if up+hole == 11 && (up == 1 || hole == 1) {
+			natural += odds[hole]
+			continue
+		}
+		dealerDraw(r, odds, &totals, up+hole, up == 1 || hole == 1, odds[hole])
+	}
+	for t := range totals {
+		totals[t] /= 1 - natural
+	}
+	return totals, natural
+}
+
+// dealerDraw adds the chances of the dealer's final totals from a hand adding
+// up to sum with aces as one, reached with chance p.
+func dealerDraw(r blackjack.Rules, odds [11]float64, totals *[23]float64, sum int, ace bool, p float64) {
+	total, soft := score(sum, ace)
+	switch {
+	case total > 21:
+		totals[22] += p
+	case standsOn(total, soft, r.StandSoft17):
+		totals[total] += p
+	default:
+		for v := 1; v <= 10; v++ {
+			dealerDraw(r, odds, totals, sum+v, ace || v == 1, p*odds[v])
+		}
+	}
+}
+
+// settle returns the player's expected result from the chances of the final
+// totals of the player and of a dealer without blackjack.
+func settle(r blackjack.Rules, player, dealer *[23]float64) float64 {
+	ev := -player[22]
+	for p := 4; p <= 21; p++ {
+		for d := 17; d <= 22; d++ {
+			chance := player[p] * dealer[d]
+			switch {
+			case d == 22 || p > d:
+				ev += chance
+			case p < d || r.DealerWinsTies:
+				ev -= chance
+			}
+		}
+	}
+	return ev
+}
@@ -0,0 +1,78 @@
+// Package validate checks the engine against published house edges: it plays
+// well-known rule sets with basic strategy and compares the edge it measures
+// with the figure quoted for those rules. A rule implemented wrong shows up as
+// an edge outside the tolerance. Baselines do the same for strategies simple
+// enough to work the edge out for.
+package validate
+
+import (
+	"math"
+
+	"github.com/Scrimzay/blackjacksimulator/blackjack"
+	"github.com/Scrimzay/blackjacksimulator/stats"
+	"github.com/Scrimzay/blackjacksimulator/strategy"
+)
+
+// Case is a rule set with a published house edge.
+type Case struct {
+	Name     string
+	Options  blackjack.Options // The rules; Run sets the rounds, seed and OnRound
+	Strategy string            // Registered strategy played, basic strategy if empty
+	Edge     float64           // Published house edge of the strategy, in percent of the amount wagered
+	Slack    float64           // How far published figures for the rules differ, in percent
+}
+
+// Cases are the rule sets checked by default. The edges are the usual
+// published figures for basic strategy (Wizard of Odds), with peeking, splits
+// to four hands and no resplitting aces or surrender, which is how the engine
+// deals by default.
+var Cases = []Case{
+	{Name: "6D H17 DAS 3:2", Options: blackjack.Options{Decks: 6, BlackjackPayout: 1.5}, Edge: 0.64, Slack: 0.05},
+	{Name: "6D S17 DAS 3:2", Options: blackjack.Options{Decks: 6, BlackjackPayout: 1.5, StandSoft17: true}, Edge: 0.42, Slack: 0.05},
+	{Name: "8D H17 DAS 3:2", Options: blackjack.Options{Decks: 8, BlackjackPayout: 1.5}, Edge: 0.66, Slack: 0.05},
+	{Name: "6D H17 DAS 6:5", Options: blackjack.Options{Decks: 6, BlackjackPayout: 1.2}, Edge: 2.00, Slack: 0.1},
+}
+
+// Result is how a case came out.
+type Result struct {
+	Case
+	Rounds    int64
+	Measured  float64 // House edge measured, in percent of the amount wagered
+	StdErr    float64 // Standard error of Measured
+	Tolerance float64 // Widest gap allowed: three standard errors plus the case's slack
+	Err       error   // Anything Stats.Check found wrong with the totals
+}
+
+// Pass reports whether the measured edge is within tolerance of the published
+// one and the totals hold together.
+func (r Result) Pass() bool {
+	return r.Err == nil && math.Abs(r.Measured-r.Edge) <= r.Tolerance
+}
+
+// Run plays a case with its strategy for the given number of rounds.
+func Run(c Case, hands int, seed int64) Result {
+	var s stats.Stats
+	name := c.Strategy
+	if name == "" {
+		name = "basic"
+	}
+	named, err := strategy.Lookup(name)
+	if err != nil {
+		return Result{Case: c, Err: err}
+	}
+	opts := c.Options
+	opts.Hands, opts.Seed, opts.OnRound, opts.Reuse = hands, seed, s.Add, true
+	named.Options(&opts)
+	g := blackjack.New(opts)
+	g.Play(named.New(strategy.Setup{Decks: opts.Decks}))
+	bet := float64(s.Wagered) / float64(max(s.Rounds, 1))
+	r := Result{
+		Case:     c,
+		Rounds:   s.Rounds,
+		Measured: -100 * float64(s.Net) / float64(max(s.Wagered, 1)),
+		StdErr:   100 * s.StdErr() / max(bet, 1),
+		Err:      s.Check(),
+	}
+	r.Tolerance = 3*r.StdErr + c.Slack
+	return r
+}